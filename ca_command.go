@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/wonderlandcompute/server/wonderland"
+	"golang.org/x/net/context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+)
+
+const caUsage = `usage: wonderland_server ca <command> [flags]
+
+commands:
+  init    create a new certificate authority
+  sign    sign a CSR with the CA configured by ca_cert and ca_key
+
+Run "wonderland_server ca <command> -h" for the flags of a command.
+`
+
+// grantsFlag collects repeated -grant flags.
+type grantsFlag []string
+
+func (g *grantsFlag) String() string {
+	return strings.Join(*g, ",")
+}
+
+func (g *grantsFlag) Set(value string) error {
+	*g = append(*g, value)
+	return nil
+}
+
+func loadCertificateAuthority() (*wonderland.CertificateAuthority, error) {
+	ca, err := wonderland.LoadCertificateAuthority(Config.CACert, Config.CAKey)
+	if err != nil {
+		return nil, err
+	}
+	if Config.CertMaxLifetime != 0 {
+		ca.MaxLifetime = Config.CertMaxLifetime
+	}
+	return ca, nil
+}
+
+// caCommand runs the "ca" subcommand and returns the exit code.
+func caCommand(args []string) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "init":
+			return caInit(args[1:])
+		case "sign":
+			return caSign(args[1:])
+		}
+	}
+	fmt.Fprint(os.Stderr, caUsage)
+	return 2
+}
+
+func caInit(args []string) int {
+	flags := flag.NewFlagSet("ca init", flag.ExitOnError)
+	commonName := flags.String("cn", "wonderland", "common name of the CA")
+	certFile := flags.String("cert", "ca.crt", "where to write the CA certificate")
+	keyFile := flags.String("key", "ca.key", "where to write the CA private key")
+	lifetime := flags.Duration("lifetime", 10*365*24*time.Hour, "validity of the CA certificate")
+	flags.Parse(args)
+
+	certPEM, keyPEM, err := wonderland.GenerateCertificateAuthority(*commonName, *lifetime)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating CA: %v\n", err)
+		return 1
+	}
+	for _, f := range []struct {
+		path    string
+		content []byte
+		mode    os.FileMode
+	}{{*keyFile, keyPEM, 0600}, {*certFile, certPEM, 0644}} {
+		out, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, f.mode)
+		if err == nil {
+			_, err = out.Write(f.content)
+			out.Close()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", f.path, err)
+			return 1
+		}
+	}
+	fmt.Printf("Created %s and %s, set them as ca_cert and ca_key in the server config\n", *certFile, *keyFile)
+	return 0
+}
+
+func caSign(args []string) int {
+	flags := flag.NewFlagSet("ca sign", flag.ExitOnError)
+	csrFile := flags.String("csr", "", "PEM encoded certificate signing request")
+	lifetime := flags.Duration("lifetime", 0, "validity of the certificate (server default if not set)")
+	outFile := flags.String("out", "", "where to write the certificate (stdout if not set)")
+	var grants grantsFlag
+	flags.Var(&grants, "grant", "project.kind grant, may be repeated")
+	flags.Parse(args)
+
+	loadConfig()
+	ca, err := loadCertificateAuthority()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading CA: %v\n", err)
+		return 1
+	}
+
+	csrPEM, err := ioutil.ReadFile(*csrFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading CSR: %v\n", err)
+		return 1
+	}
+	csr, err := wonderland.ParseCSR(string(csrPEM))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	parsedGrants, err := wonderland.ParseGrants(grants)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	cert, err := ca.Sign(csr, parsedGrants, *lifetime)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	issued := wonderland.IssuedCertificateFrom(cert, "ca-command")
+	if Config.DatabaseURI != "" {
+		storage, err := wonderland.NewWonderlandStorage(Config.DatabaseURI)
+		if err == nil {
+			err = storage.RecordIssuedCertificate(context.Background(), issued, "")
+			storage.Close()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error recording the certificate: %v\n", err)
+			return 1
+		}
+	}
+
+	if *outFile == "" {
+		fmt.Print(issued.Certificate)
+		return 0
+	}
+	err = ioutil.WriteFile(*outFile, []byte(issued.Certificate), 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", *outFile, err)
+		return 1
+	}
+	return 0
+}
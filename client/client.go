@@ -0,0 +1,189 @@
+// Package client is a Go SDK for the Wonderland job server. It wraps the
+// generated WonderlandClient with mTLS dialing, retries of idempotent calls
+// and a few higher level helpers.
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/wonderlandcompute/server/wonderland"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	defaultMaxRetries   = 5
+	defaultRetryBackoff = 100 * time.Millisecond
+	defaultPollInterval = time.Second
+)
+
+type Config struct {
+	ClientCert string `yaml:"client_cert"`
+	ClientKey  string `yaml:"client_key"`
+	CACert     string `yaml:"ca_cert"`
+	ConnectTo  string `yaml:"connect_to"`
+
+	// Token is a bearer token sent with every call. The client certificate
+	// may be left out when it is set.
+	Token string `yaml:"token"`
+
+	// MaxRetries is how many times an idempotent call is retried when the
+	// server is unavailable. RetryBackoff is the delay before the first
+	// retry, doubled after every attempt.
+	MaxRetries   uint          `yaml:"max_retries"`
+	RetryBackoff time.Duration `yaml:"retry_backoff"`
+
+	// PollInterval is used by SubmitAndWait and Worker while waiting for jobs.
+	PollInterval time.Duration `yaml:"poll_interval"`
+}
+
+func LoadConfig(path string) (*Config, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	err = yaml.Unmarshal(content, config)
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (c *Config) setDefaults() {
+	if c.MaxRetries == 0 {
+		c.MaxRetries = defaultMaxRetries
+	}
+	if c.RetryBackoff == 0 {
+		c.RetryBackoff = defaultRetryBackoff
+	}
+	if c.PollInterval == 0 {
+		c.PollInterval = defaultPollInterval
+	}
+}
+
+func getTransportCredentials(config *Config) (credentials.TransportCredentials, error) {
+	var certificates []tls.Certificate
+	if config.ClientCert != "" || config.Token == "" {
+		peerCert, err := tls.LoadX509KeyPair(config.ClientCert, config.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, peerCert)
+	}
+	caCert, err := ioutil.ReadFile(config.CACert)
+	if err != nil {
+		return nil, err
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no certificates found in %s", config.CACert)
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: certificates,
+		RootCAs:      caCertPool,
+	}), nil
+}
+
+// tokenCredentials sends a bearer token in the authorization metadata.
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// Client is a connection to the Wonderland server. All generated
+// WonderlandClient methods are available on it directly.
+type Client struct {
+	wonderland.WonderlandClient
+	conn   *grpc.ClientConn
+	config Config
+}
+
+// Dial connects to config.ConnectTo using the client certificate or token
+// from config.
+// Extra dial options are appended after the ones set up by Dial.
+func Dial(config *Config, opts ...grpc.DialOption) (*Client, error) {
+	cfg := *config
+	cfg.setDefaults()
+
+	tc, err := getTransportCredentials(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(tc),
+		grpc.WithUnaryInterceptor(UnaryRetryInterceptor(cfg.MaxRetries, cfg.RetryBackoff)),
+		grpc.WithStreamInterceptor(StreamRetryInterceptor(cfg.MaxRetries, cfg.RetryBackoff)),
+	}
+	if cfg.Token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(tokenCredentials(cfg.Token)))
+	}
+	conn, err := grpc.Dial(cfg.ConnectTo, append(dialOpts, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		WonderlandClient: wonderland.NewWonderlandClient(conn),
+		conn:             conn,
+		config:           cfg,
+	}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// IsFinished reports whether a job in the given status will not be picked up
+// or changed by workers anymore.
+func IsFinished(status wonderland.Job_Status) bool {
+	switch status {
+	case wonderland.Job_COMPLETED, wonderland.Job_FAILED, wonderland.Job_KILLED, wonderland.Job_EXPIRED:
+		return true
+	}
+	return false
+}
+
+// SubmitAndWait creates the job and polls it until it is finished or ctx is
+// done. The last seen state of the job is returned in both cases.
+func (c *Client) SubmitAndWait(ctx context.Context, job *wonderland.Job) (*wonderland.Job, error) {
+	return submitAndWait(ctx, c.WonderlandClient, job, c.config.PollInterval)
+}
+
+func submitAndWait(ctx context.Context, c wonderland.WonderlandClient, job *wonderland.Job, pollInterval time.Duration) (*wonderland.Job, error) {
+	created, err := c.CreateJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	current := created
+	for !IsFinished(current.Status) {
+		select {
+		case <-ctx.Done():
+			return current, ctx.Err()
+		case <-ticker.C:
+		}
+		next, err := c.GetJob(ctx, &wonderland.RequestWithId{Id: created.Id})
+		if err != nil {
+			return current, err
+		}
+		current = next
+	}
+	return current, nil
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/wonderlandcompute/server/wonderland"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// idempotentMethods are safe to send again when the first attempt may have
+// reached the server. CreateJob, CreateJobFromTemplate, CreateArrayJob and
+// PullPendingJobs are deliberately absent: repeating them would create or
+// pull extra jobs, unless the job has an idempotency key (see isIdempotent).
+var idempotentMethods = map[string]bool{
+	"/Wonderland/GetJob":    true,
+	"/Wonderland/ListJobs":  true,
+	"/Wonderland/ModifyJob": true,
+	"/Wonderland/KillJob":   true,
+
+	"/Wonderland/ReportProgress": true,
+	"/Wonderland/WatchJob":       true,
+
+	"/Wonderland/ListRoleBindings": true,
+	"/Wonderland/ListAuditEvents":  true,
+	"/Wonderland/ListSchedules":    true,
+
+	"/Wonderland/ListWebhooks":          true,
+	"/Wonderland/ListWebhookDeliveries": true,
+	"/Wonderland/GetKind":               true,
+	"/Wonderland/ListKinds":             true,
+	"/Wonderland/ListJobTemplates":      true,
+	"/Wonderland/GetArrayJob":           true,
+	"/Wonderland/KillArrayJob":          true,
+
+	"/Wonderland/SummarizeFailures": true,
+	"/Wonderland/TailJobLogs":       true,
+	"/Wonderland/StreamEvents":      true,
+}
+
+func isIdempotent(method string, req interface{}) bool {
+	if method == "/Wonderland/CreateJob" {
+		job, ok := req.(*wonderland.Job)
+		return ok && job.IdempotencyKey != ""
+	}
+	if method == "/Wonderland/CreateJobFromTemplate" {
+		in, ok := req.(*wonderland.CreateJobFromTemplateRequest)
+		return ok && in.IdempotencyKey != ""
+	}
+	return idempotentMethods[method]
+}
+
+func isRetryable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
+
+// maxBackoffDelay caps the delay between retries before jitter.
+const maxBackoffDelay = time.Minute
+
+// backoffDelay returns the delay before retry number attempt (starting at 0),
+// doubling base every time up to maxBackoffDelay and adding up to 50% of
+// jitter.
+func backoffDelay(base time.Duration, attempt uint) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	delay := maxBackoffDelay
+	// compared before shifting, as base << attempt overflows
+	if base <= maxBackoffDelay>>attempt {
+		delay = base << attempt
+	}
+	return delay + time.Duration(rand.Int63n(int64(delay)/2+1))
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// UnaryRetryInterceptor retries idempotent unary calls failing with
+// codes.Unavailable up to maxRetries times with exponential backoff.
+func UnaryRetryInterceptor(maxRetries uint, backoff time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if !isIdempotent(method, req) {
+			return err
+		}
+		for attempt := uint(0); attempt < maxRetries && isRetryable(err); attempt++ {
+			if sleepErr := sleepCtx(ctx, backoffDelay(backoff, attempt)); sleepErr != nil {
+				return err
+			}
+			err = invoker(ctx, method, req, reply, cc, opts...)
+		}
+		return err
+	}
+}
+
+// StreamRetryInterceptor retries opening a stream for idempotent methods.
+// Once the stream is established errors are returned to the caller as is.
+func StreamRetryInterceptor(maxRetries uint, backoff time.Duration) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if !idempotentMethods[method] {
+			return stream, err
+		}
+		for attempt := uint(0); attempt < maxRetries && isRetryable(err); attempt++ {
+			if sleepErr := sleepCtx(ctx, backoffDelay(backoff, attempt)); sleepErr != nil {
+				return nil, err
+			}
+			stream, err = streamer(ctx, desc, cc, method, opts...)
+		}
+		return stream, err
+	}
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wonderlandcompute/server/wonderland"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func checkTestErr(err error, t *testing.T) {
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+}
+
+// failingInvoker fails the first failures calls with code and succeeds after.
+func failingInvoker(failures int, code codes.Code, calls *int) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*calls++
+		if *calls <= failures {
+			return status.Error(code, "failing")
+		}
+		return nil
+	}
+}
+
+func TestRetryIdempotentCall(t *testing.T) {
+	interceptor := UnaryRetryInterceptor(3, time.Millisecond)
+	calls := 0
+	err := interceptor(context.Background(), "/Wonderland/GetJob", nil, nil, nil, failingInvoker(2, codes.Unavailable, &calls))
+	checkTestErr(err, t)
+	if calls != 3 {
+		t.Fail()
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	interceptor := UnaryRetryInterceptor(3, time.Millisecond)
+	calls := 0
+	err := interceptor(context.Background(), "/Wonderland/GetJob", nil, nil, nil, failingInvoker(10, codes.Unavailable, &calls))
+	if status.Code(err) != codes.Unavailable || calls != 4 {
+		t.Fail()
+	}
+}
+
+func TestNoRetryForNonIdempotentCall(t *testing.T) {
+	interceptor := UnaryRetryInterceptor(3, time.Millisecond)
+	calls := 0
+	err := interceptor(context.Background(), "/Wonderland/CreateJob", nil, nil, nil, failingInvoker(1, codes.Unavailable, &calls))
+	if status.Code(err) != codes.Unavailable || calls != 1 {
+		t.Fail()
+	}
+}
+
+func TestNoRetryForOtherCodes(t *testing.T) {
+	interceptor := UnaryRetryInterceptor(3, time.Millisecond)
+	calls := 0
+	err := interceptor(context.Background(), "/Wonderland/GetJob", nil, nil, nil, failingInvoker(1, codes.PermissionDenied, &calls))
+	if status.Code(err) != codes.PermissionDenied || calls != 1 {
+		t.Fail()
+	}
+}
+
+func TestRetryCreateJobWithIdempotencyKey(t *testing.T) {
+	interceptor := UnaryRetryInterceptor(3, time.Millisecond)
+	calls := 0
+	job := &wonderland.Job{Kind: "docker", IdempotencyKey: "nightly-2018-04-16"}
+	err := interceptor(context.Background(), "/Wonderland/CreateJob", job, nil, nil, failingInvoker(1, codes.Unavailable, &calls))
+	checkTestErr(err, t)
+	if calls != 2 {
+		t.Fail()
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	for _, attempt := range []uint{0, 3, 40, 64, 1000} {
+		delay := backoffDelay(time.Second, attempt)
+		if delay < time.Second || delay > maxBackoffDelay*3/2 {
+			t.Errorf("delay before retry %d is %v", attempt, delay)
+		}
+	}
+	if backoffDelay(0, 5) != 0 {
+		t.Fail()
+	}
+}
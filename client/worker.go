@@ -0,0 +1,292 @@
+package client
+
+import (
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+	"github.com/wonderlandcompute/server/wonderland"
+	"golang.org/x/net/context"
+)
+
+const (
+	defaultConcurrency       = 1
+	defaultHeartbeatInterval = 10 * time.Second
+	defaultShutdownTimeout   = 30 * time.Second
+
+	// the server rejects longer job errors
+	maxErrorMessageLength = 4 * 1024
+	maxErrorDetailsLength = 64 * 1024
+)
+
+// HandlerFunc runs a single job. The returned string becomes the job output,
+// an error fails the job and becomes its output and error, see Failure.
+// ctx is cancelled when the job gets killed, exceeds its max_runtime or the
+// worker gives up waiting for it during shutdown.
+type HandlerFunc func(ctx context.Context, job *wonderland.Job) (string, error)
+
+// Worker pulls jobs of the registered kinds, runs their handlers and reports
+// the results back to the server.
+type Worker struct {
+	// Concurrency is the maximum number of jobs running at the same time.
+	Concurrency int
+	// PollInterval is how long to wait before pulling again when there
+	// were no pending jobs.
+	PollInterval time.Duration
+	// HeartbeatInterval is how often a running job is checked for being
+	// killed or failed by the server.
+	HeartbeatInterval time.Duration
+	// ShutdownTimeout is how long Run waits for running jobs once its
+	// context is done. Jobs still running after it are cancelled and put
+	// back to PENDING.
+	ShutdownTimeout time.Duration
+	Logger          logrus.FieldLogger
+
+	client   wonderland.WonderlandClient
+	handlers map[string]HandlerFunc
+	kinds    []string
+
+	running  sync.WaitGroup
+	slots    chan struct{}
+	shutdown chan struct{}
+}
+
+func NewWorker(c *Client) *Worker {
+	w := newWorker(c.WonderlandClient)
+	w.PollInterval = c.config.PollInterval
+	return w
+}
+
+func newWorker(c wonderland.WonderlandClient) *Worker {
+	return &Worker{
+		Concurrency:       defaultConcurrency,
+		PollInterval:      defaultPollInterval,
+		HeartbeatInterval: defaultHeartbeatInterval,
+		ShutdownTimeout:   defaultShutdownTimeout,
+		Logger:            logrus.StandardLogger(),
+		client:            c,
+		handlers:          map[string]HandlerFunc{},
+	}
+}
+
+// Handle registers the handler for jobs of the given kind. It must not be
+// called after Run.
+func (w *Worker) Handle(kind string, handler HandlerFunc) {
+	if _, ok := w.handlers[kind]; !ok {
+		w.kinds = append(w.kinds, kind)
+	}
+	w.handlers[kind] = handler
+}
+
+// Run processes jobs until ctx is done, then waits for the running jobs as
+// described for ShutdownTimeout.
+func (w *Worker) Run(ctx context.Context) error {
+	w.slots = make(chan struct{}, w.Concurrency)
+	w.shutdown = make(chan struct{})
+
+	for ctx.Err() == nil {
+		pulled := 0
+		for _, kind := range w.kinds {
+			n, err := w.pull(ctx, kind)
+			if err != nil && ctx.Err() == nil {
+				w.Logger.WithError(err).WithField("kind", kind).Warn("Failed to pull jobs")
+			}
+			pulled += n
+		}
+		if pulled == 0 {
+			sleepCtx(ctx, w.PollInterval)
+		}
+	}
+
+	return w.wait()
+}
+
+func (w *Worker) wait() error {
+	done := make(chan struct{})
+	go func() {
+		w.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(w.ShutdownTimeout):
+		w.Logger.Warn("Shutdown timeout reached, cancelling running jobs")
+		close(w.shutdown)
+		<-done
+		return context.DeadlineExceeded
+	}
+}
+
+// pull asks for as many jobs of kind as there are free slots and starts them.
+func (w *Worker) pull(ctx context.Context, kind string) (int, error) {
+	free := cap(w.slots) - len(w.slots)
+	if free == 0 {
+		return 0, nil
+	}
+
+	jobs, err := w.client.PullPendingJobs(ctx, &wonderland.ListJobsRequest{
+		HowMany: uint32(free),
+		Kind:    kind,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range jobs.Jobs {
+		w.slots <- struct{}{}
+		w.running.Add(1)
+		go func(job *wonderland.Job) {
+			defer func() {
+				<-w.slots
+				w.running.Done()
+			}()
+			w.process(job)
+		}(job)
+	}
+	return len(jobs.Jobs), nil
+}
+
+func (w *Worker) process(job *wonderland.Job) {
+	logger := w.Logger.WithField("job_id", job.Id)
+	// Reporting must not depend on the Run context: results of jobs
+	// finished during shutdown still have to reach the server.
+	reportCtx := context.Background()
+
+	handler, ok := w.handlers[job.Kind]
+	if !ok {
+		job.Status = wonderland.Job_FAILED
+		job.Output = "no handler registered for kind " + job.Kind
+		job.Error = &wonderland.JobError{Code: "NO_HANDLER", Message: job.Output}
+		w.report(reportCtx, logger, job)
+		return
+	}
+
+	job.Status = wonderland.Job_RUNNING
+	if !w.report(reportCtx, logger, job) {
+		return
+	}
+
+	jobCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	killed := make(chan struct{})
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		w.heartbeat(jobCtx, cancel, job.Id, killed)
+	}()
+
+	output, err := handler(jobCtx, job)
+	cancel()
+	<-heartbeatDone
+
+	select {
+	case <-killed:
+		logger.Info("Job was stopped by the server")
+		return
+	default:
+	}
+
+	select {
+	case <-w.shutdown:
+		// The handler was interrupted, let another worker run it again.
+		job.Status = wonderland.Job_PENDING
+	default:
+		if err != nil {
+			job.Status = wonderland.Job_FAILED
+			job.Output = err.Error()
+			job.Error = jobError(err)
+		} else {
+			job.Status = wonderland.Job_COMPLETED
+			job.Output = output
+		}
+	}
+	w.report(reportCtx, logger, job)
+}
+
+// heartbeat periodically checks that the job is still wanted and cancels it
+// when it has been killed, failed by the server's watchdog or the worker is
+// shutting down.
+func (w *Worker) heartbeat(ctx context.Context, cancel context.CancelFunc, id uint64, killed chan struct{}) {
+	ticker := time.NewTicker(w.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.shutdown:
+			cancel()
+			return
+		case <-ticker.C:
+		}
+
+		current, err := w.client.GetJob(ctx, &wonderland.RequestWithId{Id: id})
+		if err != nil {
+			w.Logger.WithError(err).WithField("job_id", id).Warn("Heartbeat failed")
+			continue
+		}
+		if current.Status == wonderland.Job_KILLED || current.FailureReason != "" {
+			close(killed)
+			cancel()
+			return
+		}
+	}
+}
+
+// Failure lets handlers describe why a job failed in more detail than the
+// error message.
+type Failure struct {
+	// Code is a short machine readable cause, HANDLER_ERROR if empty.
+	Code      string
+	Retryable bool
+	// Details is a stack trace or log excerpt.
+	Details string
+	Err     error
+}
+
+func (f *Failure) Error() string {
+	switch {
+	case f.Err != nil:
+		return f.Err.Error()
+	case f.Code != "":
+		return f.Code
+	default:
+		return "handler failed"
+	}
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// jobError describes err within the limits of the server.
+func jobError(err error) *wonderland.JobError {
+	jobErr := &wonderland.JobError{Code: "HANDLER_ERROR", Message: truncate(err.Error(), maxErrorMessageLength)}
+	if failure, ok := err.(*Failure); ok {
+		if failure.Code != "" {
+			jobErr.Code = failure.Code
+		}
+		jobErr.Retryable = failure.Retryable
+		jobErr.Details = truncate(failure.Details, maxErrorDetailsLength)
+	}
+	return jobErr
+}
+
+func (w *Worker) report(ctx context.Context, logger logrus.FieldLogger, job *wonderland.Job) bool {
+	_, err := w.client.ModifyJob(ctx, job)
+	if err != nil {
+		logger.WithError(err).WithField("status", job.Status).Error("Failed to update job")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,225 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/wonderlandcompute/server/wonderland"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// fakeServer keeps jobs in memory and implements the subset of
+// WonderlandClient used by Worker and SubmitAndWait.
+type fakeServer struct {
+	wonderland.WonderlandClient
+
+	mu     sync.Mutex
+	nextId uint64
+	jobs   map[uint64]*wonderland.Job
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{jobs: map[uint64]*wonderland.Job{}}
+}
+
+func (f *fakeServer) get(id uint64) wonderland.Job {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return *f.jobs[id]
+}
+
+func (f *fakeServer) CreateJob(ctx context.Context, in *wonderland.Job, opts ...grpc.CallOption) (*wonderland.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextId++
+	job := *in
+	job.Id = f.nextId
+	job.Status = wonderland.Job_PENDING
+	f.jobs[job.Id] = &job
+	ret := job
+	return &ret, nil
+}
+
+func (f *fakeServer) GetJob(ctx context.Context, in *wonderland.RequestWithId, opts ...grpc.CallOption) (*wonderland.Job, error) {
+	job := f.get(in.Id)
+	return &job, nil
+}
+
+func (f *fakeServer) ModifyJob(ctx context.Context, in *wonderland.Job, opts ...grpc.CallOption) (*wonderland.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job := *in
+	f.jobs[in.Id] = &job
+	ret := job
+	return &ret, nil
+}
+
+func (f *fakeServer) KillJob(ctx context.Context, in *wonderland.RequestWithId, opts ...grpc.CallOption) (*wonderland.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.jobs[in.Id].Status = wonderland.Job_KILLED
+	ret := *f.jobs[in.Id]
+	return &ret, nil
+}
+
+func (f *fakeServer) PullPendingJobs(ctx context.Context, in *wonderland.ListJobsRequest, opts ...grpc.CallOption) (*wonderland.ListOfJobs, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ret := &wonderland.ListOfJobs{}
+	for id := uint64(1); id <= f.nextId; id++ {
+		job, ok := f.jobs[id]
+		if !ok || job.Status != wonderland.Job_PENDING || job.Kind != in.Kind {
+			continue
+		}
+		if in.HowMany != 0 && uint32(len(ret.Jobs)) == in.HowMany {
+			break
+		}
+		job.Status = wonderland.Job_PULLED
+		pulled := *job
+		ret.Jobs = append(ret.Jobs, &pulled)
+	}
+	return ret, nil
+}
+
+func newTestWorker(f *fakeServer) *Worker {
+	w := newWorker(f)
+	w.PollInterval = time.Millisecond
+	w.HeartbeatInterval = time.Millisecond
+	w.ShutdownTimeout = time.Second
+	return w
+}
+
+func waitForStatus(t *testing.T, f *fakeServer, id uint64, status wonderland.Job_Status) {
+	deadline := time.Now().Add(5 * time.Second)
+	for f.get(id).Status != status {
+		if time.Now().After(deadline) {
+			t.Fatalf("job %d: expected status %v, got %v", id, status, f.get(id).Status)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWorkerReportsResults(t *testing.T) {
+	f := newFakeServer()
+	ok, _ := f.CreateJob(context.Background(), &wonderland.Job{Kind: "echo", Input: "hello"})
+	failing, _ := f.CreateJob(context.Background(), &wonderland.Job{Kind: "fail"})
+
+	w := newTestWorker(f)
+	w.Concurrency = 2
+	w.Handle("echo", func(ctx context.Context, job *wonderland.Job) (string, error) {
+		return job.Input, nil
+	})
+	w.Handle("fail", func(ctx context.Context, job *wonderland.Job) (string, error) {
+		return "", &Failure{Code: "OUT_OF_MEMORY", Retryable: true, Err: errors.New("boom")}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Run(ctx) }()
+
+	waitForStatus(t, f, ok.Id, wonderland.Job_COMPLETED)
+	waitForStatus(t, f, failing.Id, wonderland.Job_FAILED)
+	cancel()
+	checkTestErr(<-done, t)
+
+	if f.get(ok.Id).Output != "hello" {
+		t.Fail()
+	}
+	failed := f.get(failing.Id)
+	if failed.Output != "boom" || failed.Error.Code != "OUT_OF_MEMORY" || !failed.Error.Retryable {
+		t.Log(failed)
+		t.Fail()
+	}
+}
+
+func TestWorkerCancelsKilledJob(t *testing.T) {
+	f := newFakeServer()
+	job, _ := f.CreateJob(context.Background(), &wonderland.Job{Kind: "sleep"})
+
+	started := make(chan struct{})
+	w := newTestWorker(f)
+	w.Handle("sleep", func(ctx context.Context, job *wonderland.Job) (string, error) {
+		close(started)
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Run(ctx) }()
+
+	<-started
+	f.KillJob(ctx, &wonderland.RequestWithId{Id: job.Id})
+	cancel()
+	checkTestErr(<-done, t)
+
+	if f.get(job.Id).Status != wonderland.Job_KILLED {
+		t.Fail()
+	}
+}
+
+func TestWorkerRequeuesJobsOnShutdownTimeout(t *testing.T) {
+	f := newFakeServer()
+	job, _ := f.CreateJob(context.Background(), &wonderland.Job{Kind: "sleep"})
+
+	started := make(chan struct{})
+	w := newTestWorker(f)
+	w.HeartbeatInterval = time.Hour
+	w.ShutdownTimeout = 10 * time.Millisecond
+	w.Handle("sleep", func(ctx context.Context, job *wonderland.Job) (string, error) {
+		close(started)
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Run(ctx) }()
+
+	<-started
+	cancel()
+	if <-done != context.DeadlineExceeded {
+		t.Fail()
+	}
+	if f.get(job.Id).Status != wonderland.Job_PENDING {
+		t.Fail()
+	}
+}
+
+func TestSubmitAndWait(t *testing.T) {
+	f := newFakeServer()
+	w := newTestWorker(f)
+	w.Handle("echo", func(ctx context.Context, job *wonderland.Job) (string, error) {
+		return job.Input, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	job, err := submitAndWait(ctx, f, &wonderland.Job{Kind: "echo", Input: "42"}, time.Millisecond)
+	checkTestErr(err, t)
+	if job.Status != wonderland.Job_COMPLETED || job.Output != "42" {
+		t.Fail()
+	}
+}
+
+func TestJobErrorFitsServerLimits(t *testing.T) {
+	jobErr := jobError(&Failure{Details: strings.Repeat("é", maxErrorDetailsLength), Err: errors.New(strings.Repeat("x", 5000))})
+	if len(jobErr.Message) != maxErrorMessageLength || len(jobErr.Details) != maxErrorDetailsLength ||
+		!utf8.ValidString(jobErr.Details) {
+		t.Fail()
+	}
+
+	// failures without an error still describe themselves
+	jobErr = jobError(&Failure{Code: "OUT_OF_MEMORY"})
+	if jobErr.Message != "OUT_OF_MEMORY" {
+		t.Log(jobErr)
+		t.Fail()
+	}
+}
@@ -0,0 +1 @@
+ALTER TABLE jobs ADD trace_parent TEXT NOT NULL DEFAULT '';
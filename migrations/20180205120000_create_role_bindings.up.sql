@@ -0,0 +1,16 @@
+CREATE TABLE role_bindings (
+  id           SERIAL NOT NULL,
+  principal    VARCHAR(40) NOT NULL,
+  role         TEXT   NOT NULL,
+  project      VARCHAR(40) NOT NULL      DEFAULT 'ANY',
+  kind         TEXT   NOT NULL             DEFAULT 'ANY',
+
+  created      TIMESTAMP WITHOUT TIME ZONE DEFAULT (now() AT TIME ZONE 'utc'),
+  creator      VARCHAR(40),
+
+  PRIMARY KEY (id),
+  UNIQUE (principal, role, project, kind)
+);
+
+CREATE INDEX role_bindings_principal_idx
+  ON role_bindings (principal);
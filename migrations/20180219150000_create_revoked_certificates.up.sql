@@ -0,0 +1,9 @@
+CREATE TABLE revoked_certificates (
+  serial       VARCHAR(64) NOT NULL,
+  reason       TEXT   NOT NULL             DEFAULT '',
+  revoked_by   VARCHAR(40) NOT NULL      DEFAULT '',
+
+  created      TIMESTAMP WITHOUT TIME ZONE DEFAULT (now() AT TIME ZONE 'utc'),
+
+  PRIMARY KEY (serial)
+);
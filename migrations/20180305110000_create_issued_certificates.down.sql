@@ -0,0 +1 @@
+DROP TABLE issued_certificates;
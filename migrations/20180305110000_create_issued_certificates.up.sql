@@ -0,0 +1,15 @@
+CREATE TABLE issued_certificates (
+  serial       VARCHAR(64) NOT NULL,
+  common_name  VARCHAR(40) NOT NULL,
+  grants       TEXT   NOT NULL             DEFAULT '',
+  not_after    TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+
+  created      TIMESTAMP WITHOUT TIME ZONE DEFAULT (now() AT TIME ZONE 'utc'),
+  issued_by    VARCHAR(40) NOT NULL      DEFAULT '',
+  renewed_from VARCHAR(64) NOT NULL      DEFAULT '',
+
+  PRIMARY KEY (serial)
+);
+
+CREATE INDEX issued_certificates_common_name_idx
+  ON issued_certificates (common_name);
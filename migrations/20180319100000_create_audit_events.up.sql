@@ -0,0 +1,33 @@
+CREATE TABLE audit_events (
+  id           BIGSERIAL NOT NULL,
+  created      TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  principal    VARCHAR(40) NOT NULL      DEFAULT '',
+  rpc          TEXT   NOT NULL             DEFAULT '',
+  job_id       BIGINT NOT NULL             DEFAULT 0,
+  project      VARCHAR(40) NOT NULL      DEFAULT '',
+  before       JSONB,
+  after        JSONB,
+  changed      TEXT[] NOT NULL             DEFAULT '{}',
+  peer_address TEXT   NOT NULL             DEFAULT '',
+  cert_serial  VARCHAR(64) NOT NULL      DEFAULT '',
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX audit_events_job_id_idx
+  ON audit_events (job_id);
+CREATE INDEX audit_events_project_idx
+  ON audit_events (project, id);
+CREATE INDEX audit_events_principal_idx
+  ON audit_events (principal, id);
+
+-- audit events are append-only
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+  BEFORE UPDATE OR DELETE ON audit_events
+  FOR EACH ROW EXECUTE PROCEDURE audit_events_append_only();
@@ -0,0 +1,2 @@
+DELETE FROM jobs WHERE deleted_at IS NOT NULL;
+ALTER TABLE jobs DROP COLUMN deleted_at;
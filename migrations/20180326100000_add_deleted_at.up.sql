@@ -0,0 +1,4 @@
+ALTER TABLE jobs ADD deleted_at TIMESTAMP WITHOUT TIME ZONE;
+
+CREATE INDEX jobs_deleted_at_idx
+  ON jobs (deleted_at) WHERE deleted_at IS NOT NULL;
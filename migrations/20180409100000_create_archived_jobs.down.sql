@@ -0,0 +1,2 @@
+DROP INDEX jobs_status_last_modified_idx;
+DROP TABLE archived_jobs;
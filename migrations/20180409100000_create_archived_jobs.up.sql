@@ -0,0 +1,15 @@
+CREATE TABLE archived_jobs (
+  id           INTEGER NOT NULL,
+  project      VARCHAR(40) NOT NULL      DEFAULT '',
+  kind         TEXT   NOT NULL             DEFAULT '',
+  status       SMALLINT NOT NULL,
+  archive      TEXT   NOT NULL,
+
+  archived_at  TIMESTAMP WITHOUT TIME ZONE DEFAULT (now() AT TIME ZONE 'utc'),
+
+  PRIMARY KEY (id)
+);
+
+-- retention rules look for finished jobs by last modification
+CREATE INDEX jobs_status_last_modified_idx
+  ON jobs (status, last_modified);
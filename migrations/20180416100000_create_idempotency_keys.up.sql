@@ -0,0 +1,13 @@
+CREATE TABLE idempotency_keys (
+  project      VARCHAR(40) NOT NULL,
+  key          VARCHAR(128) NOT NULL,
+  job_id       INTEGER NOT NULL,
+  payload_hash CHAR(64) NOT NULL,
+
+  created      TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+
+  PRIMARY KEY (project, key)
+);
+
+CREATE INDEX idempotency_keys_created_idx
+  ON idempotency_keys (created);
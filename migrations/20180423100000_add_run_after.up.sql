@@ -0,0 +1,4 @@
+ALTER TABLE jobs ADD run_after TIMESTAMP WITHOUT TIME ZONE;
+
+CREATE INDEX jobs_run_after_idx
+  ON jobs (run_after) WHERE run_after IS NOT NULL;
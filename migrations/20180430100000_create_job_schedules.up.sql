@@ -0,0 +1,25 @@
+CREATE TABLE job_schedules (
+  id           SERIAL NOT NULL,
+  project      VARCHAR(40) NOT NULL,
+  kind         TEXT   NOT NULL             DEFAULT '',
+  template     JSONB  NOT NULL,
+  cron         TEXT   NOT NULL,
+  timezone     TEXT   NOT NULL             DEFAULT '',
+  overlap      SMALLINT NOT NULL           DEFAULT 0,
+  paused       BOOLEAN NOT NULL            DEFAULT false,
+
+  next_run     TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  last_run     TIMESTAMP WITHOUT TIME ZONE,
+  last_job_id  INTEGER NOT NULL            DEFAULT 0,
+
+  created      TIMESTAMP WITHOUT TIME ZONE DEFAULT (now() AT TIME ZONE 'utc'),
+  creator      VARCHAR(40),
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX job_schedules_next_run_idx
+  ON job_schedules (next_run) WHERE NOT paused;
+
+CREATE INDEX job_schedules_project_idx
+  ON job_schedules (project);
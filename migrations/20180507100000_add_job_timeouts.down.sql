@@ -0,0 +1,4 @@
+ALTER TABLE jobs DROP COLUMN failure_reason;
+ALTER TABLE jobs DROP COLUMN pulled_at;
+ALTER TABLE jobs DROP COLUMN deadline;
+ALTER TABLE jobs DROP COLUMN max_runtime;
@@ -0,0 +1,9 @@
+ALTER TABLE jobs ADD max_runtime BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD deadline TIMESTAMP WITHOUT TIME ZONE;
+ALTER TABLE jobs ADD pulled_at TIMESTAMP WITHOUT TIME ZONE;
+ALTER TABLE jobs ADD failure_reason TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX jobs_deadline_idx
+  ON jobs (deadline) WHERE deadline IS NOT NULL AND status = 0;
+CREATE INDEX jobs_max_runtime_idx
+  ON jobs (pulled_at) WHERE max_runtime > 0 AND status IN (1, 2);
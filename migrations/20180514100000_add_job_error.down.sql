@@ -0,0 +1,2 @@
+DROP INDEX jobs_failed_idx;
+ALTER TABLE jobs DROP COLUMN error;
@@ -0,0 +1,4 @@
+ALTER TABLE jobs ADD error JSONB;
+
+CREATE INDEX jobs_failed_idx
+  ON jobs (last_modified) WHERE status = 3;
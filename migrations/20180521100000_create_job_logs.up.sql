@@ -0,0 +1,12 @@
+CREATE TABLE job_logs (
+  id           BIGSERIAL NOT NULL,
+  job_id       INTEGER NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+  stream       TEXT   NOT NULL             DEFAULT '',
+  data         TEXT   NOT NULL,
+  created      TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX job_logs_job_id_idx
+  ON job_logs (job_id, id);
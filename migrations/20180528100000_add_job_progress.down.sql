@@ -0,0 +1,2 @@
+DROP TABLE job_progress;
+ALTER TABLE jobs DROP COLUMN progress;
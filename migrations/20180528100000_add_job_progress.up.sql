@@ -0,0 +1,13 @@
+ALTER TABLE jobs ADD progress JSONB;
+
+CREATE TABLE job_progress (
+  id           BIGSERIAL NOT NULL,
+  job_id       INTEGER NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+  fraction     DOUBLE PRECISION NOT NULL,
+  reported_at  TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX job_progress_job_id_idx
+  ON job_progress (job_id, id);
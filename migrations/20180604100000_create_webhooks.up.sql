@@ -0,0 +1,42 @@
+CREATE TABLE webhooks (
+  id           SERIAL NOT NULL,
+  project      VARCHAR(40) NOT NULL,
+  url          TEXT   NOT NULL,
+  statuses     INTEGER[] NOT NULL          DEFAULT '{}',
+  kinds        TEXT[] NOT NULL             DEFAULT '{}',
+  labels       JSONB  NOT NULL             DEFAULT '{}',
+  secret       TEXT   NOT NULL,
+
+  created      TIMESTAMP WITHOUT TIME ZONE DEFAULT (now() AT TIME ZONE 'utc'),
+  creator      VARCHAR(40),
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX webhooks_project_idx
+  ON webhooks (project);
+
+-- the outbox, filled in the transactions changing the status of jobs; job_id
+-- has no foreign key so that deliveries outlive purged jobs
+CREATE TABLE webhook_deliveries (
+  id                 BIGSERIAL NOT NULL,
+  webhook_id         INTEGER NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+  job_id             INTEGER NOT NULL,
+  status             SMALLINT NOT NULL,
+  payload            JSONB  NOT NULL,
+  state              SMALLINT NOT NULL     DEFAULT 0,
+  attempts           INTEGER NOT NULL      DEFAULT 0,
+  created            TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  next_attempt       TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  delivered_at       TIMESTAMP WITHOUT TIME ZONE,
+  last_error         TEXT   NOT NULL       DEFAULT '',
+  last_response_code INTEGER NOT NULL      DEFAULT 0,
+
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX webhook_deliveries_due_idx
+  ON webhook_deliveries (next_attempt) WHERE state = 0;
+
+CREATE INDEX webhook_deliveries_webhook_id_idx
+  ON webhook_deliveries (webhook_id, id);
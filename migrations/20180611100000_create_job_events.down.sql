@@ -0,0 +1,2 @@
+DROP TABLE job_event_horizon;
+DROP TABLE job_events;
@@ -0,0 +1,28 @@
+CREATE TABLE job_events (
+  sequence        BIGSERIAL NOT NULL,
+  type            SMALLINT NOT NULL,
+  job_id          INTEGER NOT NULL,
+  project         VARCHAR(40) NOT NULL,
+  kind            TEXT   NOT NULL          DEFAULT '',
+  status          SMALLINT NOT NULL,
+  previous_status SMALLINT NOT NULL        DEFAULT 0,
+  changed         TEXT[] NOT NULL          DEFAULT '{}',
+  job             JSONB  NOT NULL,
+  principal       TEXT   NOT NULL          DEFAULT '',
+  created         TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+
+  PRIMARY KEY (sequence)
+);
+
+CREATE INDEX job_events_project_idx
+  ON job_events (project, sequence);
+
+CREATE INDEX job_events_created_idx
+  ON job_events (created);
+
+-- the largest sequence deleted by compaction
+CREATE TABLE job_event_horizon (
+  sequence     BIGINT NOT NULL
+);
+
+INSERT INTO job_event_horizon (sequence) VALUES (0);
@@ -0,0 +1,4 @@
+ALTER TABLE jobs
+  DROP COLUMN kind_version;
+
+DROP TABLE job_kinds;
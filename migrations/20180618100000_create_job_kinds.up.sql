@@ -0,0 +1,16 @@
+CREATE TABLE job_kinds (
+  project       VARCHAR(40) NOT NULL,
+  name          TEXT   NOT NULL,
+  version       INTEGER NOT NULL,
+  input_schema  TEXT   NOT NULL            DEFAULT '',
+  output_schema TEXT   NOT NULL            DEFAULT '',
+  description   TEXT   NOT NULL            DEFAULT '',
+
+  created       TIMESTAMP WITHOUT TIME ZONE DEFAULT (now() AT TIME ZONE 'utc'),
+  creator       VARCHAR(40),
+
+  PRIMARY KEY (project, name, version)
+);
+
+ALTER TABLE jobs
+  ADD COLUMN kind_version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+ALTER TABLE jobs
+  DROP COLUMN template_id,
+  DROP COLUMN template_version;
+
+DROP TABLE job_templates;
+DROP SEQUENCE job_templates_id_seq;
@@ -0,0 +1,20 @@
+CREATE SEQUENCE job_templates_id_seq;
+
+-- the versions of a template share its id
+CREATE TABLE job_templates (
+  id           INTEGER NOT NULL,
+  project      VARCHAR(40) NOT NULL,
+  name         TEXT   NOT NULL,
+  version      INTEGER NOT NULL,
+  template     JSONB  NOT NULL,
+
+  created      TIMESTAMP WITHOUT TIME ZONE DEFAULT (now() AT TIME ZONE 'utc'),
+  creator      VARCHAR(40),
+
+  PRIMARY KEY (id, version),
+  UNIQUE (project, name, version)
+);
+
+ALTER TABLE jobs
+  ADD COLUMN template_id      INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN template_version INTEGER NOT NULL DEFAULT 0;
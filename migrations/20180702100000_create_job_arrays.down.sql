@@ -0,0 +1,7 @@
+DROP INDEX jobs_array_id_idx;
+
+ALTER TABLE jobs
+  DROP COLUMN array_id,
+  DROP COLUMN array_index;
+
+DROP TABLE job_arrays;
@@ -0,0 +1,19 @@
+CREATE TABLE job_arrays (
+  id           SERIAL NOT NULL,
+  project      VARCHAR(40) NOT NULL,
+  kind         TEXT   NOT NULL             DEFAULT '',
+  definition   JSONB  NOT NULL,
+  size         INTEGER NOT NULL,
+
+  created      TIMESTAMP WITHOUT TIME ZONE DEFAULT (now() AT TIME ZONE 'utc'),
+  creator      VARCHAR(40),
+
+  PRIMARY KEY (id)
+);
+
+ALTER TABLE jobs
+  ADD COLUMN array_id    INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN array_index INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX jobs_array_id_idx
+  ON jobs (array_id, status) WHERE array_id <> 0;
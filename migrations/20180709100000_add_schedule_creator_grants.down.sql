@@ -0,0 +1,3 @@
+ALTER TABLE job_schedules
+  DROP COLUMN creator_grants,
+  DROP COLUMN creator_serial;
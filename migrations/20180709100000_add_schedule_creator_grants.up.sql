@@ -0,0 +1,5 @@
+-- the grants of the certificate or token a schedule was created with, NULL
+-- for schedules created before they were recorded
+ALTER TABLE job_schedules
+  ADD COLUMN creator_grants TEXT[],
+  ADD COLUMN creator_serial TEXT NOT NULL DEFAULT '';
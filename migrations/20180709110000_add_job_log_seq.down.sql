@@ -0,0 +1,6 @@
+DROP INDEX job_logs_job_id_seq_idx;
+CREATE INDEX job_logs_job_id_idx
+  ON job_logs (job_id, id);
+
+ALTER TABLE job_logs DROP COLUMN seq;
+ALTER TABLE jobs DROP COLUMN log_seq;
@@ -0,0 +1,16 @@
+-- chunks are numbered per job under the lock of its row, so that they become
+-- visible in order
+ALTER TABLE jobs ADD log_seq BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE job_logs ADD seq BIGINT;
+
+UPDATE job_logs SET seq = id;
+UPDATE jobs
+SET log_seq = logs.seq
+FROM (SELECT job_id, max(seq) AS seq FROM job_logs GROUP BY job_id) logs
+WHERE jobs.id = logs.job_id;
+
+ALTER TABLE job_logs ALTER seq SET NOT NULL;
+
+DROP INDEX job_logs_job_id_idx;
+CREATE UNIQUE INDEX job_logs_job_id_seq_idx
+  ON job_logs (job_id, seq);
@@ -0,0 +1,3 @@
+-- finished deliveries are pruned by age
+CREATE INDEX webhook_deliveries_created_idx
+  ON webhook_deliveries (created) WHERE state <> 0;
@@ -0,0 +1,14 @@
+DROP TRIGGER job_events_sequence ON job_events;
+DROP FUNCTION sequence_job_events();
+DROP TABLE job_event_counter;
+
+DROP INDEX job_events_unsequenced_idx;
+DROP INDEX job_events_sequence_idx;
+CREATE SEQUENCE job_events_sequence_seq OWNED BY job_events.sequence;
+SELECT setval('job_events_sequence_seq', GREATEST((SELECT max(sequence) FROM job_events), 1));
+ALTER TABLE job_events
+  ALTER sequence SET DEFAULT nextval('job_events_sequence_seq'),
+  ALTER sequence SET NOT NULL;
+ALTER TABLE job_events DROP CONSTRAINT job_events_pkey;
+ALTER TABLE job_events ADD PRIMARY KEY (sequence);
+ALTER TABLE job_events DROP COLUMN id;
@@ -0,0 +1,47 @@
+-- events get their sequence from a counter when their transaction commits,
+-- so that sequences become visible in increasing order without serializing
+-- whole transactions; until then it is NULL
+ALTER TABLE job_events ADD COLUMN id BIGSERIAL NOT NULL;
+ALTER TABLE job_events DROP CONSTRAINT job_events_pkey;
+ALTER TABLE job_events ADD PRIMARY KEY (id);
+ALTER TABLE job_events ALTER sequence DROP DEFAULT, ALTER sequence DROP NOT NULL;
+DROP SEQUENCE job_events_sequence_seq;
+
+CREATE UNIQUE INDEX job_events_sequence_idx
+  ON job_events (sequence);
+CREATE INDEX job_events_unsequenced_idx
+  ON job_events (id) WHERE sequence IS NULL;
+
+-- the largest sequence assigned so far
+CREATE TABLE job_event_counter (
+  sequence     BIGINT NOT NULL
+);
+
+INSERT INTO job_event_counter (sequence)
+SELECT GREATEST((SELECT COALESCE(max(sequence), 0) FROM job_events), (SELECT sequence FROM job_event_horizon));
+
+-- runs at commit: the lock on the counter row is only held from there until
+-- the commit is visible, so a later sequence is never visible first
+CREATE FUNCTION sequence_job_events() RETURNS trigger AS $$
+DECLARE
+  pending BIGINT;
+  last    BIGINT;
+BEGIN
+  SELECT count(*) INTO pending FROM job_events WHERE sequence IS NULL;
+  IF pending = 0 THEN
+    RETURN NULL;
+  END IF;
+
+  UPDATE job_event_counter SET sequence = sequence + pending RETURNING sequence INTO last;
+  UPDATE job_events e
+  SET sequence = last - pending + n.position
+  FROM (SELECT id, row_number() OVER (ORDER BY id) AS position FROM job_events WHERE sequence IS NULL) n
+  WHERE e.id = n.id;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER job_events_sequence
+  AFTER INSERT ON job_events
+  DEFERRABLE INITIALLY DEFERRED
+  FOR EACH ROW EXECUTE PROCEDURE sequence_job_events();
@@ -0,0 +1,301 @@
+package wonderland
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+const (
+	defaultArchiveInterval  = time.Hour
+	defaultArchiveBatchSize = 1000
+)
+
+// finishedStatuses are the statuses retention rules may apply to.
+var finishedStatuses = []Job_Status{Job_COMPLETED, Job_FAILED, Job_KILLED, Job_EXPIRED}
+
+func isFinished(status Job_Status) bool {
+	for _, finished := range finishedStatuses {
+		if status == finished {
+			return true
+		}
+	}
+	return false
+}
+
+// RetentionRule keeps finished jobs of a project and kind with the given
+// status for Keep after their last modification. Empty fields match every
+// project, kind or finished status.
+type RetentionRule struct {
+	Project string        `yaml:"project"`
+	Kind    string        `yaml:"kind"`
+	Status  string        `yaml:"status"`
+	Keep    time.Duration `yaml:"keep"`
+}
+
+// ArchiveConfig configures the archival of finished jobs. A job is governed
+// by the first rule matching it, jobs matching no rule are kept forever.
+type ArchiveConfig struct {
+	Dir       string          `yaml:"dir"`
+	Interval  time.Duration   `yaml:"interval"`
+	BatchSize int             `yaml:"batch_size"`
+	Rules     []RetentionRule `yaml:"rules"`
+}
+
+func (r RetentionRule) statuses() ([]int64, error) {
+	statuses := []int64{}
+	for _, status := range finishedStatuses {
+		if r.Status == "" || r.Status == status.String() {
+			statuses = append(statuses, int64(status))
+		}
+	}
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("retention rules only apply to finished statuses, not %q", r.Status)
+	}
+	return statuses, nil
+}
+
+// condition returns an SQL condition matching the jobs of r, appending its
+// arguments to args.
+func (r RetentionRule) condition(args *[]interface{}) string {
+	statuses, _ := r.statuses()
+	*args = append(*args, pq.Array(statuses))
+	condition := "status = ANY($" + strconv.Itoa(len(*args)) + ")"
+	if r.Project != "" {
+		*args = append(*args, r.Project)
+		condition += " AND project=$" + strconv.Itoa(len(*args))
+	}
+	if r.Kind != "" {
+		*args = append(*args, r.Kind)
+		condition += " AND kind=$" + strconv.Itoa(len(*args))
+	}
+	return "(" + condition + ")"
+}
+
+// expiredCondition returns an SQL condition matching the jobs governed by
+// rules[i] which expired at now, appending its arguments to args.
+func expiredCondition(rules []RetentionRule, i int, now time.Time, args *[]interface{}) string {
+	conditions := []string{rules[i].condition(args)}
+	*args = append(*args, now.Add(-rules[i].Keep))
+	conditions = append(conditions, "last_modified < $"+strconv.Itoa(len(*args)))
+	for _, earlier := range rules[:i] {
+		conditions = append(conditions, "NOT "+earlier.condition(args))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// archivedJob is a line of an archive file. The job is stored the way the
+// REST API returns it, along with its log and progress reports, which would
+// otherwise be deleted with it.
+type archivedJob struct {
+	Job          json.RawMessage     `json:"job"`
+	Creator      string              `json:"creator"`
+	Created      time.Time           `json:"created"`
+	LastModified time.Time           `json:"last_modified"`
+	Logs         []*archivedLog      `json:"logs,omitempty"`
+	Progress     []*archivedProgress `json:"progress,omitempty"`
+}
+
+// archivedLog is a row of job_logs.
+type archivedLog struct {
+	Seq     uint64    `json:"seq"`
+	Stream  string    `json:"stream"`
+	Data    string    `json:"data"`
+	Created time.Time `json:"created"`
+}
+
+// archivedProgress is a row of job_progress.
+type archivedProgress struct {
+	Fraction   float64   `json:"fraction"`
+	ReportedAt time.Time `json:"reported_at"`
+}
+
+func (a *archivedJob) job() (*Job, error) {
+	job := &Job{}
+	err := jsonpb.Unmarshal(bytes.NewReader(a.Job), job)
+	return job, err
+}
+
+// archiveEntry tells which archive file holds a job.
+type archiveEntry struct {
+	JobId   uint64
+	Project string
+	Kind    string
+	Archive string
+}
+
+// Archiver moves finished jobs past their retention to gzipped JSON lines
+// files and restores them on demand.
+type Archiver struct {
+	storage *WonderlandStorage
+	config  ArchiveConfig
+}
+
+func NewArchiver(storage *WonderlandStorage, config ArchiveConfig) (*Archiver, error) {
+	if config.Dir == "" {
+		return nil, fmt.Errorf("archive directory is not set")
+	}
+	if config.Interval == 0 {
+		config.Interval = defaultArchiveInterval
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = defaultArchiveBatchSize
+	}
+	for _, rule := range config.Rules {
+		_, err := rule.statuses()
+		if err != nil {
+			return nil, err
+		}
+		if rule.Keep <= 0 {
+			return nil, fmt.Errorf("retention rule for project %q and kind %q needs a positive keep", rule.Project, rule.Kind)
+		}
+	}
+	err := os.MkdirAll(config.Dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+	return &Archiver{storage: storage, config: config}, nil
+}
+
+// Run archives expired jobs every interval until ctx is done.
+func (a *Archiver) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.config.Interval)
+	defer ticker.Stop()
+
+	// archiving is recorded in the audit log like API changes
+	ctx = withRequestInfo(ctx, "archive", "")
+	for {
+		archived, err := a.ArchiveExpired(ctx)
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to archive expired jobs")
+		} else if archived > 0 {
+			logrus.WithField("jobs", archived).Info("Archived expired jobs")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ArchiveExpired archives the jobs past their retention, a file per batch.
+func (a *Archiver) ArchiveExpired(ctx context.Context) (int, error) {
+	total := 0
+	now := a.storage.now()
+	for i := range a.config.Rules {
+		for {
+			args := []interface{}{}
+			condition := expiredCondition(a.config.Rules, i, now, &args)
+			archived, err := a.storage.ArchiveJobs(ctx, condition, args, a.config.BatchSize, a.writeArchive)
+			total += archived
+			if err != nil {
+				return total, err
+			}
+			if archived < a.config.BatchSize {
+				break
+			}
+		}
+	}
+	return total, nil
+}
+
+// writeArchive writes jobs to a new archive file and returns its name. The
+// file is complete once this returns, so that jobs are only deleted from the
+// database after they are safely stored.
+func (a *Archiver) writeArchive(jobs []*archivedJob) (string, error) {
+	first, err := jobs[0].job()
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("jobs-%s-%d.jsonl.gz", time.Now().UTC().Format("20060102T150405"), first.Id)
+
+	tmp, err := ioutil.TempFile(a.config.Dir, ".archive")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	gz := gzip.NewWriter(tmp)
+	encoder := json.NewEncoder(gz)
+	for _, job := range jobs {
+		err = encoder.Encode(job)
+		if err != nil {
+			tmp.Close()
+			return "", err
+		}
+	}
+	err = gz.Close()
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	return name, os.Rename(tmp.Name(), filepath.Join(a.config.Dir, name))
+}
+
+// readArchive finds the job with the given id in an archive file.
+func (a *Archiver) readArchive(name string, id uint64) (*archivedJob, error) {
+	file, err := os.Open(filepath.Join(a.config.Dir, filepath.Base(name)))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(gz)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			record := &archivedJob{}
+			decodeErr := json.Unmarshal(line, record)
+			if decodeErr != nil {
+				return nil, decodeErr
+			}
+			job, decodeErr := record.job()
+			if decodeErr != nil {
+				return nil, decodeErr
+			}
+			if job.Id == id {
+				return record, nil
+			}
+		}
+		if err == io.EOF {
+			return nil, fmt.Errorf("job %d not found in archive %s", id, name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Restore moves an archived job back into the jobs table.
+func (a *Archiver) Restore(ctx context.Context, entry *archiveEntry) (*Job, error) {
+	record, err := a.readArchive(entry.Archive, entry.JobId)
+	if err != nil {
+		return nil, err
+	}
+	return a.storage.RestoreArchivedJob(ctx, record)
+}
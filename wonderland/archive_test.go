@@ -0,0 +1,102 @@
+package wonderland
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func TestExpiredCondition(t *testing.T) {
+	rules := []RetentionRule{
+		{Project: "lhcb", Status: "COMPLETED", Keep: time.Hour},
+		{Kind: "docker", Keep: 24 * time.Hour},
+	}
+	now := time.Date(2018, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	args := []interface{}{}
+	condition := expiredCondition(rules, 1, now, &args)
+	expected := "(status = ANY($1) AND kind=$2) AND last_modified < $3 AND NOT (status = ANY($4) AND project=$5)"
+	if condition != expected {
+		t.Log(condition)
+		t.Fail()
+	}
+	if !reflect.DeepEqual(args, []interface{}{
+		pq.Array([]int64{int64(Job_COMPLETED), int64(Job_FAILED), int64(Job_KILLED), int64(Job_EXPIRED)}), "docker", now.Add(-24 * time.Hour),
+		pq.Array([]int64{int64(Job_COMPLETED)}), "lhcb",
+	}) {
+		t.Log(args)
+		t.Fail()
+	}
+}
+
+func TestNewArchiverRejectsInvalidRules(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	checkTestErr(err, t)
+	defer os.RemoveAll(dir)
+
+	invalid := [][]RetentionRule{
+		{{Status: "PENDING", Keep: time.Hour}},
+		{{Status: "DONE", Keep: time.Hour}},
+		{{Project: "lhcb"}},
+	}
+	for _, rules := range invalid {
+		_, err := NewArchiver(nil, ArchiveConfig{Dir: dir, Rules: rules})
+		if err == nil {
+			t.Log(rules)
+			t.Fail()
+		}
+	}
+	_, err = NewArchiver(nil, ArchiveConfig{Rules: []RetentionRule{{Keep: time.Hour}}})
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestArchiveFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	checkTestErr(err, t)
+	defer os.RemoveAll(dir)
+	a, err := NewArchiver(nil, ArchiveConfig{Dir: dir})
+	checkTestErr(err, t)
+
+	records := []*archivedJob{}
+	for _, job := range []*Job{
+		{Id: 3, Project: "lhcb", Kind: "docker", Status: Job_COMPLETED, Output: "result"},
+		{Id: 5, Project: "lhcb", Kind: "docker", Status: Job_FAILED},
+	} {
+		content, err := auditMarshaler.MarshalToString(job)
+		checkTestErr(err, t)
+		records = append(records, &archivedJob{Job: json.RawMessage(content), Creator: "alice"})
+	}
+	records[0].Logs = []*archivedLog{{Stream: "stdout", Data: "done\n"}}
+	records[0].Progress = []*archivedProgress{{Fraction: 1}}
+	name, err := a.writeArchive(records)
+	checkTestErr(err, t)
+
+	record, err := a.readArchive(name, 3)
+	checkTestErr(err, t)
+	if record == nil {
+		t.FailNow()
+	}
+	job, err := record.job()
+	checkTestErr(err, t)
+	if job.Id != 3 || job.Output != "result" || job.Status != Job_COMPLETED || record.Creator != "alice" {
+		t.Log(job)
+		t.Fail()
+	}
+	// logs and progress reports are archived with the job
+	if len(record.Logs) != 1 || record.Logs[0].Data != "done\n" || len(record.Progress) != 1 || record.Progress[0].Fraction != 1 {
+		t.Log(record)
+		t.Fail()
+	}
+
+	_, err = a.readArchive(name, 4)
+	if err == nil {
+		t.Fail()
+	}
+}
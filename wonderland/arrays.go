@@ -0,0 +1,443 @@
+package wonderland
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxArraySize = 1000
+
+// arrayParameterSets returns the parameters of each job of array, from its
+// grid or its parameter sets.
+func arrayParameterSets(array *ArrayJob) ([]map[string]string, error) {
+	if (len(array.Grid) == 0) == (len(array.ParameterSets) == 0) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "Array jobs need either a grid or parameter sets")
+	}
+	if len(array.ParameterSets) > maxArraySize {
+		return nil, grpc.Errorf(codes.InvalidArgument, "Array jobs have at most %d jobs", maxArraySize)
+	}
+	if len(array.ParameterSets) > 0 {
+		sets := []map[string]string{}
+		for _, set := range array.ParameterSets {
+			sets = append(sets, set.Parameters)
+		}
+		return sets, nil
+	}
+
+	size := 1
+	seen := map[string]bool{}
+	for _, axis := range array.Grid {
+		if len(axis.Values) == 0 {
+			return nil, grpc.Errorf(codes.InvalidArgument, "Grid parameter %s has no values", axis.Name)
+		}
+		if seen[axis.Name] {
+			return nil, grpc.Errorf(codes.InvalidArgument, "Grid parameter %s is given twice", axis.Name)
+		}
+		seen[axis.Name] = true
+		size *= len(axis.Values)
+		if size > maxArraySize {
+			return nil, grpc.Errorf(codes.InvalidArgument, "Array jobs have at most %d jobs", maxArraySize)
+		}
+	}
+	sets := make([]map[string]string, size)
+	for i := range sets {
+		sets[i] = map[string]string{}
+		// i in the mixed radix of the axis lengths, the last axis varying
+		// fastest
+		rest := i
+		for a := len(array.Grid) - 1; a >= 0; a-- {
+			axis := array.Grid[a]
+			sets[i][axis.Name] = axis.Values[rest%len(axis.Values)]
+			rest /= len(axis.Values)
+		}
+	}
+	return sets, nil
+}
+
+// renderArrayJobs returns the jobs of array, numbered by their index.
+func renderArrayJobs(array *ArrayJob) ([]*Job, error) {
+	base := array.Base
+	if base == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "Array jobs need a base job")
+	}
+	err := validateParameters(array.Parameters, base.Input, base.Metadata)
+	if err == nil {
+		err = validateJobTimes(base)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sets, err := arrayParameterSets(array)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &JobTemplate{
+		Project:    base.Project,
+		Kind:       base.Kind,
+		Input:      base.Input,
+		Metadata:   base.Metadata,
+		MaxRuntime: base.MaxRuntime,
+		Parameters: array.Parameters,
+	}
+	jobs := []*Job{}
+	for i, set := range sets {
+		job, err := renderJobTemplate(template, set)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "Job %d: %s", i, status.Convert(err).Message())
+		}
+		job.RunAfter = base.RunAfter
+		job.Deadline = base.Deadline
+		job.ArrayIndex = uint32(i)
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// arrayStatus counts jobs by status.
+func arrayStatus(counts map[Job_Status]uint32) *ArrayStatus {
+	ret := &ArrayStatus{
+		Pending:   counts[Job_PENDING],
+		Pulled:    counts[Job_PULLED],
+		Running:   counts[Job_RUNNING],
+		Completed: counts[Job_COMPLETED],
+		Failed:    counts[Job_FAILED],
+		Killed:    counts[Job_KILLED],
+		Expired:   counts[Job_EXPIRED],
+	}
+	for status, count := range counts {
+		if isFinished(status) {
+			ret.Done += count
+		}
+	}
+	return ret
+}
+
+const arrayJobColumns = `id, definition::text, size, EXTRACT(EPOCH FROM created)::bigint`
+
+// scanArrayJob reads a row of arrayJobColumns.
+func scanArrayJob(row interface {
+	Scan(dest ...interface{}) error
+}) (*ArrayJob, error) {
+	array := &ArrayJob{}
+	var id uint64
+	var definition string
+	var size uint32
+	var created int64
+	err := row.Scan(&id, &definition, &size, &created)
+	if err != nil {
+		return nil, err
+	}
+	err = scanJSONB(definition, array)
+	if err != nil {
+		return nil, err
+	}
+	array.Id = id
+	array.Size_ = size
+	array.Created = created
+	return array, nil
+}
+
+// insertArrayJobs inserts the jobs of an array in tx with a single statement.
+// The jobs only differ in their input, metadata and index.
+func insertArrayJobs(ctx context.Context, tx *sql.Tx, jobs []*Job, creator string) ([]*Job, error) {
+	inputs := make([]string, len(jobs))
+	metadata := make([]string, len(jobs))
+	indexes := make([]int64, len(jobs))
+	for i, job := range jobs {
+		inputs[i] = job.Input
+		metadata[i] = job.Metadata
+		indexes[i] = int64(job.ArrayIndex)
+	}
+	base := jobs[0]
+	rows, err := tx.QueryContext(ctx, `
+		INSERT INTO jobs (project, status, metadata, creator, input, output, kind, trace_parent, run_after, max_runtime, deadline,
+			kind_version, template_id, template_version, array_id, array_index)
+		SELECT $1, $2::smallint, t.metadata, $3, t.input, '', $4, $5, $6::timestamp, $7::bigint, $8::timestamp,
+			$9::integer, $10::integer, $11::integer, $12::integer, t.array_index
+		FROM unnest($13::text[], $14::text[], $15::integer[]) AS t(input, metadata, array_index)
+		RETURNING `+jobColumns+`;`,
+		base.Project, base.Status, creator, base.Kind, traceParent(ctx), timeOrNull(base.RunAfter), base.MaxRuntime,
+		timeOrNull(base.Deadline), base.KindVersion, base.TemplateId, base.TemplateVersion, base.ArrayId,
+		pq.Array(inputs), pq.Array(metadata), pq.Array(indexes),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	created := []*Job{}
+	for rows.Next() {
+		job := &Job{}
+		err = rows.Scan(jobFields(job)...)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, job)
+	}
+	return created, rows.Err()
+}
+
+// CreateArrayJob stores array along with its jobs.
+func (storage *WonderlandStorage) CreateArrayJob(ctx context.Context, array *ArrayJob, jobs []*Job, creator User) (created *ArrayJob, err error) {
+	ctx, span := startStorageSpan(ctx, "CreateArrayJob")
+	defer func() { endSpan(span, err) }()
+
+	definition, err := auditMarshaler.MarshalToString(&ArrayJob{
+		Base:          array.Base,
+		Parameters:    array.Parameters,
+		Grid:          array.Grid,
+		ParameterSets: array.ParameterSets,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err = scanArrayJob(tx.QueryRowContext(ctx, `
+		INSERT INTO job_arrays (project, kind, definition, size, creator, created)
+		VALUES ($1, $2, $3::jsonb, $4, $5, $6)
+		RETURNING `+arrayJobColumns+`;`,
+		array.Base.Project,
+		array.Base.Kind,
+		definition,
+		len(jobs),
+		creator.Username,
+		storage.now(),
+	))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, 0, array.Base.Project, nil, created)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	for _, job := range jobs {
+		job.ArrayId = created.Id
+	}
+	createdJobs, err := insertArrayJobs(ctx, tx, jobs, creator.Username)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	events, err := recordJobsCreated(ctx, tx, createdJobs)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	events = append([]*AuditEvent{event}, events...)
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(events...)
+	created.Status = arrayStatus(map[Job_Status]uint32{Job_PENDING: created.Size_})
+	return created, nil
+}
+
+// GetArrayJob returns an array job with the counts of its jobs by status.
+func (storage *WonderlandStorage) GetArrayJob(ctx context.Context, id uint64) (array *ArrayJob, err error) {
+	ctx, span := startStorageSpan(ctx, "GetArrayJob")
+	defer func() { endSpan(span, err) }()
+
+	array, err = scanArrayJob(storage.db.QueryRowContext(ctx, `
+		SELECT `+arrayJobColumns+`
+		FROM job_arrays
+		WHERE id=$1;`, id,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := storage.db.QueryContext(ctx, `
+		SELECT status, count(*)
+		FROM jobs
+		WHERE array_id=$1 AND deleted_at IS NULL
+		GROUP BY status;`, id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[Job_Status]uint32{}
+	for rows.Next() {
+		var status Job_Status
+		var count uint32
+		err = rows.Scan(&status, &count)
+		if err != nil {
+			return nil, err
+		}
+		counts[status] = count
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	array.Status = arrayStatus(counts)
+	return array, nil
+}
+
+// KillArrayJob kills the jobs of an array job which are not finished yet.
+func (storage *WonderlandStorage) KillArrayJob(ctx context.Context, id uint64) (killed int, err error) {
+	ctx, span := startStorageSpan(ctx, "KillArrayJob")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+jobColumns+`
+		FROM jobs
+		WHERE array_id=$1 AND deleted_at IS NULL AND status IN ($2, $3, $4)
+		ORDER BY id
+		FOR UPDATE;`, id, Job_PENDING, Job_PULLED, Job_RUNNING,
+	)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	running, err := queryJobs(rows)
+	if err == nil {
+		err = rows.Err()
+	}
+	rows.Close()
+	if err != nil || len(running.Jobs) == 0 {
+		tx.Rollback()
+		return 0, err
+	}
+
+	now := storage.now()
+	events := []*AuditEvent{}
+	jobs := []*Job{}
+	for _, before := range running.Jobs {
+		job := &Job{}
+		err = tx.QueryRowContext(ctx, `
+			UPDATE jobs
+			SET
+				status=$1,
+				last_modified=$2
+			WHERE id=$3
+			RETURNING `+jobColumns+`;`, Job_KILLED, now, before.Id,
+		).Scan(jobFields(job)...)
+		var event *AuditEvent
+		if err == nil {
+			event, err = recordAuditEvent(ctx, tx, job.Id, job.Project, before, job)
+		}
+		if err == nil {
+			err = storage.enqueueWebhookDeliveries(ctx, tx, before, job)
+		}
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		events = append(events, event)
+		jobs = append(jobs, job)
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	storage.exportAuditEvents(events...)
+	for i, job := range jobs {
+		linkJobTrace(ctx, job)
+		countFinishedJob(running.Jobs[i], job)
+	}
+	return len(jobs), nil
+}
+
+// authorizeArrayJob reads the array job with the given id and checks that
+// user has perm on its jobs.
+func (s *Server) authorizeArrayJob(ctx context.Context, user User, perm Permission, id uint64) (*ArrayJob, error) {
+	if !user.MayEver(perm) {
+		return nil, errNoAccess
+	}
+
+	array, err := s.Storage.GetArrayJob(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, grpc.Errorf(codes.NotFound, "Array job %d does not exist", id)
+	}
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+	if !user.Can(perm, array.Base.Project, array.Base.Kind) {
+		return nil, errNoAccess
+	}
+	return array, nil
+}
+
+// CreateArrayJob creates the jobs of an array job at once, all of them or
+// none.
+func (s *Server) CreateArrayJob(ctx context.Context, in *ArrayJob) (*ArrayJob, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if in.Base == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "Array jobs need a base job")
+	}
+	if in.Base.Project == "" {
+		project, err := user.onlyProject(PermCreateJobs)
+		if err != nil {
+			return nil, err
+		}
+		in.Base.Project = project
+	}
+	if !user.Can(PermCreateJobs, in.Base.Project, in.Base.Kind) {
+		return nil, errNoAccess
+	}
+	jobs, err := renderArrayJobs(in)
+	if err != nil {
+		return nil, err
+	}
+	err = s.validateJobDocuments(ctx, jobs...)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := s.Storage.CreateArrayJob(ctx, in, jobs, user)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) GetArrayJob(ctx context.Context, in *RequestWithId) (*ArrayJob, error) {
+	user := getAuthUserFromContext(ctx)
+
+	return s.authorizeArrayJob(ctx, user, PermGetJobs, in.Id)
+}
+
+func (s *Server) KillArrayJob(ctx context.Context, in *RequestWithId) (*ArrayJob, error) {
+	user := getAuthUserFromContext(ctx)
+
+	_, err := s.authorizeArrayJob(ctx, user, PermKillJobs, in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.Storage.KillArrayJob(ctx, in.Id)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+	ret, err := s.Storage.GetArrayJob(ctx, in.Id)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
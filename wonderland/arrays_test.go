@@ -0,0 +1,96 @@
+package wonderland
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testArrayJob() *ArrayJob {
+	return &ArrayJob{
+		Base: &Job{
+			Project:  "lhcb",
+			Kind:     "docker",
+			Input:    `{"learning_rate": "{{lr}}", "layers": "{{layers}}"}`,
+			Metadata: `sweep {{lr}}/{{layers}}`,
+			RunAfter: 1530000000,
+		},
+		Parameters: []*TemplateParameter{
+			{Name: "lr", Type: TemplateParameter_NUMBER},
+			{Name: "layers", Type: TemplateParameter_INTEGER, DefaultValue: "2", HasDefault: true},
+		},
+		Grid: []*ArrayAxis{
+			{Name: "lr", Values: []string{"0.1", "0.01"}},
+			{Name: "layers", Values: []string{"2", "4", "8"}},
+		},
+	}
+}
+
+func TestArrayParameterSets(t *testing.T) {
+	sets, err := arrayParameterSets(testArrayJob())
+	checkTestErr(err, t)
+	expected := []string{"0.1/2", "0.1/4", "0.1/8", "0.01/2", "0.01/4", "0.01/8"}
+	if len(sets) != len(expected) {
+		t.Fatalf("%d parameter sets", len(sets))
+	}
+	for i, set := range sets {
+		if set["lr"]+"/"+set["layers"] != expected[i] {
+			t.Errorf("parameter set %d is %v", i, set)
+		}
+	}
+
+	invalid := []func(*ArrayJob){
+		func(array *ArrayJob) { array.Grid = nil },
+		func(array *ArrayJob) { array.ParameterSets = []*ParameterSet{{}} },
+		func(array *ArrayJob) { array.Grid[1].Name = "lr" },
+		func(array *ArrayJob) { array.Grid[1].Values = nil },
+		func(array *ArrayJob) { array.Grid[0].Values = make([]string, maxArraySize/2) },
+	}
+	for i, change := range invalid {
+		array := testArrayJob()
+		change(array)
+		_, err := arrayParameterSets(array)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("change %d should make the array invalid", i)
+		}
+	}
+}
+
+func TestRenderArrayJobs(t *testing.T) {
+	array := testArrayJob()
+	array.Grid = nil
+	array.ParameterSets = []*ParameterSet{
+		{Parameters: map[string]string{"lr": "0.5"}},
+		{Parameters: map[string]string{"lr": "0.05", "layers": "16"}},
+	}
+	jobs, err := renderArrayJobs(array)
+	checkTestErr(err, t)
+	if len(jobs) != 2 {
+		t.Fatalf("%d jobs", len(jobs))
+	}
+	if jobs[1].Input != `{"layers":16,"learning_rate":0.05}` || jobs[1].Metadata != "sweep 0.05/16" ||
+		jobs[1].ArrayIndex != 1 || jobs[1].RunAfter != 1530000000 || jobs[0].Input != `{"layers":2,"learning_rate":0.5}` {
+		t.Errorf("rendered %v", jobs)
+	}
+
+	array.ParameterSets[1].Parameters["lr"] = "fast"
+	_, err = renderArrayJobs(array)
+	if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message()[:7] != "Job 1: " {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	array = testArrayJob()
+	array.Base.Input = `{"seed": "{{seed}}"}`
+	_, err = renderArrayJobs(array)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fail()
+	}
+}
+
+func TestArrayStatus(t *testing.T) {
+	status := arrayStatus(map[Job_Status]uint32{Job_PENDING: 3, Job_RUNNING: 2, Job_COMPLETED: 4, Job_FAILED: 1})
+	if status.Pending != 3 || status.Running != 2 || status.Completed != 4 || status.Done != 5 {
+		t.Errorf("status %v", status)
+	}
+}
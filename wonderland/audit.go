@@ -0,0 +1,306 @@
+package wonderland
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// requestInfo describes how a request reached the server, for audit events.
+type requestInfo struct {
+	// RPC is the full gRPC method name, or the HTTP method and path of
+	// gateway requests.
+	RPC         string
+	PeerAddress string
+}
+
+func withRequestInfo(ctx context.Context, rpc, peerAddress string) context.Context {
+	return context.WithValue(ctx, "request-info", requestInfo{RPC: rpc, PeerAddress: peerAddress})
+}
+
+func getRequestInfoFromContext(ctx context.Context) requestInfo {
+	v := ctx.Value("request-info")
+	if v != nil {
+		return v.(requestInfo)
+	}
+	return requestInfo{}
+}
+
+var auditMarshaler = &jsonpb.Marshaler{OrigName: true}
+
+// auditJSON marshals msg the way the REST API returns it, or returns an
+// empty string for nil.
+func auditJSON(msg proto.Message) (string, error) {
+	if msg == nil || reflect.ValueOf(msg).IsNil() {
+		return "", nil
+	}
+	return auditMarshaler.MarshalToString(msg)
+}
+
+// auditDiff returns the sorted names of the top-level fields that differ
+// between the JSON objects before and after.
+func auditDiff(before, after string) ([]string, error) {
+	fields := [2]map[string]interface{}{{}, {}}
+	for i, content := range []string{before, after} {
+		if content == "" {
+			continue
+		}
+		err := json.Unmarshal([]byte(content), &fields[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	changed := []string{}
+	for name, value := range fields[0] {
+		if !reflect.DeepEqual(value, fields[1][name]) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range fields[1] {
+		if _, ok := fields[0][name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// auditFields returns the JSON object content with only the given top-level
+// fields.
+func auditFields(content string, names []string) (string, error) {
+	fields := map[string]json.RawMessage{}
+	err := json.Unmarshal([]byte(content), &fields)
+	if err != nil {
+		return "", err
+	}
+	kept := map[string]json.RawMessage{}
+	for _, name := range names {
+		if value, ok := fields[name]; ok {
+			kept[name] = value
+		}
+	}
+	trimmed, err := json.Marshal(kept)
+	return string(trimmed), err
+}
+
+// newAuditEvent describes the change of an object from before to after made
+// by the request in ctx. before or after is nil if the object did not exist.
+// When both exist, only their changed fields are kept.
+func newAuditEvent(ctx context.Context, jobID uint64, project string, before, after proto.Message) (*AuditEvent, error) {
+	user := getAuthUserFromContext(ctx)
+	info := getRequestInfoFromContext(ctx)
+
+	event := &AuditEvent{
+		Principal:   user.Username,
+		Rpc:         info.RPC,
+		JobId:       jobID,
+		Project:     project,
+		PeerAddress: info.PeerAddress,
+	}
+	if user.Certificate != nil {
+		event.CertSerial = serialString(user.Certificate)
+	}
+
+	var err error
+	event.Before, err = auditJSON(before)
+	if err != nil {
+		return nil, err
+	}
+	event.After, err = auditJSON(after)
+	if err != nil {
+		return nil, err
+	}
+	event.Changed, err = auditDiff(event.Before, event.After)
+	if err != nil || event.Before == "" || event.After == "" {
+		return event, err
+	}
+	event.Before, err = auditFields(event.Before, event.Changed)
+	if err != nil {
+		return nil, err
+	}
+	event.After, err = auditFields(event.After, event.Changed)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+const auditEventColumns = `id, created, principal, rpc, job_id, project, COALESCE(before::text, ''),
+	COALESCE(after::text, ''), changed, peer_address, cert_serial`
+
+// scanAuditEvent reads a row of auditEventColumns.
+func scanAuditEvent(row interface {
+	Scan(dest ...interface{}) error
+}) (*AuditEvent, error) {
+	event := &AuditEvent{}
+	var created time.Time
+	err := row.Scan(
+		&event.Id,
+		&created,
+		&event.Principal,
+		&event.Rpc,
+		&event.JobId,
+		&event.Project,
+		&event.Before,
+		&event.After,
+		pq.Array(&event.Changed),
+		&event.PeerAddress,
+		&event.CertSerial,
+	)
+	if err != nil {
+		return nil, err
+	}
+	event.Created = created.Unix()
+	return event, nil
+}
+
+// recordAuditEvent stores the change of an object from before to after in
+// tx, so that the event is kept exactly when the change is committed.
+// Changes of jobs are appended to the change feed as well.
+func recordAuditEvent(ctx context.Context, tx *sql.Tx, jobID uint64, project string, before, after proto.Message) (*AuditEvent, error) {
+	ctx, span := startStorageSpan(ctx, "RecordAuditEvent")
+	event, err := newAuditEvent(ctx, jobID, project, before, after)
+	if err == nil {
+		event, err = scanAuditEvent(tx.QueryRowContext(ctx, `
+			INSERT INTO audit_events (principal, rpc, job_id, project, before, after, changed, peer_address, cert_serial)
+			VALUES ($1, $2, $3, $4, NULLIF($5, '')::jsonb, NULLIF($6, '')::jsonb, $7, $8, $9)
+			RETURNING `+auditEventColumns+`;`,
+			event.Principal,
+			event.Rpc,
+			event.JobId,
+			event.Project,
+			event.Before,
+			event.After,
+			pq.Array(event.Changed),
+			event.PeerAddress,
+			event.CertSerial,
+		))
+	}
+	if err == nil && jobID != 0 {
+		beforeJob, _ := before.(*Job)
+		afterJob, _ := after.(*Job)
+		err = appendJobEvent(ctx, tx, event, beforeJob, afterJob)
+	}
+	endSpan(span, err)
+	return event, err
+}
+
+// recordJobsCreated stores the creation of jobs in tx like recordAuditEvent,
+// with a single statement for their audit events and one for the change feed.
+func recordJobsCreated(ctx context.Context, tx *sql.Tx, jobs []*Job) (events []*AuditEvent, err error) {
+	ctx, span := startStorageSpan(ctx, "RecordJobsCreated")
+	defer func() { endSpan(span, err) }()
+
+	if len(jobs) == 0 {
+		return []*AuditEvent{}, nil
+	}
+	audits := make([]*AuditEvent, len(jobs))
+	ids := make([]int64, len(jobs))
+	projects := make([]string, len(jobs))
+	contents := make([]string, len(jobs))
+	changed := make([]string, len(jobs))
+	for i, job := range jobs {
+		audits[i], err = newAuditEvent(ctx, job.Id, job.Project, nil, job)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = int64(job.Id)
+		projects[i] = job.Project
+		contents[i] = audits[i].After
+		// field names have no commas
+		changed[i] = strings.Join(audits[i].Changed, ",")
+	}
+
+	principal, rpc, peerAddress, certSerial := audits[0].Principal, audits[0].Rpc, audits[0].PeerAddress, audits[0].CertSerial
+	rows, err := tx.QueryContext(ctx, `
+		INSERT INTO audit_events (principal, rpc, job_id, project, after, changed, peer_address, cert_serial)
+		SELECT $1, $2, t.job_id, t.project, t.after::jsonb, string_to_array(t.changed, ','), $3, $4
+		FROM unnest($5::bigint[], $6::text[], $7::text[], $8::text[]) AS t(job_id, project, after, changed)
+		RETURNING `+auditEventColumns+`;`,
+		principal, rpc, peerAddress, certSerial,
+		pq.Array(ids), pq.Array(projects), pq.Array(contents), pq.Array(changed),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events = []*AuditEvent{}
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	err = appendJobsCreated(ctx, tx, audits, jobs)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// AuditFileSink appends audit events to a file as JSON lines, for shipping
+// them to a log pipeline.
+type AuditFileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func OpenAuditFileSink(path string) (*AuditFileSink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditFileSink{file: file}, nil
+}
+
+// Write appends one line per event.
+func (s *AuditFileSink) Write(events ...*AuditEvent) error {
+	var buf bytes.Buffer
+	for _, event := range events {
+		err := auditMarshaler.Marshal(&buf, event)
+		if err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.file.Write(buf.Bytes())
+	return err
+}
+
+func (s *AuditFileSink) Close() error {
+	return s.file.Close()
+}
+
+// exportAuditEvents writes committed events to the file sink, if any. The
+// database stays the record of truth, so failures are only logged.
+func (storage *WonderlandStorage) exportAuditEvents(events ...*AuditEvent) {
+	if storage.AuditSink == nil || len(events) == 0 {
+		return
+	}
+	err := storage.AuditSink.Write(events...)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to export audit events")
+	}
+}
@@ -0,0 +1,77 @@
+package wonderland
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestNewAuditEvent(t *testing.T) {
+	user := User{Username: "alice", Certificate: &x509.Certificate{SerialNumber: big.NewInt(0xbeef)}}
+	ctx := context.WithValue(context.Background(), "authorized-user", user)
+	ctx = withRequestInfo(ctx, "/Wonderland/KillJob", "10.0.0.1:4242")
+
+	before := &Job{Id: 7, Project: "ship-shield", Kind: "docker", Status: Job_RUNNING, Metadata: `{"a": 1}`}
+	after := &Job{Id: 7, Project: "ship-shield", Kind: "docker", Status: Job_KILLED, Metadata: `{"a": 1}`}
+	event, err := newAuditEvent(ctx, after.Id, after.Project, before, after)
+	checkTestErr(err, t)
+	if event.Principal != "alice" || event.CertSerial != "beef" || event.Rpc != "/Wonderland/KillJob" ||
+		event.PeerAddress != "10.0.0.1:4242" || event.JobId != 7 || event.Project != "ship-shield" {
+		t.Log(event)
+		t.Fail()
+	}
+	if !reflect.DeepEqual(event.Changed, []string{"status"}) {
+		t.Log(event.Changed)
+		t.Fail()
+	}
+	// only the changed fields are stored
+	if event.Before != `{"status":"RUNNING"}` || event.After != `{"status":"KILLED"}` {
+		t.Log(event.Before, event.After)
+		t.Fail()
+	}
+
+	// deletions have no after state and every field changes
+	event, err = newAuditEvent(ctx, before.Id, before.Project, before, nil)
+	checkTestErr(err, t)
+	if event.After != "" || !reflect.DeepEqual(event.Changed, []string{"id", "kind", "metadata", "project", "status"}) {
+		t.Log(event)
+		t.Fail()
+	}
+}
+
+func TestAuditFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	checkTestErr(err, t)
+	path := filepath.Join(dir, "audit.jsonl")
+
+	sink, err := OpenAuditFileSink(path)
+	checkTestErr(err, t)
+	checkTestErr(sink.Write(&AuditEvent{Id: 1, Principal: "alice"}, &AuditEvent{Id: 2, Principal: "bob"}), t)
+	checkTestErr(sink.Close(), t)
+
+	// events are appended to existing files
+	sink, err = OpenAuditFileSink(path)
+	checkTestErr(err, t)
+	checkTestErr(sink.Write(&AuditEvent{Id: 3, JobId: 7, Changed: []string{"status"}}), t)
+	checkTestErr(sink.Close(), t)
+
+	content, err := ioutil.ReadFile(path)
+	checkTestErr(err, t)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 3 {
+		t.FailNow()
+	}
+	var last map[string]interface{}
+	checkTestErr(json.Unmarshal([]byte(lines[2]), &last), t)
+	if last["id"] != "3" || last["job_id"] != "7" {
+		t.Log(lines[2])
+		t.Fail()
+	}
+}
@@ -0,0 +1,194 @@
+package wonderland
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	defaultCertificateLifetime    = 7 * 24 * time.Hour
+	defaultCertificateMaxLifetime = 30 * 24 * time.Hour
+	// certificates are valid a little before issuance to tolerate clock skew
+	certificateBackdate = 5 * time.Minute
+)
+
+// grantNamePattern is what projects and kinds may look like in issued
+// certificates. Dots would break the "project.kind" format.
+var grantNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,40}$`)
+
+// commonNamePattern is what user names may look like in issued certificates,
+// such as "alice.smith" or "ci@example.org". They are stored in columns of
+// at most 40 characters.
+var commonNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.@+-]{1,40}$`)
+
+// CertificateAuthority signs client certificates carrying grants in the
+// Organization entries, as parsed by grantsFromCertificate.
+type CertificateAuthority struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+
+	// MaxLifetime is the longest validity of an issued certificate.
+	MaxLifetime time.Duration
+}
+
+func LoadCertificateAuthority(certFile, keyFile string) (*CertificateAuthority, error) {
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+	if !cert.IsCA {
+		return nil, fmt.Errorf("%s is not a CA certificate", certFile)
+	}
+	key, ok := pair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported CA key type %T", pair.PrivateKey)
+	}
+	return &CertificateAuthority{cert: cert, key: key, MaxLifetime: defaultCertificateMaxLifetime}, nil
+}
+
+// GenerateCertificateAuthority creates a self-signed CA with an ECDSA P-256
+// key and returns the PEM encoded certificate and key.
+func GenerateCertificateAuthority(commonName string, lifetime time.Duration) (certPEM []byte, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-certificateBackdate),
+		NotAfter:              now.Add(lifetime),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+func randomSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+// ParseCSR decodes and checks the signature of a PEM encoded certificate
+// signing request.
+func ParseCSR(csrPEM string) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode([]byte(csrPEM))
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, grpc.Errorf(codes.InvalidArgument, "CSR must be a PEM encoded CERTIFICATE REQUEST")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "Invalid CSR: %v", err)
+	}
+	err = csr.CheckSignature()
+	if err != nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "Invalid CSR signature: %v", err)
+	}
+	if !commonNamePattern.MatchString(csr.Subject.CommonName) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "CSR common name must match %s", commonNamePattern)
+	}
+	return csr, nil
+}
+
+// ParseGrants validates grants in the "project.kind" format.
+func ParseGrants(fields []string) ([]Grant, error) {
+	if len(fields) == 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "At least one grant is required")
+	}
+	grants := []Grant{}
+	for _, field := range fields {
+		parts := strings.Split(field, ".")
+		if len(parts) != 2 || !grantNamePattern.MatchString(parts[0]) || !grantNamePattern.MatchString(parts[1]) {
+			return nil, grpc.Errorf(codes.InvalidArgument, "Invalid grant %q, expected project.kind", field)
+		}
+		grants = append(grants, Grant{Project: parts[0], Kind: parts[1]})
+	}
+	return grants, nil
+}
+
+func (g Grant) String() string {
+	return g.Project + "." + g.Kind
+}
+
+// Sign issues a client certificate for the key and common name of csr with
+// the given grants. A zero lifetime means the default one.
+func (ca *CertificateAuthority) Sign(csr *x509.CertificateRequest, grants []Grant, lifetime time.Duration) (*x509.Certificate, error) {
+	if lifetime == 0 {
+		lifetime = defaultCertificateLifetime
+	}
+	if lifetime < 0 || lifetime > ca.MaxLifetime {
+		return nil, grpc.Errorf(codes.InvalidArgument, "Certificate lifetime must be at most %v", ca.MaxLifetime)
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	organization := []string{}
+	for _, grant := range grants {
+		organization = append(organization, grant.String())
+	}
+	now := time.Now()
+	notAfter := now.Add(lifetime)
+	if notAfter.After(ca.cert.NotAfter) {
+		notAfter = ca.cert.NotAfter
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: csr.Subject.CommonName, Organization: organization},
+		NotBefore:    now.Add(-certificateBackdate),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, csr.PublicKey, ca.key)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+// IssuedCertificateFrom describes cert the way it is returned and recorded.
+func IssuedCertificateFrom(cert *x509.Certificate, issuedBy string) *IssuedCertificate {
+	return &IssuedCertificate{
+		Serial:      serialString(cert),
+		CommonName:  cert.Subject.CommonName,
+		Grants:      cert.Subject.Organization,
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})),
+		NotAfter:    cert.NotAfter.Unix(),
+		IssuedBy:    issuedBy,
+	}
+}
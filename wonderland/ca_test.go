@@ -0,0 +1,116 @@
+package wonderland
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestCSR(t *testing.T, cn string) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	checkTestErr(err, t)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: cn}}, key)
+	checkTestErr(err, t)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+}
+
+func newTestCertificateAuthority(t *testing.T) *CertificateAuthority {
+	dir, err := ioutil.TempDir("", "wonderland-ca")
+	checkTestErr(err, t)
+	defer os.RemoveAll(dir)
+
+	certPEM, keyPEM, err := GenerateCertificateAuthority("wonderland", time.Hour*24*365)
+	checkTestErr(err, t)
+	certFile, keyFile := filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key")
+	checkTestErr(ioutil.WriteFile(certFile, certPEM, 0644), t)
+	checkTestErr(ioutil.WriteFile(keyFile, keyPEM, 0600), t)
+
+	ca, err := LoadCertificateAuthority(certFile, keyFile)
+	checkTestErr(err, t)
+	return ca
+}
+
+func TestCertificateAuthoritySign(t *testing.T) {
+	ca := newTestCertificateAuthority(t)
+
+	csr, err := ParseCSR(newTestCSR(t, "alice"))
+	checkTestErr(err, t)
+	grants, err := ParseGrants([]string{"ship-shield.ANY", "ANY.docker"})
+	checkTestErr(err, t)
+
+	cert, err := ca.Sign(csr, grants, 0)
+	checkTestErr(err, t)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	checkTestErr(err, t)
+	if cert.NotAfter.Sub(time.Now()) > defaultCertificateLifetime {
+		t.Fail()
+	}
+
+	user, err := userFromCertificate(cert)
+	checkTestErr(err, t)
+	if user.Username != "alice" || !user.Can(PermCreateJobs, "ship-shield", "x") || !user.Can(PermPullJobs, AnyScope, "docker") {
+		t.Fail()
+	}
+
+	_, err = ca.Sign(csr, grants, ca.MaxLifetime+time.Hour)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fail()
+	}
+}
+
+func TestIssuePolicy(t *testing.T) {
+	for _, fields := range [][]string{nil, {"ship-shield"}, {"ship.shield.ANY"}, {"ship shield.ANY"}} {
+		_, err := ParseGrants(fields)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("grants %v were accepted", fields)
+		}
+	}
+	for _, csr := range []string{"", "garbage", newTestCSR(t, ""), newTestCSR(t, "alice smith")} {
+		_, err := ParseCSR(csr)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CSR %q was accepted", csr)
+		}
+	}
+	for _, cn := range []string{"alice.smith", "ci@example.org"} {
+		_, err := ParseCSR(newTestCSR(t, cn))
+		checkTestErr(err, t)
+	}
+
+	s := &Server{CA: newTestCertificateAuthority(t)}
+	submitter := User{Username: "alice", Bindings: []*RoleBinding{certificateBinding("alice", "ship-shield", AnyScope)}}
+	ctx := context.WithValue(context.Background(), "authorized-user", submitter)
+	_, err := s.IssueCertificate(ctx, &IssueCertificateRequest{Csr: newTestCSR(t, "bob"), Grants: []string{"ship-shield.ANY"}})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fail()
+	}
+
+	// the common name of a cluster admin would bring along their bindings
+	admin := User{Username: "bob", Bindings: []*RoleBinding{{Principal: "bob", Role: string(RoleProjectAdmin), Project: "ship-shield", Kind: AnyScope}}}
+	s.RoleBindings = &countingBindingStore{bindings: []*RoleBinding{{Principal: "root", Role: string(RoleClusterAdmin), Project: AnyScope, Kind: AnyScope}}}
+	adminCtx := context.WithValue(context.Background(), "authorized-user", admin)
+	_, err = s.IssueCertificate(adminCtx, &IssueCertificateRequest{Csr: newTestCSR(t, "root"), Grants: []string{"ship-shield.ANY"}})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Error("project admin was issued a certificate of a cluster admin")
+	}
+
+	// token users have no certificate to renew
+	_, err = s.RenewCertificate(ctx, &RenewCertificateRequest{Csr: newTestCSR(t, "alice")})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fail()
+	}
+}
@@ -159,6 +159,18 @@ func TestGRPCJobCRUD(t *testing.T) {
 	_, err = c.DeleteJob(ctx, &RequestWithId{Id: createdJob.Id})
 	checkTestErr(err, t)
 
+	allJobs, err = c.ListJobs(ctx, &ListJobsRequest{Kind: "remove"})
+	checkTestErr(err, t)
+	if len(allJobs.Jobs) != 0 {
+		t.Fail()
+	}
+
+	readJob, err = c.UndeleteJob(ctx, &RequestWithId{Id: createdJob.Id})
+	checkTestErr(err, t)
+	if readJob.DeletedAt != 0 {
+		t.Fail()
+	}
+
 	//kill job
 	createdJob, err = c.CreateJob(ctx, &Job{Kind: "kill"})
 	checkTestErr(err, t)
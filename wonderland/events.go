@@ -0,0 +1,307 @@
+package wonderland
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	eventBatchSize    = 1000
+	eventPollInterval = time.Second
+)
+
+// jobEventType tells what kind of change turned before into after.
+func jobEventType(before, after *Job) JobEvent_Type {
+	switch {
+	case before == nil:
+		return JobEvent_CREATED
+	case after == nil:
+		return JobEvent_PURGED
+	case before.DeletedAt == 0 && after.DeletedAt != 0:
+		return JobEvent_DELETED
+	case before.DeletedAt != 0 && after.DeletedAt == 0:
+		return JobEvent_RESTORED
+	case before.Status != after.Status:
+		return JobEvent_STATUS_CHANGED
+	default:
+		return JobEvent_UPDATED
+	}
+}
+
+// appendJobEvent adds the change of a job described by audit to the change
+// feed in tx.
+func appendJobEvent(ctx context.Context, tx *sql.Tx, audit *AuditEvent, before, after *Job) error {
+	event := &JobEvent{Type: jobEventType(before, after)}
+	job := after
+	if after == nil {
+		job = before
+	}
+	if before != nil {
+		event.PreviousStatus = before.Status
+	}
+	// audit events only keep the changed fields, the feed has the whole job
+	content, err := auditJSON(job)
+	if err != nil {
+		return err
+	}
+
+	// the sequence is assigned when tx commits, so that events become
+	// visible in sequence order and consumers never skip one
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO job_events (type, job_id, project, kind, status, previous_status, changed, job, principal)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8::jsonb, $9);`,
+		event.Type,
+		job.Id,
+		job.Project,
+		job.Kind,
+		job.Status,
+		event.PreviousStatus,
+		pq.Array(audit.Changed),
+		content,
+		audit.Principal,
+	)
+	return err
+}
+
+// appendJobsCreated adds the creation of jobs, described by the audit events
+// of the same index, to the change feed in tx with a single statement.
+func appendJobsCreated(ctx context.Context, tx *sql.Tx, audits []*AuditEvent, jobs []*Job) error {
+	ids := make([]int64, len(jobs))
+	projects := make([]string, len(jobs))
+	kinds := make([]string, len(jobs))
+	statuses := make([]int64, len(jobs))
+	changed := make([]string, len(jobs))
+	contents := make([]string, len(jobs))
+	for i, job := range jobs {
+		ids[i] = int64(job.Id)
+		projects[i] = job.Project
+		kinds[i] = job.Kind
+		statuses[i] = int64(job.Status)
+		changed[i] = strings.Join(audits[i].Changed, ",")
+		// the audit events of creations keep the whole job
+		contents[i] = audits[i].After
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO job_events (type, job_id, project, kind, status, changed, job, principal)
+		SELECT $1::smallint, t.job_id, t.project, t.kind, t.status, string_to_array(t.changed, ','), t.job::jsonb, $2
+		FROM unnest($3::integer[], $4::text[], $5::text[], $6::smallint[], $7::text[], $8::text[])
+			AS t(job_id, project, kind, status, changed, job);`,
+		JobEvent_CREATED,
+		audits[0].Principal,
+		pq.Array(ids),
+		pq.Array(projects),
+		pq.Array(kinds),
+		pq.Array(statuses),
+		pq.Array(changed),
+		pq.Array(contents),
+	)
+	return err
+}
+
+const jobEventColumns = `sequence, type, job_id, project, kind, status, previous_status, changed, job,
+	EXTRACT(EPOCH FROM created)::bigint, principal`
+
+// ListJobEvents returns up to limit events of the change feed with at least
+// the sequence from, along with the largest compacted sequence and the
+// largest sequence so far.
+func (storage *WonderlandStorage) ListJobEvents(ctx context.Context, project, kind string, from uint64, limit int) (events []*JobEvent, horizon uint64, latest uint64, err error) {
+	ctx, span := startStorageSpan(ctx, "ListJobEvents")
+	defer func() { endSpan(span, err) }()
+
+	// a snapshot, so that the horizon matches the events
+	tx, err := storage.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		SELECT sequence, GREATEST(sequence, (SELECT COALESCE(max(sequence), 0) FROM job_events))
+		FROM job_event_horizon;`,
+	).Scan(&horizon, &latest)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	strQuery := `SELECT ` + jobEventColumns + ` FROM job_events WHERE sequence >= $1`
+	args := []interface{}{from}
+	if project != "" {
+		args = append(args, project)
+		strQuery += " AND project=$" + strconv.Itoa(len(args))
+	}
+	if kind != "" {
+		args = append(args, kind)
+		strQuery += " AND kind=$" + strconv.Itoa(len(args))
+	}
+	args = append(args, limit)
+	strQuery += " ORDER BY sequence LIMIT $" + strconv.Itoa(len(args)) + ";"
+
+	rows, err := tx.QueryContext(ctx, strQuery, args...)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer rows.Close()
+
+	events = []*JobEvent{}
+	for rows.Next() {
+		event := &JobEvent{Job: &Job{}}
+		var job []byte
+		err = rows.Scan(
+			&event.Sequence,
+			&event.Type,
+			&event.JobId,
+			&event.Project,
+			&event.Kind,
+			&event.Status,
+			&event.PreviousStatus,
+			pq.Array(&event.Changed),
+			&job,
+			&event.Created,
+			&event.Principal,
+		)
+		if err == nil {
+			err = scanJSONB(job, event.Job)
+		}
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		events = append(events, event)
+	}
+	err = rows.Err()
+	return events, horizon, latest, err
+}
+
+// CompactJobEvents deletes the events up to the newest one created before
+// the given time, and moves the horizon past them.
+func (storage *WonderlandStorage) CompactJobEvents(ctx context.Context, createdBefore time.Time) (compacted int64, err error) {
+	ctx, span := startStorageSpan(ctx, "CompactJobEvents")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// a prefix of the feed is deleted, as transactions committing late may
+	// have created newer events with older times
+	var last uint64
+	err = tx.QueryRowContext(ctx, `
+		WITH deleted AS (
+			DELETE FROM job_events
+			WHERE sequence <= (SELECT max(sequence) FROM job_events WHERE created < $1)
+			RETURNING sequence
+		)
+		SELECT count(*), COALESCE(max(sequence), 0) FROM deleted;`, createdBefore,
+	).Scan(&compacted, &last)
+	if err == nil && compacted > 0 {
+		_, err = tx.ExecContext(ctx, `UPDATE job_event_horizon SET sequence=GREATEST(sequence, $1);`, last)
+	}
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	return compacted, nil
+}
+
+// CompactEvents deletes the events older than retention every interval until
+// ctx is done.
+func (storage *WonderlandStorage) CompactEvents(ctx context.Context, retention time.Duration, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		compacted, err := storage.CompactJobEvents(ctx, storage.now().Add(-retention))
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to compact job events")
+		} else if compacted > 0 {
+			logrus.WithField("events", compacted).Info("Compacted job events")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func errEventsCompacted(horizon uint64) error {
+	return grpc.Errorf(codes.OutOfRange, "Events up to sequence %d were compacted", horizon)
+}
+
+// StreamEvents sends the change feed of jobs from in.FromSequence. In follow
+// mode it keeps polling for new events until the client goes away or the
+// server drains.
+func (s *Server) StreamEvents(in *StreamEventsRequest, stream Wonderland_StreamEventsServer) error {
+	ctx := stream.Context()
+	user := getAuthUserFromContext(ctx)
+
+	if in.Project == "" && !user.Can(PermListJobs, AnyScope, orAnyScope(in.Kind)) {
+		project, err := user.onlyProject(PermListJobs)
+		if err != nil {
+			return err
+		}
+		in.Project = project
+	}
+	if !user.Can(PermListJobs, orAnyScope(in.Project), orAnyScope(in.Kind)) {
+		return errNoAccess
+	}
+
+	ticker := time.NewTicker(eventPollInterval)
+	defer ticker.Stop()
+
+	next := in.FromSequence
+	for {
+		for {
+			events, horizon, latest, err := s.Storage.ListJobEvents(ctx, in.Project, in.Kind, next, eventBatchSize)
+			if err != nil {
+				return detailedInternalError(err)
+			}
+			if next == 0 {
+				next = horizon + 1
+			}
+			// events not sent yet were compacted
+			if next <= horizon {
+				return errEventsCompacted(horizon)
+			}
+			for _, event := range events {
+				err = stream.Send(event)
+				if err != nil {
+					return err
+				}
+				next = event.Sequence + 1
+			}
+			if len(events) < eventBatchSize {
+				// no other events up to latest match the filters, which
+				// keeps quiet filtered feeds ahead of compaction
+				next = latest + 1
+				break
+			}
+		}
+
+		if !in.Follow {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.draining():
+			return grpc.Errorf(codes.Unavailable, "Server is shutting down")
+		case <-ticker.C:
+		}
+	}
+}
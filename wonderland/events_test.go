@@ -0,0 +1,47 @@
+package wonderland
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+func TestJobEventType(t *testing.T) {
+	pending := &Job{Status: Job_PENDING}
+	pulled := &Job{Status: Job_PULLED}
+	deleted := &Job{Status: Job_PENDING, DeletedAt: 1525132800}
+	modified := &Job{Status: Job_PENDING, Metadata: `{"priority": 1}`}
+
+	expected := []struct {
+		before, after *Job
+		eventType     JobEvent_Type
+	}{
+		{nil, pending, JobEvent_CREATED},
+		{pending, pulled, JobEvent_STATUS_CHANGED},
+		{pending, modified, JobEvent_UPDATED},
+		{pending, deleted, JobEvent_DELETED},
+		{deleted, pending, JobEvent_RESTORED},
+		{deleted, nil, JobEvent_PURGED},
+	}
+	for _, e := range expected {
+		if jobEventType(e.before, e.after) != e.eventType {
+			t.Errorf("%v to %v should be %s", e.before, e.after, e.eventType)
+		}
+	}
+}
+
+type eventStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events []*JobEvent
+}
+
+func (s *eventStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *eventStream) Send(event *JobEvent) error {
+	s.events = append(s.events, event)
+	return nil
+}
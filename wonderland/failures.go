@@ -0,0 +1,133 @@
+package wonderland
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	maxJobErrorMessageLength = 4 * 1024
+	maxJobErrorDetailsLength = 64 * 1024
+	defaultFailureWindow     = 24 * time.Hour
+)
+
+var jobErrorCodePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// validateJobError checks the error a worker reports with a job update and
+// fills in the worker if it is missing. Jobs leaving FAILED, for example to
+// run again, lose their error.
+func validateJobError(job *Job, user User) error {
+	if job.Status != Job_FAILED {
+		job.Error = nil
+	}
+	if job.Error == nil {
+		return nil
+	}
+	if !jobErrorCodePattern.MatchString(job.Error.Code) {
+		return grpc.Errorf(codes.InvalidArgument, "Error code must match %s", jobErrorCodePattern)
+	}
+	if len(job.Error.Message) > maxJobErrorMessageLength || len(job.Error.Details) > maxJobErrorDetailsLength {
+		return grpc.Errorf(codes.InvalidArgument, "Error message must be at most %d and details at most %d bytes long",
+			maxJobErrorMessageLength, maxJobErrorDetailsLength)
+	}
+	if job.Error.Worker == "" {
+		job.Error.Worker = user.Username
+	}
+	return nil
+}
+
+// jobErrorJSON returns the value stored in the error column for e.
+func jobErrorJSON(e *JobError) (interface{}, error) {
+	if e == nil {
+		return nil, nil
+	}
+	return auditMarshaler.MarshalToString(e)
+}
+
+// jobErrorColumn scans the error column of jobs, leaving the error nil for
+// NULL.
+type jobErrorColumn struct {
+	err **JobError
+}
+
+func (c jobErrorColumn) Scan(src interface{}) error {
+	*c.err = nil
+	if src == nil {
+		return nil
+	}
+	e := &JobError{}
+	err := scanJSONB(src, e)
+	if err != nil {
+		return err
+	}
+	*c.err = e
+	return nil
+}
+
+// SummarizeFailures groups the jobs which failed since in.Since by project,
+// kind and error code, most frequent first.
+func (storage *WonderlandStorage) SummarizeFailures(ctx context.Context, in *SummarizeFailuresRequest) (ret *FailureSummary, err error) {
+	ctx, span := startStorageSpan(ctx, "SummarizeFailures")
+	defer func() { endSpan(span, err) }()
+
+	since := storage.now().Add(-defaultFailureWindow)
+	if in.Since != 0 {
+		since = time.Unix(in.Since, 0).UTC()
+	}
+	args := []interface{}{Job_FAILED, since}
+	strQuery := `
+		SELECT
+			project,
+			kind,
+			COALESCE(error->>'code', ''),
+			count(*),
+			count(*) FILTER (WHERE (error->>'retryable')::boolean),
+			EXTRACT(EPOCH FROM max(last_modified))::bigint,
+			(array_agg(id ORDER BY last_modified DESC, id DESC))[1],
+			(array_agg(COALESCE(error->>'message', '') ORDER BY last_modified DESC, id DESC))[1]
+		FROM jobs
+		WHERE status=$1 AND last_modified >= $2 AND deleted_at IS NULL`
+	if in.Project != "" {
+		args = append(args, in.Project)
+		strQuery += " AND project=$" + strconv.Itoa(len(args))
+	}
+	if in.Kind != "" {
+		args = append(args, in.Kind)
+		strQuery += " AND kind=$" + strconv.Itoa(len(args))
+	}
+	strQuery += `
+		GROUP BY 1, 2, 3
+		ORDER BY 4 DESC, 1, 2, 3;`
+
+	rows, err := storage.db.QueryContext(ctx, strQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret = &FailureSummary{Groups: []*FailureGroup{}}
+	for rows.Next() {
+		group := &FailureGroup{}
+		err = rows.Scan(
+			&group.Project,
+			&group.Kind,
+			&group.Code,
+			&group.Count,
+			&group.Retryable,
+			&group.LastFailed,
+			&group.LastJobId,
+			&group.LastMessage,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ret.Groups = append(ret.Groups, group)
+	}
+	err = rows.Err()
+	return ret, err
+}
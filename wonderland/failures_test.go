@@ -0,0 +1,49 @@
+package wonderland
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateJobError(t *testing.T) {
+	user := User{Username: "worker-7"}
+	job := &Job{Status: Job_FAILED, Error: &JobError{Code: "OUT_OF_MEMORY", Message: "killed by the OOM killer"}}
+	checkTestErr(validateJobError(job, user), t)
+	if job.Error.Worker != "worker-7" {
+		t.Fail()
+	}
+	// retried jobs drop the error of the previous run
+	retried := &Job{Status: Job_PENDING, Error: job.Error}
+	checkTestErr(validateJobError(retried, user), t)
+	if retried.Error != nil {
+		t.Fail()
+	}
+
+	invalid := []*Job{
+		{Status: Job_FAILED, Error: &JobError{}},
+		{Status: Job_FAILED, Error: &JobError{Code: "out of memory"}},
+		{Status: Job_FAILED, Error: &JobError{Code: "OOM", Details: strings.Repeat("x", maxJobErrorDetailsLength+1)}},
+	}
+	for _, job := range invalid {
+		if status.Code(validateJobError(job, user)) != codes.InvalidArgument {
+			t.Log(job)
+			t.Fail()
+		}
+	}
+}
+
+func TestJobErrorColumn(t *testing.T) {
+	var jobErr *JobError
+	column := jobErrorColumn{&jobErr}
+	checkTestErr(column.Scan([]byte(`{"code": "OOM", "retryable": true}`)), t)
+	if jobErr == nil || jobErr.Code != "OOM" || !jobErr.Retryable {
+		t.FailNow()
+	}
+	checkTestErr(column.Scan(nil), t)
+	if jobErr != nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,46 @@
+package wonderland
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/net/context"
+)
+
+// NewGateway returns an HTTP handler exposing the REST/JSON mapping of the
+// Wonderland API (see the google.api.http options in wonderland.proto).
+// Requests are served by s directly, with the User taken from the client
+// certificate of the HTTPS connection, so the same access rules apply as
+// for gRPC calls.
+func NewGateway(ctx context.Context, s *Server) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{OrigName: true, EmitDefaults: true}),
+	)
+	err := RegisterWonderlandHandlerServer(ctx, mux, s)
+	if err != nil {
+		return nil, err
+	}
+	return otelhttp.NewHandler(authenticateHTTP(s, mux), "gateway"), nil
+}
+
+func authenticateHTTP(s *Server, mux *runtime.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(withRequestInfo(r.Context(), r.Method+" "+r.URL.Path, r.RemoteAddr))
+
+		token := ""
+		if auth := r.Header.Get("Authorization"); strings.HasPrefix(strings.ToLower(auth), "bearer ") {
+			token = auth[len("bearer "):]
+		}
+		// the same rules as for gRPC calls, see AuthFuncOverride
+		user, err := s.authenticateRequest(r.Context(), token, verifiedCertificate(r.TLS))
+		if err != nil {
+			runtime.HTTPError(r.Context(), mux, &runtime.JSONPb{}, w, r, err)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "authorized-user", user)
+		mux.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
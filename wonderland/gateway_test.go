@@ -0,0 +1,82 @@
+package wonderland
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func gatewayRequest(t *testing.T, method, url string, organization ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, nil)
+	if organization != nil {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: "tester", Organization: organization}}
+		req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}, VerifiedChains: [][]*x509.Certificate{{cert}}}
+	}
+	return serveGateway(t, req)
+}
+
+func serveGateway(t *testing.T, req *http.Request) *httptest.ResponseRecorder {
+	gateway, err := NewGateway(context.Background(), &Server{})
+	checkTestErr(err, t)
+
+	rec := httptest.NewRecorder()
+	gateway.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGatewayRequiresCertificate(t *testing.T) {
+	rec := gatewayRequest(t, "GET", "/v1/jobs/1")
+	if rec.Code != http.StatusUnauthorized {
+		t.Fail()
+	}
+}
+
+func TestGatewayRejectsMalformedCertificate(t *testing.T) {
+	rec := gatewayRequest(t, "GET", "/v1/jobs/1", "no-dot")
+	if rec.Code != http.StatusInternalServerError {
+		t.Fail()
+	}
+}
+
+func TestGatewayAppliesAccessRules(t *testing.T) {
+	// workers cannot delete or kill jobs, so these fail before touching storage
+	rec := gatewayRequest(t, "DELETE", "/v1/jobs/1", "ANY.docker")
+	if rec.Code != http.StatusForbidden {
+		t.Fail()
+	}
+	rec = gatewayRequest(t, "POST", "/v1/jobs/1:kill", "ANY.docker")
+	if rec.Code != http.StatusForbidden {
+		t.Fail()
+	}
+}
+
+func TestGatewayAuthenticatesLikeGRPC(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "tester", Organization: []string{"ANY.docker"}}}
+
+	// a certificate the handshake did not verify is no certificate
+	req := httptest.NewRequest("DELETE", "/v1/jobs/1", nil)
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	rec := serveGateway(t, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Error(rec.Code)
+	}
+
+	// a stale token falls back to the verified certificate
+	req = httptest.NewRequest("DELETE", "/v1/jobs/1", nil)
+	req.Header.Set("Authorization", "Bearer stale-token")
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}, VerifiedChains: [][]*x509.Certificate{{cert}}}
+	rec = serveGateway(t, req)
+	if rec.Code != http.StatusForbidden {
+		t.Error(rec.Code)
+	}
+	req.TLS = nil
+	rec = serveGateway(t, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Error(rec.Code)
+	}
+}
@@ -0,0 +1,73 @@
+package wonderland
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// serviceName is the name of the Wonderland service in health checks.
+const serviceName = "Wonderland"
+
+const defaultHealthCheckTimeout = 2 * time.Second
+
+// HealthChecker keeps the status of the standard grpc.health.v1 service up
+// to date: both the server as a whole ("") and the Wonderland service are
+// SERVING while the database answers pings, and NOT_SERVING otherwise.
+type HealthChecker struct {
+	*health.Server
+
+	storage *WonderlandStorage
+}
+
+func NewHealthChecker(storage *WonderlandStorage) *HealthChecker {
+	return &HealthChecker{
+		Server:  health.NewServer(),
+		storage: storage,
+	}
+}
+
+// Run pings the database every interval until ctx is done.
+func (h *HealthChecker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		h.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *HealthChecker) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, defaultHealthCheckTimeout)
+	defer cancel()
+
+	status := healthpb.HealthCheckResponse_SERVING
+	err := h.storage.Ping(ctx)
+	if err != nil {
+		logrus.WithError(err).Warn("Database is unreachable")
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	h.SetServingStatus("", status)
+	h.SetServingStatus(serviceName, status)
+}
+
+// ServeHTTP answers readiness probes: 200 while the server is SERVING and
+// 503 otherwise, including after Shutdown.
+func (h *HealthChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.Check(r.Context(), &healthpb.HealthCheckRequest{Service: serviceName})
+	if err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+		http.Error(w, "not serving", http.StatusServiceUnavailable)
+		return
+	}
+	w.Write([]byte("ok\n"))
+}
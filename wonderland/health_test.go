@@ -0,0 +1,66 @@
+package wonderland
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/context"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthCheckerUnreachableDatabase(t *testing.T) {
+	storage, err := NewWonderlandStorage("postgres://127.0.0.1:1/wonderland?sslmode=disable&connect_timeout=1")
+	checkTestErr(err, t)
+	defer storage.Close()
+
+	h := NewHealthChecker(storage)
+	h.check(context.Background())
+
+	resp, err := h.Check(context.Background(), &healthpb.HealthCheckRequest{Service: serviceName})
+	checkTestErr(err, t)
+	if resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fail()
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fail()
+	}
+}
+
+func TestHealthCheckerShutdown(t *testing.T) {
+	h := NewHealthChecker(nil)
+	h.SetServingStatus(serviceName, healthpb.HealthCheckResponse_SERVING)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fail()
+	}
+
+	h.Shutdown()
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fail()
+	}
+}
+
+func TestServerDrain(t *testing.T) {
+	s := &Server{}
+	select {
+	case <-s.draining():
+		t.Fatal("draining before Drain")
+	default:
+	}
+
+	s.Drain()
+	s.Drain()
+	select {
+	case <-s.draining():
+	default:
+		t.Fail()
+	}
+}
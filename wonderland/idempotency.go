@@ -0,0 +1,128 @@
+package wonderland
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	defaultIdempotencyKeyTTL = 24 * time.Hour
+	maxIdempotencyKeyLength  = 128
+)
+
+var (
+	errIdempotencyKeyReused = grpc.Errorf(codes.AlreadyExists, "Idempotency key was already used for a different job")
+	errIdempotentJobGone    = grpc.Errorf(codes.FailedPrecondition, "Idempotency key was used for a job which has since been purged or archived")
+)
+
+func validateIdempotencyKey(key string) error {
+	if len(key) > maxIdempotencyKeyLength {
+		return grpc.Errorf(codes.InvalidArgument, "Idempotency key must be at most %d bytes", maxIdempotencyKeyLength)
+	}
+	return nil
+}
+
+// payloadHash identifies what a CreateJob request asks for, so that retries
+// can be told from different jobs submitted with the same key.
+func payloadHash(job *Job) (string, error) {
+	content, err := auditMarshaler.MarshalToString(&Job{
+		Project:    job.Project,
+		Kind:       job.Kind,
+		Status:     job.Status,
+		Input:      job.Input,
+		Output:     job.Output,
+		Metadata:   job.Metadata,
+		RunAfter:   job.RunAfter,
+		MaxRuntime: job.MaxRuntime,
+		Deadline:   job.Deadline,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (storage *WonderlandStorage) idempotencyKeyTTL() time.Duration {
+	if storage.IdempotencyKeyTTL == 0 {
+		return defaultIdempotencyKeyTTL
+	}
+	return storage.IdempotencyKeyTTL
+}
+
+// storeIdempotencyKey remembers that the key of request created job in tx.
+// It returns false if the key is already taken by a job created within the
+// TTL, waiting for concurrent transactions using the same key to finish.
+func (storage *WonderlandStorage) storeIdempotencyKey(ctx context.Context, tx *sql.Tx, request *Job, job *Job) (bool, error) {
+	hash, err := payloadHash(request)
+	if err != nil {
+		return false, err
+	}
+
+	var jobID uint64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO idempotency_keys (project, key, job_id, payload_hash, created)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (project, key) DO UPDATE
+		SET job_id=EXCLUDED.job_id, payload_hash=EXCLUDED.payload_hash, created=EXCLUDED.created
+		WHERE idempotency_keys.created < $6
+		RETURNING job_id;`,
+		job.Project, request.IdempotencyKey, job.Id, hash, storage.now(), storage.now().Add(-storage.idempotencyKeyTTL()),
+	).Scan(&jobID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// jobForIdempotencyKey returns the job created earlier with the key of
+// request, errIdempotencyKeyReused if it was created from another payload or
+// errIdempotentJobGone if it is no longer in the jobs table.
+func (storage *WonderlandStorage) jobForIdempotencyKey(ctx context.Context, request *Job) (*Job, error) {
+	hash, err := payloadHash(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobID uint64
+	var storedHash string
+	err = storage.db.QueryRowContext(ctx, `
+		SELECT job_id, payload_hash
+		FROM idempotency_keys
+		WHERE project=$1 AND key=$2;`, request.Project, request.IdempotencyKey,
+	).Scan(&jobID, &storedHash)
+	if err != nil {
+		return nil, err
+	}
+	if storedHash != hash {
+		return nil, errIdempotencyKeyReused
+	}
+
+	job, err := storage.GetJob(ctx, jobID)
+	if err == sql.ErrNoRows {
+		return nil, errIdempotentJobGone
+	}
+	if err != nil {
+		return nil, err
+	}
+	job.IdempotencyKey = request.IdempotencyKey
+	return job, nil
+}
+
+// DeleteExpiredIdempotencyKeys forgets the keys older than the TTL.
+func (storage *WonderlandStorage) DeleteExpiredIdempotencyKeys(ctx context.Context) (err error) {
+	ctx, span := startStorageSpan(ctx, "DeleteExpiredIdempotencyKeys")
+	defer func() { endSpan(span, err) }()
+
+	_, err = storage.db.ExecContext(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE created < $1;`, storage.now().Add(-storage.idempotencyKeyTTL()),
+	)
+	return err
+}
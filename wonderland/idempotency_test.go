@@ -0,0 +1,37 @@
+package wonderland
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPayloadHash(t *testing.T) {
+	request := &Job{Project: "lhcb", Kind: "docker", Input: "in", IdempotencyKey: "a"}
+	hash, err := payloadHash(request)
+	checkTestErr(err, t)
+
+	// the key and fields set by the server do not matter
+	retry, err := payloadHash(&Job{Project: "lhcb", Kind: "docker", Input: "in", IdempotencyKey: "b", Id: 3, TraceParent: "x"})
+	checkTestErr(err, t)
+	if retry != hash {
+		t.Fail()
+	}
+
+	other, err := payloadHash(&Job{Project: "lhcb", Kind: "docker", Input: "other", IdempotencyKey: "a"})
+	checkTestErr(err, t)
+	if other == hash {
+		t.Fail()
+	}
+}
+
+func TestValidateIdempotencyKey(t *testing.T) {
+	checkTestErr(validateIdempotencyKey(""), t)
+	checkTestErr(validateIdempotencyKey("nightly-2018-04-16"), t)
+	err := validateIdempotencyKey(strings.Repeat("k", maxIdempotencyKeyLength+1))
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fail()
+	}
+}
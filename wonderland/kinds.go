@@ -0,0 +1,365 @@
+package wonderland
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxSchemaLength = 64 * 1024
+	// maxSchemaErrors is how many validation errors are reported at most
+	maxSchemaErrors = 10
+	// kindLockKey namespaces the advisory locks serializing the
+	// registrations of a kind
+	kindLockKey = 0x6b696e64
+)
+
+// checkSchemaRefs fails for $ref outside of the schema itself, which would
+// make the server fetch URLs or read files.
+func checkSchemaRefs(value interface{}) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, child := range v {
+			if ref, ok := child.(string); ok && name == "$ref" && !strings.HasPrefix(ref, "#") {
+				return fmt.Errorf("$ref %q must start with #", ref)
+			}
+			err := checkSchemaRefs(child)
+			if err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			err := checkSchemaRefs(child)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// compileSchema parses a JSON Schema, nil for the empty schema.
+func compileSchema(schema string) (*gojsonschema.Schema, error) {
+	if schema == "" {
+		return nil, nil
+	}
+	var document map[string]interface{}
+	err := json.Unmarshal([]byte(schema), &document)
+	if err != nil {
+		return nil, fmt.Errorf("not a JSON object: %v", err)
+	}
+	err = checkSchemaRefs(document)
+	if err != nil {
+		return nil, err
+	}
+	return gojsonschema.NewSchema(gojsonschema.NewGoLoader(document))
+}
+
+func validateKind(kind *Kind) error {
+	if kind.Name == "" {
+		return grpc.Errorf(codes.InvalidArgument, "Kind name is required")
+	}
+	for field, schema := range map[string]string{"input_schema": kind.InputSchema, "output_schema": kind.OutputSchema} {
+		if len(schema) > maxSchemaLength {
+			return grpc.Errorf(codes.InvalidArgument, "%s must be at most %d bytes long", field, maxSchemaLength)
+		}
+		_, err := compileSchema(schema)
+		if err != nil {
+			return grpc.Errorf(codes.InvalidArgument, "Invalid %s: %v", field, err)
+		}
+	}
+	return nil
+}
+
+// validateDocument checks the input or output of a job, named field, against
+// schema. Empty documents are validated as null. Errors list the paths of
+// the offending values, such as "input.files.0.name".
+func validateDocument(field string, schema string, document string) error {
+	compiled, err := compileSchema(schema)
+	if err != nil {
+		return detailedInternalError(err)
+	}
+	return checkDocument(field, compiled, document)
+}
+
+// checkDocument is validateDocument for a compiled schema, nil accepting
+// anything.
+func checkDocument(field string, compiled *gojsonschema.Schema, document string) error {
+	if compiled == nil {
+		return nil
+	}
+	if document == "" {
+		document = "null"
+	}
+
+	result, err := compiled.Validate(gojsonschema.NewStringLoader(document))
+	if err != nil {
+		return grpc.Errorf(codes.InvalidArgument, "Invalid %s, not JSON: %v", field, err)
+	}
+	if result.Valid() {
+		return nil
+	}
+	problems := []string{}
+	for i, e := range result.Errors() {
+		if i == maxSchemaErrors {
+			problems = append(problems, fmt.Sprintf("and %d more", len(result.Errors())-i))
+			break
+		}
+		path := field
+		if e.Field() != gojsonschema.STRING_ROOT_SCHEMA_PROPERTY {
+			path += "." + e.Field()
+		}
+		problems = append(problems, path+": "+e.Description())
+	}
+	return grpc.Errorf(codes.InvalidArgument, "Invalid %s: %s", field, strings.Join(problems, "; "))
+}
+
+// validateJobDocuments validates the input and output of new jobs of the same
+// project and kind against the latest version of their kind, if it is
+// registered, and records that version in the jobs.
+func (s *Server) validateJobDocuments(ctx context.Context, jobs ...*Job) error {
+	for _, job := range jobs {
+		job.KindVersion = 0
+	}
+	kind, err := s.Storage.GetKind(ctx, jobs[0].Project, jobs[0].Kind, 0)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return detailedInternalError(err)
+	}
+	input, err := compileSchema(kind.InputSchema)
+	if err != nil {
+		return detailedInternalError(err)
+	}
+	output, err := compileSchema(kind.OutputSchema)
+	if err != nil {
+		return detailedInternalError(err)
+	}
+
+	for i, job := range jobs {
+		err = checkDocument("input", input, job.Input)
+		if err == nil && job.Output != "" {
+			err = checkDocument("output", output, job.Output)
+		}
+		if err != nil && len(jobs) > 1 {
+			return grpc.Errorf(codes.InvalidArgument, "Job %d: %s", i, status.Convert(err).Message())
+		}
+		if err != nil {
+			return err
+		}
+		job.KindVersion = kind.Version
+	}
+	return nil
+}
+
+// validateJobOutput validates the output a worker reports for job against
+// the version of its kind the job was created with.
+func (s *Server) validateJobOutput(ctx context.Context, job *Job, output string) error {
+	if job.KindVersion == 0 || output == "" {
+		return nil
+	}
+	kind, err := s.Storage.GetKind(ctx, job.Project, job.Kind, job.KindVersion)
+	if err != nil {
+		return detailedInternalError(err)
+	}
+	return validateDocument("output", kind.OutputSchema, output)
+}
+
+// outputInvalid is the error of a job finished with an output which does not
+// match the schema of its kind.
+func outputInvalid(err error) *JobError {
+	message := status.Convert(err).Message()
+	if len(message) > maxJobErrorMessageLength {
+		message = message[:maxJobErrorMessageLength]
+	}
+	return &JobError{Code: "OUTPUT_INVALID", Message: message}
+}
+
+const kindColumns = `project, name, version, input_schema, output_schema, description, EXTRACT(EPOCH FROM created)::bigint`
+
+func kindFields(kind *Kind) []interface{} {
+	return []interface{}{
+		&kind.Project,
+		&kind.Name,
+		&kind.Version,
+		&kind.InputSchema,
+		&kind.OutputSchema,
+		&kind.Description,
+		&kind.Created,
+	}
+}
+
+// RegisterKind stores the next version of a kind.
+func (storage *WonderlandStorage) RegisterKind(ctx context.Context, kind *Kind, creator User) (registered *Kind, err error) {
+	ctx, span := startStorageSpan(ctx, "RegisterKind")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2 || '/' || $3));`,
+		kindLockKey, kind.Project, kind.Name,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	registered = &Kind{}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO job_kinds (project, name, version, input_schema, output_schema, description, creator, created)
+		SELECT $1, $2, COALESCE(max(version), 0) + 1, $3, $4, $5, $6, $7
+		FROM job_kinds
+		WHERE project=$1 AND name=$2
+		RETURNING `+kindColumns+`;`,
+		kind.Project,
+		kind.Name,
+		kind.InputSchema,
+		kind.OutputSchema,
+		kind.Description,
+		creator.Username,
+		storage.now(),
+	).Scan(kindFields(registered)...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, 0, registered.Project, nil, registered)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(event)
+	return registered, nil
+}
+
+// GetKind returns a version of a kind, the latest one if version is 0.
+func (storage *WonderlandStorage) GetKind(ctx context.Context, project, name string, version uint32) (kind *Kind, err error) {
+	ctx, span := startStorageSpan(ctx, "GetKind")
+	defer func() { endSpan(span, err) }()
+
+	kind = &Kind{}
+	err = storage.db.QueryRowContext(ctx, `
+		SELECT `+kindColumns+`
+		FROM job_kinds
+		WHERE project=$1 AND name=$2 AND (version=$3 OR $3=0)
+		ORDER BY version DESC
+		LIMIT 1;`, project, name, version,
+	).Scan(kindFields(kind)...)
+	if err != nil {
+		return nil, err
+	}
+	return kind, nil
+}
+
+// ListKinds returns the latest version of the kinds of project, of every
+// project if it is empty.
+func (storage *WonderlandStorage) ListKinds(ctx context.Context, project string) (ret *ListOfKinds, err error) {
+	ctx, span := startStorageSpan(ctx, "ListKinds")
+	defer func() { endSpan(span, err) }()
+
+	rows, err := storage.db.QueryContext(ctx, `
+		SELECT DISTINCT ON (project, name) `+kindColumns+`
+		FROM job_kinds
+		WHERE project=$1 OR $1=''
+		ORDER BY project, name, version DESC;`, project,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret = &ListOfKinds{Kinds: []*Kind{}}
+	for rows.Next() {
+		kind := &Kind{}
+		err = rows.Scan(kindFields(kind)...)
+		if err != nil {
+			return nil, err
+		}
+		ret.Kinds = append(ret.Kinds, kind)
+	}
+	err = rows.Err()
+	return ret, err
+}
+
+func (s *Server) RegisterKind(ctx context.Context, in *Kind) (*Kind, error) {
+	user := getAuthUserFromContext(ctx)
+
+	err := validateKind(in)
+	if err != nil {
+		return nil, err
+	}
+	if in.Project == "" {
+		project, err := user.onlyProject(PermManageKinds)
+		if err != nil {
+			return nil, err
+		}
+		in.Project = project
+	}
+	if !user.Can(PermManageKinds, in.Project, in.Name) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Storage.RegisterKind(ctx, in, user)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) GetKind(ctx context.Context, in *GetKindRequest) (*Kind, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if !user.Can(PermGetJobs, in.Project, in.Name) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Storage.GetKind(ctx, in.Project, in.Name, in.Version)
+	if err == sql.ErrNoRows {
+		return nil, grpc.Errorf(codes.NotFound, "Kind %s/%s is not registered", in.Project, in.Name)
+	}
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) ListKinds(ctx context.Context, in *ListKindsRequest) (*ListOfKinds, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if in.Project == "" && !user.Can(PermListJobs, AnyScope, AnyScope) {
+		project, err := user.onlyProject(PermListJobs)
+		if err != nil {
+			return nil, err
+		}
+		in.Project = project
+	}
+	if !user.Can(PermListJobs, orAnyScope(in.Project), AnyScope) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Storage.ListKinds(ctx, in.Project)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
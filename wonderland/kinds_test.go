@@ -0,0 +1,56 @@
+package wonderland
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testInputSchema = `{
+	"type": "object",
+	"required": ["files"],
+	"properties": {
+		"files": {"type": "array", "items": {"$ref": "#/definitions/file"}}
+	},
+	"definitions": {
+		"file": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string", "minLength": 1}}}
+	}
+}`
+
+func TestValidateKind(t *testing.T) {
+	checkTestErr(validateKind(&Kind{Name: "docker", InputSchema: testInputSchema}), t)
+	checkTestErr(validateKind(&Kind{Name: "docker"}), t)
+
+	invalid := []*Kind{
+		{InputSchema: testInputSchema},
+		{Name: "docker", InputSchema: `[]`},
+		{Name: "docker", OutputSchema: `{"type": 42}`},
+		{Name: "docker", InputSchema: `{"$ref": "http://example.com/schema.json"}`},
+		{Name: "docker", InputSchema: `{"properties": {"a": {"$ref": "file:///etc/passwd"}}}`},
+	}
+	for _, kind := range invalid {
+		if status.Code(validateKind(kind)) != codes.InvalidArgument {
+			t.Errorf("%v should be invalid", kind)
+		}
+	}
+}
+
+func TestValidateDocument(t *testing.T) {
+	checkTestErr(validateDocument("input", testInputSchema, `{"files": [{"name": "a.root"}]}`), t)
+	checkTestErr(validateDocument("input", "", "anything"), t)
+
+	expected := map[string]string{
+		`{"files": [{"name": "a.root"}, {"name": ""}]}`: "input.files.1.name",
+		`{}`:          "input: files is required",
+		``:            "input: Invalid type",
+		`{"files": [`: "not JSON",
+	}
+	for document, message := range expected {
+		err := validateDocument("input", testInputSchema, document)
+		if status.Code(err) != codes.InvalidArgument || !strings.Contains(status.Convert(err).Message(), message) {
+			t.Errorf("%q: %v should mention %q", document, err, message)
+		}
+	}
+}
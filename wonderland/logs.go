@@ -0,0 +1,287 @@
+package wonderland
+
+import (
+	"database/sql"
+	"io"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	maxLogChunkLength    = 64 * 1024
+	defaultMaxJobLogSize = 10 * 1024 * 1024
+	tailBatchSize        = 1000
+	tailPollInterval     = time.Second
+)
+
+const jobLogColumns = `seq, job_id, stream, data, EXTRACT(EPOCH FROM created)::bigint`
+
+func jobLogFields(c *JobLogChunk) []interface{} {
+	return []interface{}{
+		&c.Seq,
+		&c.JobId,
+		&c.Stream,
+		&c.Data,
+		&c.Created,
+	}
+}
+
+func (storage *WonderlandStorage) maxJobLogSize() int64 {
+	if storage.MaxJobLogSize == 0 {
+		return defaultMaxJobLogSize
+	}
+	return storage.MaxJobLogSize
+}
+
+// AppendJobLog stores a log chunk of a pulled or running job, returning
+// sql.ErrNoRows for other jobs. Chunks are numbered under the lock of the row
+// of their job, so that readers never see a chunk before the ones preceding
+// it.
+func (storage *WonderlandStorage) AppendJobLog(ctx context.Context, chunk *JobLogChunk) (stored *JobLogChunk, err error) {
+	ctx, span := startStorageSpan(ctx, "AppendJobLog")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var seq uint64
+	err = tx.QueryRowContext(ctx, `
+		UPDATE jobs
+		SET log_seq=log_seq+1
+		WHERE id=$1 AND status IN ($2, $3)
+		RETURNING log_seq;`, chunk.JobId, Job_PULLED, Job_RUNNING,
+	).Scan(&seq)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	stored = &JobLogChunk{}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO job_logs (job_id, seq, stream, data, created)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+jobLogColumns+`;`,
+		chunk.JobId, seq, chunk.Stream, chunk.Data, storage.now(),
+	).Scan(jobLogFields(stored)...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// TrimJobLog deletes the oldest chunks of the log of a job until it is at
+// most MaxJobLogSize bytes long.
+func (storage *WonderlandStorage) TrimJobLog(ctx context.Context, jobID uint64) (err error) {
+	ctx, span := startStorageSpan(ctx, "TrimJobLog")
+	defer func() { endSpan(span, err) }()
+
+	_, err = storage.db.ExecContext(ctx, `
+		DELETE FROM job_logs
+		WHERE id IN (
+			SELECT id
+			FROM (
+				SELECT id, sum(octet_length(data)) OVER (ORDER BY seq DESC) AS newer
+				FROM job_logs
+				WHERE job_id=$1
+			) sizes
+			WHERE newer > $2
+		);`, jobID, storage.maxJobLogSize(),
+	)
+	return err
+}
+
+// ListJobLogs returns the chunks of the log of a job after the given seq,
+// only the last ones if last is not zero.
+func (storage *WonderlandStorage) ListJobLogs(ctx context.Context, jobID uint64, afterSeq uint64, last uint32) (chunks []*JobLogChunk, err error) {
+	ctx, span := startStorageSpan(ctx, "ListJobLogs")
+	defer func() { endSpan(span, err) }()
+
+	strQuery := `
+		SELECT ` + jobLogColumns + `
+		FROM job_logs
+		WHERE job_id=$1 AND seq > $2
+		ORDER BY seq
+		LIMIT $3;`
+	limit := uint32(tailBatchSize)
+	if last != 0 {
+		// the newest chunks, in the order they were written
+		strQuery = `
+			SELECT * FROM (
+				SELECT ` + jobLogColumns + `
+				FROM job_logs
+				WHERE job_id=$1 AND seq > $2
+				ORDER BY seq DESC
+				LIMIT $3
+			) newest
+			ORDER BY seq;`
+		limit = last
+	}
+
+	rows, err := storage.db.QueryContext(ctx, strQuery, jobID, afterSeq, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	chunks = []*JobLogChunk{}
+	for rows.Next() {
+		chunk := &JobLogChunk{}
+		err = rows.Scan(jobLogFields(chunk)...)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+	err = rows.Err()
+	return chunks, err
+}
+
+func validateLogChunk(chunk *JobLogChunk) error {
+	if chunk.JobId == 0 {
+		return grpc.Errorf(codes.InvalidArgument, "Log chunks need a job_id")
+	}
+	if len(chunk.Data) > maxLogChunkLength {
+		return grpc.Errorf(codes.InvalidArgument, "Log chunks must be at most %d bytes long", maxLogChunkLength)
+	}
+	return nil
+}
+
+// AppendJobLogs stores the log chunks sent by a worker. Logs are trimmed to
+// MaxJobLogSize every tenth of it appended and when the stream ends, so they
+// may briefly grow a little larger.
+func (s *Server) AppendJobLogs(stream Wonderland_AppendJobLogsServer) error {
+	ctx := stream.Context()
+	user := getAuthUserFromContext(ctx)
+
+	response := &AppendJobLogsResponse{}
+	// bytes appended to each job since its log was last trimmed
+	untrimmed := map[uint64]int64{}
+	trimAfter := s.Storage.maxJobLogSize() / 10
+	trim := func(jobID uint64) error {
+		err := s.Storage.TrimJobLog(ctx, jobID)
+		if err != nil {
+			return detailedInternalError(err)
+		}
+		untrimmed[jobID] = 0
+		return nil
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		err = validateLogChunk(chunk)
+		if err != nil {
+			return err
+		}
+		if _, ok := untrimmed[chunk.JobId]; !ok {
+			job, err := s.authorizeJob(ctx, user, PermUpdateJobs, chunk.JobId)
+			if err != nil {
+				return err
+			}
+			if job.DeletedAt != 0 {
+				return errJobDeleted(job)
+			}
+			untrimmed[chunk.JobId] = 0
+		}
+
+		_, err = s.Storage.AppendJobLog(ctx, chunk)
+		if err == sql.ErrNoRows {
+			return grpc.Errorf(codes.FailedPrecondition, "Job %d is not pulled or running", chunk.JobId)
+		}
+		if err != nil {
+			return detailedInternalError(err)
+		}
+		response.Chunks++
+		response.Bytes += uint64(len(chunk.Data))
+		untrimmed[chunk.JobId] += int64(len(chunk.Data))
+		if untrimmed[chunk.JobId] > trimAfter {
+			err = trim(chunk.JobId)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for jobID, size := range untrimmed {
+		if size > 0 {
+			err := trim(jobID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return stream.SendAndClose(response)
+}
+
+// TailJobLogs sends the log of a job. In follow mode it keeps polling for new
+// chunks until the job is finished, the client goes away or the server
+// drains.
+func (s *Server) TailJobLogs(in *TailJobLogsRequest, stream Wonderland_TailJobLogsServer) error {
+	ctx := stream.Context()
+	user := getAuthUserFromContext(ctx)
+
+	_, err := s.authorizeJob(ctx, user, PermGetJobs, in.Id)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(tailPollInterval)
+	defer ticker.Stop()
+
+	after, last := in.AfterSeq, in.Last
+	for {
+		// the status is read before the chunks, so that the chunks written
+		// before the job finished are all sent
+		job, err := s.Storage.GetJob(ctx, in.Id)
+		if err == sql.ErrNoRows {
+			return grpc.Errorf(codes.NotFound, "Job %d does not exist", in.Id)
+		}
+		if err != nil {
+			return detailedInternalError(err)
+		}
+		for {
+			chunks, err := s.Storage.ListJobLogs(ctx, in.Id, after, last)
+			if err != nil {
+				return detailedInternalError(err)
+			}
+			for _, chunk := range chunks {
+				err = stream.Send(chunk)
+				if err != nil {
+					return err
+				}
+				after = chunk.Seq
+			}
+			if last != 0 || len(chunks) < tailBatchSize {
+				last = 0
+				break
+			}
+		}
+
+		if !in.Follow || isFinished(job.Status) || job.DeletedAt != 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.draining():
+			return grpc.Errorf(codes.Unavailable, "Server is shutting down")
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package wonderland
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateLogChunk(t *testing.T) {
+	checkTestErr(validateLogChunk(&JobLogChunk{JobId: 1, Stream: "stdout", Data: "hello\n"}), t)
+
+	invalid := []*JobLogChunk{
+		{Data: "hello\n"},
+		{JobId: 1, Data: strings.Repeat("x", maxLogChunkLength+1)},
+	}
+	for _, chunk := range invalid {
+		if status.Code(validateLogChunk(chunk)) != codes.InvalidArgument {
+			t.Fail()
+		}
+	}
+}
+
+type tailStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*JobLogChunk
+}
+
+func (s *tailStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *tailStream) Send(chunk *JobLogChunk) error {
+	s.chunks = append(s.chunks, chunk)
+	return nil
+}
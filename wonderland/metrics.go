@@ -0,0 +1,244 @@
+package wonderland
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const metricsNamespace = "wonderland"
+
+var (
+	rpcHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "grpc",
+		Name:      "server_handled_total",
+		Help:      "Total number of RPCs completed on the server, by method and status code.",
+	}, []string{"method", "code"})
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "grpc",
+		Name:      "server_handling_seconds",
+		Help:      "Time spent handling RPCs on the server, by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	queueJobs          = newQueueJobsCollector()
+	queueOldestPending = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "queue",
+		Name:      "oldest_pending_seconds",
+		Help:      "Age of the oldest PENDING job, as of the last sample.",
+	})
+	pullLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "queue",
+		Name:      "pull_seconds",
+		Help:      "Time spent pulling pending jobs from the database.",
+		Buckets:   prometheus.DefBuckets,
+	})
+	jobsFinished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "queue",
+		Name:      "jobs_finished_total",
+		Help:      "Number of jobs moved to COMPLETED, FAILED, KILLED or EXPIRED.",
+	}, []string{"status", "project", "kind"})
+)
+
+func init() {
+	prometheus.MustRegister(rpcHandled, rpcDuration, queueJobs, queueOldestPending, pullLatency, jobsFinished)
+}
+
+func observeRPC(method string, start time.Time, err error) {
+	rpcHandled.WithLabelValues(method, status.Code(err).String()).Inc()
+	rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// UnaryServerMetricsInterceptor counts unary RPCs and measures their duration.
+func UnaryServerMetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeRPC(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerMetricsInterceptor counts streaming RPCs and measures their
+// duration.
+func StreamServerMetricsInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeRPC(info.FullMethod, start, err)
+		return err
+	}
+}
+
+// countFinishedJob counts job if the change from before finished it. Updates
+// of jobs which were already finished are not counted again.
+func countFinishedJob(before, job *Job) {
+	if isFinished(job.Status) && (before == nil || !isFinished(before.Status)) {
+		jobsFinished.WithLabelValues(job.Status.String(), job.Project, job.Kind).Inc()
+	}
+}
+
+// queueJobsCollector exports the number of jobs by status, project and kind
+// of the last sample. Samples are swapped in whole, so that scrapes never see
+// one half built.
+type queueJobsCollector struct {
+	desc *prometheus.Desc
+
+	mu      sync.Mutex
+	metrics []prometheus.Metric
+}
+
+func newQueueJobsCollector() *queueJobsCollector {
+	return &queueJobsCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "queue", "jobs"),
+			"Number of jobs by status, project and kind, as of the last sample.",
+			[]string{"status", "project", "kind"}, nil,
+		),
+	}
+}
+
+func (c *queueJobsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *queueJobsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	metrics := c.metrics
+	c.mu.Unlock()
+	for _, metric := range metrics {
+		ch <- metric
+	}
+}
+
+// set replaces the sample.
+func (c *queueJobsCollector) set(metrics []prometheus.Metric) {
+	c.mu.Lock()
+	c.metrics = metrics
+	c.mu.Unlock()
+}
+
+// dbStatsCollector exports sql.DB connection pool statistics.
+type dbStatsCollector struct {
+	db *sql.DB
+
+	openConnections *prometheus.Desc
+	inUse           *prometheus.Desc
+	idle            *prometheus.Desc
+	waitCount       *prometheus.Desc
+	waitDuration    *prometheus.Desc
+}
+
+func newDBStatsCollector(db *sql.DB) *dbStatsCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "db", name), help, nil, nil)
+	}
+	return &dbStatsCollector{
+		db:              db,
+		openConnections: desc("open_connections", "Number of established connections, both in use and idle."),
+		inUse:           desc("in_use_connections", "Number of connections currently in use."),
+		idle:            desc("idle_connections", "Number of idle connections."),
+		waitCount:       desc("wait_count_total", "Total number of connections waited for."),
+		waitDuration:    desc("wait_duration_seconds_total", "Total time blocked waiting for a new connection."),
+	}
+}
+
+func (c *dbStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.openConnections
+	ch <- c.inUse
+	ch <- c.idle
+	ch <- c.waitCount
+	ch <- c.waitDuration
+}
+
+func (c *dbStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.db.Stats()
+	ch <- prometheus.MustNewConstMetric(c.openConnections, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.inUse, prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+}
+
+// RegisterMetrics exports the connection pool statistics of the storage.
+func (storage *WonderlandStorage) RegisterMetrics() error {
+	return prometheus.Register(newDBStatsCollector(storage.db))
+}
+
+// SampleQueueMetrics updates the queue gauges every interval until ctx is done.
+func (storage *WonderlandStorage) SampleQueueMetrics(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := storage.sampleQueueMetrics()
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to sample queue metrics")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (storage *WonderlandStorage) sampleQueueMetrics() error {
+	rows, err := storage.db.Query(`
+		SELECT status, project, kind, count(*)
+		FROM jobs
+		WHERE deleted_at IS NULL
+		GROUP BY status, project, kind;`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	metrics := []prometheus.Metric{}
+	for rows.Next() {
+		var jobStatus Job_Status
+		var project sql.NullString
+		var kind string
+		var count float64
+		err = rows.Scan(&jobStatus, &project, &kind, &count)
+		if err != nil {
+			return err
+		}
+		metrics = append(metrics, prometheus.MustNewConstMetric(
+			queueJobs.desc, prometheus.GaugeValue, count, jobStatus.String(), project.String, kind,
+		))
+	}
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+	queueJobs.set(metrics)
+
+	var oldest pq.NullTime
+	err = storage.db.QueryRow(`
+		SELECT min(COALESCE(run_after, created))
+		FROM jobs
+		WHERE status=$1 AND deleted_at IS NULL AND (run_after IS NULL OR run_after <= $2);`, Job_PENDING, storage.now()).Scan(&oldest)
+	if err != nil {
+		return err
+	}
+	if oldest.Valid {
+		queueOldestPending.Set(storage.now().Sub(oldest.Time).Seconds())
+	} else {
+		queueOldestPending.Set(0)
+	}
+	return nil
+}
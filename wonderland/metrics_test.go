@@ -0,0 +1,46 @@
+package wonderland
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestMetricsInterceptorCountsCodes(t *testing.T) {
+	interceptor := UnaryServerMetricsInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/Wonderland/GetJob"}
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	denied := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "No access")
+	}
+
+	before := testutil.ToFloat64(rpcHandled.WithLabelValues(info.FullMethod, "OK"))
+	interceptor(context.Background(), nil, info, ok)
+	interceptor(context.Background(), nil, info, ok)
+	interceptor(context.Background(), nil, info, denied)
+
+	if testutil.ToFloat64(rpcHandled.WithLabelValues(info.FullMethod, "OK"))-before != 2 {
+		t.Fail()
+	}
+	if testutil.ToFloat64(rpcHandled.WithLabelValues(info.FullMethod, "PermissionDenied")) != 1 {
+		t.Fail()
+	}
+}
+
+func TestCountFinishedJobOnce(t *testing.T) {
+	running := &Job{Project: "metrics", Kind: "count_once", Status: Job_RUNNING}
+	completed := &Job{Project: "metrics", Kind: "count_once", Status: Job_COMPLETED}
+	counter := jobsFinished.WithLabelValues("COMPLETED", "metrics", "count_once")
+
+	countFinishedJob(running, completed)
+	// a later update of the finished job
+	countFinishedJob(completed, completed)
+	if testutil.ToFloat64(counter) != 1 {
+		t.Fail()
+	}
+}
@@ -0,0 +1,278 @@
+package wonderland
+
+import (
+	"database/sql"
+	"math"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	maxProgressMessageLength = 1024
+	// progressSamples is how many of the latest reports of a job the ETA
+	// is estimated from
+	progressSamples   = 20
+	watchPollInterval = time.Second
+)
+
+// validateProgress checks a progress report and derives the fraction from
+// the steps if it is left out.
+func validateProgress(progress *Progress) error {
+	if progress == nil {
+		return grpc.Errorf(codes.InvalidArgument, "Progress is required")
+	}
+	if math.IsNaN(progress.Fraction) || progress.Fraction < 0 || progress.Fraction > 1 {
+		return grpc.Errorf(codes.InvalidArgument, "Progress fraction must be between 0 and 1")
+	}
+	if progress.TotalSteps != 0 && progress.Step > progress.TotalSteps {
+		return grpc.Errorf(codes.InvalidArgument, "Progress step must be at most total_steps")
+	}
+	if len(progress.Message) > maxProgressMessageLength {
+		return grpc.Errorf(codes.InvalidArgument, "Progress message must be at most %d bytes long", maxProgressMessageLength)
+	}
+	if progress.Fraction == 0 && progress.TotalSteps != 0 {
+		progress.Fraction = float64(progress.Step) / float64(progress.TotalSteps)
+	}
+	progress.UpdatedAt = 0
+	progress.Eta = 0
+	return nil
+}
+
+// progressSample is a reported fraction at a time in seconds since the epoch.
+type progressSample struct {
+	at       float64
+	fraction float64
+}
+
+// estimateCompletion fits a line through samples, oldest first, and returns
+// when it reaches a fraction of 1, or 0 if the job does not seem to advance.
+func estimateCompletion(samples []progressSample) int64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	latest := samples[len(samples)-1]
+	if latest.fraction >= 1 {
+		return int64(latest.at)
+	}
+	if len(samples) < 2 {
+		return 0
+	}
+
+	var meanAt, meanFraction float64
+	for _, sample := range samples {
+		meanAt += sample.at
+		meanFraction += sample.fraction
+	}
+	meanAt /= float64(len(samples))
+	meanFraction /= float64(len(samples))
+	var covariance, variance float64
+	for _, sample := range samples {
+		covariance += (sample.at - meanAt) * (sample.fraction - meanFraction)
+		variance += (sample.at - meanAt) * (sample.at - meanAt)
+	}
+	if variance == 0 || covariance <= 0 {
+		return 0
+	}
+	rate := covariance / variance
+	return int64(math.Ceil(latest.at + (1-latest.fraction)/rate))
+}
+
+// jobProgressColumn scans the progress column of jobs, leaving the progress
+// nil for NULL.
+type jobProgressColumn struct {
+	progress **Progress
+}
+
+func (c jobProgressColumn) Scan(src interface{}) error {
+	*c.progress = nil
+	if src == nil {
+		return nil
+	}
+	progress := &Progress{}
+	err := scanJSONB(src, progress)
+	if err != nil {
+		return err
+	}
+	*c.progress = progress
+	return nil
+}
+
+// ReportProgress stores the progress of a pulled or running job along with
+// its ETA, returning sql.ErrNoRows for other jobs. Reports are frequent and
+// change nothing but the progress, so unlike other updates they are not
+// audited.
+func (storage *WonderlandStorage) ReportProgress(ctx context.Context, id uint64, progress *Progress) (reported *Progress, err error) {
+	ctx, span := startStorageSpan(ctx, "ReportProgress")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// the job may have finished since it was authorized
+	var running bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT true
+		FROM jobs
+		WHERE id=$1 AND status IN ($2, $3) AND deleted_at IS NULL AND failure_reason=''
+		FOR UPDATE;`, id, Job_PULLED, Job_RUNNING,
+	).Scan(&running)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	now := storage.now()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO job_progress (job_id, fraction, reported_at)
+		VALUES ($1, $2, $3);`, id, progress.Fraction, now,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// only the reports since the job was last pulled tell about this run
+	rows, err := tx.QueryContext(ctx, `
+		SELECT EXTRACT(EPOCH FROM p.reported_at), p.fraction
+		FROM job_progress p JOIN jobs j ON j.id = p.job_id
+		WHERE p.job_id=$1 AND p.reported_at >= j.pulled_at
+		ORDER BY p.reported_at DESC, p.id DESC
+		LIMIT $2;`, id, progressSamples,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	samples := make([]progressSample, progressSamples)
+	n := 0
+	for rows.Next() {
+		n++
+		// newest first, stored oldest first
+		err = rows.Scan(&samples[progressSamples-n].at, &samples[progressSamples-n].fraction)
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	rows.Close()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	reported = proto.Clone(progress).(*Progress)
+	reported.UpdatedAt = now.Unix()
+	reported.Eta = estimateCompletion(samples[progressSamples-n:])
+	content, err := auditMarshaler.MarshalToString(reported)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE jobs
+		SET progress=$1::jsonb
+		WHERE id=$2;`, content, id,
+	)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM job_progress
+			WHERE job_id=$1 AND id NOT IN (
+				SELECT id FROM job_progress WHERE job_id=$1 ORDER BY id DESC LIMIT $2
+			);`, id, progressSamples,
+		)
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return reported, nil
+}
+
+func (s *Server) ReportProgress(ctx context.Context, in *ReportProgressRequest) (*Progress, error) {
+	user := getAuthUserFromContext(ctx)
+
+	err := validateProgress(in.Progress)
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.authorizeJob(ctx, user, PermUpdateJobs, in.Id)
+	if err != nil {
+		return nil, err
+	}
+	if job.DeletedAt != 0 {
+		return nil, errJobDeleted(job)
+	}
+	if job.FailureReason != "" {
+		return nil, errJobStopped(job)
+	}
+	if job.Status != Job_PULLED && job.Status != Job_RUNNING {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "Job %d is %s, only pulled and running jobs report progress", job.Id, job.Status)
+	}
+
+	ret, err := s.Storage.ReportProgress(ctx, in.Id, in.Progress)
+	if err == sql.ErrNoRows {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "Job %d is no longer pulled or running", in.Id)
+	}
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+// WatchJob sends the job whenever it changes until it is finished or
+// deleted, the client goes away or the server drains.
+func (s *Server) WatchJob(in *RequestWithId, stream Wonderland_WatchJobServer) error {
+	ctx := stream.Context()
+	user := getAuthUserFromContext(ctx)
+
+	job, err := s.authorizeJob(ctx, user, PermGetJobs, in.Id)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	var sent *Job
+	for {
+		if sent == nil || !proto.Equal(job, sent) {
+			err = stream.Send(job)
+			if err != nil {
+				return err
+			}
+			sent = job
+		}
+		if isFinished(job.Status) || job.DeletedAt != 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.draining():
+			return grpc.Errorf(codes.Unavailable, "Server is shutting down")
+		case <-ticker.C:
+		}
+		job, err = s.Storage.GetJob(ctx, in.Id)
+		if err == sql.ErrNoRows {
+			return grpc.Errorf(codes.NotFound, "Job %d does not exist", in.Id)
+		}
+		if err != nil {
+			return detailedInternalError(err)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package wonderland
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateProgress(t *testing.T) {
+	progress := &Progress{Step: 3, TotalSteps: 4, Eta: 42}
+	checkTestErr(validateProgress(progress), t)
+	if progress.Fraction != 0.75 || progress.Eta != 0 {
+		t.Log(progress)
+		t.Fail()
+	}
+
+	invalid := []*Progress{
+		nil,
+		{Fraction: 1.5},
+		{Fraction: -0.1},
+		{Step: 5, TotalSteps: 4},
+	}
+	for _, progress := range invalid {
+		if status.Code(validateProgress(progress)) != codes.InvalidArgument {
+			t.Log(progress)
+			t.Fail()
+		}
+	}
+}
+
+func TestEstimateCompletion(t *testing.T) {
+	// a tenth every minute, so done five minutes after reaching a half
+	samples := []progressSample{{1000, 0.3}, {1060, 0.4}, {1120, 0.5}}
+	if eta := estimateCompletion(samples); eta != 1420 {
+		t.Log(eta)
+		t.Fail()
+	}
+
+	if estimateCompletion(samples[:1]) != 0 {
+		t.Fail()
+	}
+	stalled := []progressSample{{1000, 0.5}, {1060, 0.5}}
+	if estimateCompletion(stalled) != 0 {
+		t.Fail()
+	}
+	done := []progressSample{{1000, 0.5}, {1060, 1}}
+	if estimateCompletion(done) != 1060 {
+		t.Fail()
+	}
+}
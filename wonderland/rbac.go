@@ -0,0 +1,208 @@
+package wonderland
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// AnyScope in the project or kind of a RoleBinding matches every project or
+// kind. Request filters left empty are checked against AnyScope, so listing
+// or pulling across all projects needs a binding for all of them.
+const AnyScope = "ANY"
+
+type Role string
+
+const (
+	RoleViewer       Role = "viewer"
+	RoleSubmitter    Role = "submitter"
+	RoleWorker       Role = "worker"
+	RoleProjectAdmin Role = "project-admin"
+	RoleClusterAdmin Role = "cluster-admin"
+)
+
+type Permission string
+
+const (
+	PermGetJobs        Permission = "jobs.get"
+	PermListJobs       Permission = "jobs.list"
+	PermCreateJobs     Permission = "jobs.create"
+	PermUpdateJobs     Permission = "jobs.update"
+	PermPullJobs       Permission = "jobs.pull"
+	PermDeleteJobs     Permission = "jobs.delete"
+	PermKillJobs       Permission = "jobs.kill"
+	PermManageBindings Permission = "rolebindings.manage"
+	PermReadAudit      Permission = "audit.read"
+	// PermListDeletedJobs allows listing jobs in the trash.
+	PermListDeletedJobs Permission = "jobs.list-deleted"
+	// PermManageCertificates is only meaningful for all projects and kinds.
+	PermManageCertificates Permission = "certificates.manage"
+	// PermManageWebhooks allows managing webhooks and their deliveries.
+	PermManageWebhooks Permission = "webhooks.manage"
+	// PermManageKinds allows registering the schemas of kinds.
+	PermManageKinds Permission = "kinds.manage"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleViewer: {PermGetJobs, PermListJobs},
+	// Submitters may also pull the jobs of their project, as users
+	// authenticated with a "project.kind" certificate always could.
+	RoleSubmitter: {PermGetJobs, PermListJobs, PermCreateJobs, PermUpdateJobs, PermPullJobs, PermDeleteJobs, PermKillJobs},
+	RoleWorker:    {PermGetJobs, PermUpdateJobs, PermPullJobs},
+	RoleProjectAdmin: {PermGetJobs, PermListJobs, PermCreateJobs, PermUpdateJobs, PermPullJobs, PermDeleteJobs, PermKillJobs,
+		PermListDeletedJobs, PermManageBindings, PermReadAudit, PermManageWebhooks,
+		PermManageKinds},
+	RoleClusterAdmin: {PermGetJobs, PermListJobs, PermCreateJobs, PermUpdateJobs, PermPullJobs, PermDeleteJobs, PermKillJobs,
+		PermListDeletedJobs, PermManageBindings, PermReadAudit, PermManageCertificates, PermManageWebhooks,
+		PermManageKinds},
+}
+
+var errNoAccess = grpc.Errorf(codes.PermissionDenied, "No access")
+
+// RoleBindingStore looks up the role bindings stored for a principal.
+type RoleBindingStore interface {
+	ListRoleBindings(ctx context.Context, principal string, project string) (*ListOfRoleBindings, error)
+}
+
+// maxCachedPrincipals is how many principals RoleBindingCache holds before
+// it drops expired entries.
+const maxCachedPrincipals = 10000
+
+type cachedRoleBindings struct {
+	bindings *ListOfRoleBindings
+	expires  time.Time
+}
+
+// RoleBindingCache keeps the role bindings of each principal for TTL, so that
+// authenticating a request does not read them from the database every time.
+// Changes made through this server are seen at once, those made through
+// other servers after at most TTL.
+type RoleBindingCache struct {
+	Store RoleBindingStore
+	TTL   time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedRoleBindings
+}
+
+func NewRoleBindingCache(store RoleBindingStore, ttl time.Duration) *RoleBindingCache {
+	return &RoleBindingCache{Store: store, TTL: ttl, entries: map[string]cachedRoleBindings{}}
+}
+
+// ListRoleBindings returns the bindings of principal in every project from
+// the cache, other lookups from the store.
+func (c *RoleBindingCache) ListRoleBindings(ctx context.Context, principal string, project string) (*ListOfRoleBindings, error) {
+	if principal == "" || project != "" {
+		return c.Store.ListRoleBindings(ctx, principal, project)
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	entry, ok := c.entries[principal]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.bindings, nil
+	}
+
+	bindings, err := c.Store.ListRoleBindings(ctx, principal, project)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxCachedPrincipals {
+		for cached, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, cached)
+			}
+		}
+	}
+	c.entries[principal] = cachedRoleBindings{bindings: bindings, expires: now.Add(c.TTL)}
+	return bindings, nil
+}
+
+// Invalidate forgets the bindings of principal after they changed.
+func (c *RoleBindingCache) Invalidate(principal string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, principal)
+}
+
+// loadRoleBindings returns the bindings stored for principal, none if there
+// are no bindings for them.
+func loadRoleBindings(ctx context.Context, store RoleBindingStore, principal string) ([]*RoleBinding, error) {
+	stored, err := store.ListRoleBindings(ctx, principal, "")
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, grpc.Errorf(codes.Unavailable, "Error loading role bindings: %v", err)
+	}
+	return stored.Bindings, nil
+}
+
+// certificateBinding maps the "project.kind" certificate convention to the
+// equivalent role: "project.*" is a submitter in project, "ANY.kind" is a
+// worker for kind and "ANY.ANY" is a cluster admin.
+func certificateBinding(username, projectAccess, kindAccess string) *RoleBinding {
+	binding := &RoleBinding{
+		Principal: username,
+		Project:   projectAccess,
+		Kind:      AnyScope,
+	}
+	switch {
+	case projectAccess != AnyScope:
+		binding.Role = string(RoleSubmitter)
+	case kindAccess != AnyScope:
+		binding.Role = string(RoleWorker)
+		binding.Kind = kindAccess
+	default:
+		binding.Role = string(RoleClusterAdmin)
+	}
+	return binding
+}
+
+func (b *RoleBinding) hasPermission(perm Permission) bool {
+	for _, p := range rolePermissions[Role(b.Role)] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+func scopeMatches(bound, requested string) bool {
+	return bound == AnyScope || bound == requested
+}
+
+// orAnyScope returns AnyScope for an empty request filter.
+func orAnyScope(filter string) string {
+	if filter == "" {
+		return AnyScope
+	}
+	return filter
+}
+
+// validateRoleBinding checks that b names a known role and a scope.
+func validateRoleBinding(b *RoleBinding) error {
+	if b.Principal == "" {
+		return grpc.Errorf(codes.InvalidArgument, "Principal is required")
+	}
+	if _, ok := rolePermissions[Role(b.Role)]; !ok {
+		return grpc.Errorf(codes.InvalidArgument, "Unknown role %q", b.Role)
+	}
+	if b.Project == "" {
+		return grpc.Errorf(codes.InvalidArgument, "Project is required, use %s for all projects", AnyScope)
+	}
+	if b.Kind == "" {
+		b.Kind = AnyScope
+	}
+	if Role(b.Role) == RoleClusterAdmin && (b.Project != AnyScope || b.Kind != AnyScope) {
+		return grpc.Errorf(codes.InvalidArgument, "%s can only be bound to %s projects and kinds", RoleClusterAdmin, AnyScope)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package wonderland
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCertificateBindings(t *testing.T) {
+	submitter := User{Bindings: []*RoleBinding{certificateBinding("alice", "ship-shield", "docker")}}
+	if !submitter.Can(PermCreateJobs, "ship-shield", "anything") || !submitter.Can(PermPullJobs, "ship-shield", AnyScope) {
+		t.Fail()
+	}
+	if submitter.Can(PermGetJobs, "other", "docker") || submitter.Can(PermListJobs, AnyScope, AnyScope) {
+		t.Fail()
+	}
+	if submitter.MayEver(PermManageBindings) {
+		t.Fail()
+	}
+
+	worker := User{Bindings: []*RoleBinding{certificateBinding("docker", AnyScope, "docker")}}
+	if !worker.Can(PermPullJobs, AnyScope, "docker") || !worker.Can(PermUpdateJobs, "ship-shield", "docker") {
+		t.Fail()
+	}
+	if worker.Can(PermPullJobs, AnyScope, "gpu") || worker.MayEver(PermCreateJobs) || worker.MayEver(PermKillJobs) {
+		t.Fail()
+	}
+
+	admin := User{Bindings: []*RoleBinding{certificateBinding("alex", AnyScope, AnyScope)}}
+	if !admin.Can(PermManageBindings, AnyScope, AnyScope) || !admin.Can(PermDeleteJobs, "ship-shield", "docker") {
+		t.Fail()
+	}
+}
+
+func TestStoredBindingsAddAccess(t *testing.T) {
+	user := User{Bindings: []*RoleBinding{
+		certificateBinding("alice", "ship-shield", AnyScope),
+		{Principal: "alice", Role: string(RoleViewer), Project: "lhcb", Kind: AnyScope},
+	}}
+	if !user.Can(PermListJobs, "lhcb", AnyScope) {
+		t.Fail()
+	}
+	if user.Can(PermCreateJobs, "lhcb", "docker") {
+		t.Fail()
+	}
+
+	admin := User{Bindings: []*RoleBinding{{Principal: "bob", Role: string(RoleProjectAdmin), Project: "lhcb", Kind: AnyScope}}}
+	if !admin.Can(PermListDeletedJobs, "lhcb", "docker") || admin.Can(PermListDeletedJobs, AnyScope, AnyScope) {
+		t.Fail()
+	}
+}
+
+func TestValidateRoleBinding(t *testing.T) {
+	valid := &RoleBinding{Principal: "alice", Role: string(RoleProjectAdmin), Project: "lhcb"}
+	checkTestErr(validateRoleBinding(valid), t)
+	if valid.Kind != AnyScope {
+		t.Fail()
+	}
+
+	invalid := []*RoleBinding{
+		{Role: string(RoleViewer), Project: "lhcb"},
+		{Principal: "alice", Role: "root", Project: "lhcb"},
+		{Principal: "alice", Role: string(RoleViewer)},
+		{Principal: "alice", Role: string(RoleClusterAdmin), Project: "lhcb"},
+	}
+	for _, b := range invalid {
+		if validateRoleBinding(b) == nil {
+			t.Errorf("expected %v to be rejected", b)
+		}
+	}
+}
+
+func TestHandlersCheckPermissions(t *testing.T) {
+	s := &Server{}
+
+	binding := func(role Role) User {
+		return User{Bindings: []*RoleBinding{{Principal: "alice", Role: string(role), Project: "lhcb", Kind: AnyScope}}}
+	}
+	viewer, submitter, worker, admin := binding(RoleViewer), binding(RoleSubmitter), binding(RoleWorker), binding(RoleProjectAdmin)
+	stranger := User{Username: "mallory"}
+
+	denied := []struct {
+		name string
+		user User
+		call func(ctx context.Context) error
+	}{
+		{"ListAuditEvents by a submitter", submitter, func(ctx context.Context) error {
+			_, err := s.ListAuditEvents(ctx, &ListAuditEventsRequest{Project: "lhcb"})
+			return err
+		}},
+		{"ListAuditEvents of another project", admin, func(ctx context.Context) error {
+			_, err := s.ListAuditEvents(ctx, &ListAuditEventsRequest{Project: "dark-matter"})
+			return err
+		}},
+		{"ListJobs of deleted jobs", submitter, func(ctx context.Context) error {
+			_, err := s.ListJobs(ctx, &ListJobsRequest{Project: "lhcb", IncludeDeleted: true})
+			return err
+		}},
+		{"CreateSchedule", viewer, func(ctx context.Context) error {
+			_, err := s.CreateSchedule(ctx, &Schedule{Cron: "@daily", Template: &Job{Project: "lhcb", Kind: "docker"}})
+			return err
+		}},
+		{"DeleteSchedule", viewer, func(ctx context.Context) error {
+			_, err := s.DeleteSchedule(ctx, &RequestWithId{Id: 1})
+			return err
+		}},
+		{"TailJobLogs", stranger, func(ctx context.Context) error {
+			return s.TailJobLogs(&TailJobLogsRequest{Id: 1, Follow: true}, &tailStream{ctx: ctx})
+		}},
+		{"CreateWebhook", submitter, func(ctx context.Context) error {
+			_, err := s.CreateWebhook(ctx, &Webhook{Project: "lhcb", Url: "https://ci.example.com/"})
+			return err
+		}},
+		{"DeleteWebhook", submitter, func(ctx context.Context) error {
+			_, err := s.DeleteWebhook(ctx, &RequestWithId{Id: 1})
+			return err
+		}},
+		{"StreamEvents", worker, func(ctx context.Context) error {
+			return s.StreamEvents(&StreamEventsRequest{Project: "lhcb", Follow: true}, &eventStream{ctx: ctx})
+		}},
+		{"RegisterKind", submitter, func(ctx context.Context) error {
+			_, err := s.RegisterKind(ctx, &Kind{Project: "lhcb", Name: "docker", InputSchema: testInputSchema})
+			return err
+		}},
+		{"CreateJobTemplate", viewer, func(ctx context.Context) error {
+			_, err := s.CreateJobTemplate(ctx, testJobTemplate())
+			return err
+		}},
+		{"CreateJobFromTemplate", viewer, func(ctx context.Context) error {
+			_, err := s.CreateJobFromTemplate(ctx, &CreateJobFromTemplateRequest{Project: "lhcb", Name: "simulation"})
+			return err
+		}},
+		{"CreateJobFromTemplate of another project", submitter, func(ctx context.Context) error {
+			_, err := s.CreateJobFromTemplate(ctx, &CreateJobFromTemplateRequest{Project: "dark-matter", Name: "simulation"})
+			return err
+		}},
+		{"CreateArrayJob", viewer, func(ctx context.Context) error {
+			_, err := s.CreateArrayJob(ctx, testArrayJob())
+			return err
+		}},
+	}
+	for _, c := range denied {
+		ctx := context.WithValue(context.Background(), "authorized-user", c.user)
+		err := c.call(ctx)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%s should be denied, got %v", c.name, err)
+		}
+	}
+}
+
+func TestRescheduleJobChecks(t *testing.T) {
+	s := &Server{}
+
+	worker := User{Bindings: []*RoleBinding{certificateBinding("docker", AnyScope, "docker")}}
+	ctx := context.WithValue(context.Background(), "authorized-user", worker)
+	_, err := s.RescheduleJob(ctx, &RescheduleJobRequest{Id: 1, RunAfter: 1524470400})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fail()
+	}
+	_, err = s.RescheduleJob(ctx, &RescheduleJobRequest{Id: 1, RunAfter: -1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fail()
+	}
+}
+
+// countingBindingStore counts the lookups of the role bindings it returns.
+type countingBindingStore struct {
+	lookups  int
+	bindings []*RoleBinding
+}
+
+func (s *countingBindingStore) ListRoleBindings(ctx context.Context, principal string, project string) (*ListOfRoleBindings, error) {
+	s.lookups++
+	return &ListOfRoleBindings{Bindings: s.bindings}, nil
+}
+
+func TestRoleBindingCache(t *testing.T) {
+	store := &countingBindingStore{bindings: []*RoleBinding{{Principal: "alice", Role: string(RoleViewer), Project: "lhcb"}}}
+	cache := NewRoleBindingCache(store, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		bindings, err := loadRoleBindings(context.Background(), cache, "alice")
+		checkTestErr(err, t)
+		if len(bindings) != 1 {
+			t.Fail()
+		}
+	}
+	if store.lookups != 1 {
+		t.Errorf("%d lookups", store.lookups)
+	}
+
+	cache.Invalidate("alice")
+	_, err := loadRoleBindings(context.Background(), cache, "alice")
+	checkTestErr(err, t)
+	if store.lookups != 2 {
+		t.Errorf("%d lookups after invalidation", store.lookups)
+	}
+}
@@ -0,0 +1,87 @@
+package wonderland
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// RevocationList is an in-memory copy of the revoked certificate serials
+// stored in the database, checked on every certificate authentication.
+type RevocationList struct {
+	mu      sync.RWMutex
+	serials map[string]bool
+}
+
+func NewRevocationList() *RevocationList {
+	return &RevocationList{serials: map[string]bool{}}
+}
+
+func (l *RevocationList) IsRevoked(serial string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.serials[serial]
+}
+
+func (l *RevocationList) set(serial string, revoked bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if revoked {
+		l.serials[serial] = true
+	} else {
+		delete(l.serials, serial)
+	}
+}
+
+// Refresh replaces the list with the serials stored in the database.
+func (l *RevocationList) Refresh(ctx context.Context, storage *WonderlandStorage) error {
+	list, err := storage.ListRevokedCertificates(ctx)
+	if err != nil {
+		return err
+	}
+
+	serials := map[string]bool{}
+	for _, c := range list.Certificates {
+		serials[c.Serial] = true
+	}
+	l.mu.Lock()
+	l.serials = serials
+	l.mu.Unlock()
+	return nil
+}
+
+// Run refreshes the list every interval until ctx is done, picking up
+// revocations made through other servers.
+func (l *RevocationList) Run(ctx context.Context, storage *WonderlandStorage, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := l.Refresh(ctx, storage)
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to refresh revoked certificates")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// normalizeSerial accepts serials in hexadecimal, optionally separated by
+// colons as printed by openssl, and returns them in the stored format.
+func normalizeSerial(serial string) (string, error) {
+	n, ok := new(big.Int).SetString(strings.Replace(serial, ":", "", -1), 16)
+	if !ok || n.Sign() < 0 {
+		return "", grpc.Errorf(codes.InvalidArgument, "Invalid certificate serial %q", serial)
+	}
+	return n.Text(16), nil
+}
@@ -0,0 +1,576 @@
+package wonderland
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/lib/pq"
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// schedulerLockKey is the advisory lock held by the replica materializing
+// due schedules.
+const schedulerLockKey = 0x776f6e64
+
+// nextRun returns the first time after t at which the cron expression of
+// schedule fires in its time zone.
+func nextRun(schedule *Schedule, t time.Time) (time.Time, error) {
+	location, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return time.Time{}, grpc.Errorf(codes.InvalidArgument, "Invalid time zone %q: %v", schedule.Timezone, err)
+	}
+	spec, err := cron.ParseStandard(schedule.Cron)
+	if err != nil {
+		return time.Time{}, grpc.Errorf(codes.InvalidArgument, "Invalid cron expression %q: %v", schedule.Cron, err)
+	}
+	next := spec.Next(t.In(location))
+	if next.IsZero() {
+		return time.Time{}, grpc.Errorf(codes.InvalidArgument, "Cron expression %q never fires", schedule.Cron)
+	}
+	return next.UTC(), nil
+}
+
+// validateSchedule checks a new schedule at now and keeps only the fields of
+// its template which are copied to created jobs.
+func validateSchedule(schedule *Schedule, now time.Time) error {
+	if schedule.Template == nil {
+		return grpc.Errorf(codes.InvalidArgument, "Schedule template is required")
+	}
+	if _, ok := Schedule_Overlap_name[int32(schedule.Overlap)]; !ok {
+		return grpc.Errorf(codes.InvalidArgument, "Invalid overlap policy %d", schedule.Overlap)
+	}
+	_, err := nextRun(schedule, now)
+	if err != nil {
+		return err
+	}
+	schedule.Template = &Job{
+		Project:    schedule.Template.Project,
+		Kind:       schedule.Template.Kind,
+		Input:      schedule.Template.Input,
+		Metadata:   schedule.Template.Metadata,
+		MaxRuntime: schedule.Template.MaxRuntime,
+	}
+	return validateJobTimes(schedule.Template)
+}
+
+const scheduleColumns = `id, template::text, cron, timezone, overlap, paused,
+	EXTRACT(EPOCH FROM next_run)::bigint, COALESCE(EXTRACT(EPOCH FROM last_run)::bigint, 0), last_job_id`
+
+// scanSchedule reads a row of scheduleColumns followed by extra columns.
+func scanSchedule(row interface {
+	Scan(dest ...interface{}) error
+}, extra ...interface{}) (*Schedule, error) {
+	schedule := &Schedule{Template: &Job{}}
+	var template string
+	err := row.Scan(append([]interface{}{
+		&schedule.Id,
+		&template,
+		&schedule.Cron,
+		&schedule.Timezone,
+		&schedule.Overlap,
+		&schedule.Paused,
+		&schedule.NextRun,
+		&schedule.LastRun,
+		&schedule.LastJobId,
+	}, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	err = jsonpb.UnmarshalString(template, schedule.Template)
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// scheduleCreator is who created a schedule, as far as it tells whether they
+// may still create its jobs.
+type scheduleCreator struct {
+	username string
+	// grants are those of the certificate or token the schedule was created
+	// with, in the "project.kind" convention. unchecked is set for schedules
+	// created before grants were recorded.
+	grants    []string
+	serial    string
+	unchecked bool
+}
+
+// mayRun tells whether the creator of schedule may still create its jobs:
+// the certificate they created it with is not revoked, and its grants or the
+// role bindings now stored for them allow it.
+func (c scheduleCreator) mayRun(ctx context.Context, tx *sql.Tx, schedule *Schedule) (bool, error) {
+	if c.unchecked {
+		return true, nil
+	}
+	if c.serial != "" {
+		var revoked bool
+		err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM revoked_certificates WHERE serial=$1);`, c.serial,
+		).Scan(&revoked)
+		if err != nil || revoked {
+			return false, err
+		}
+	}
+
+	user := User{Username: c.username}
+	for _, grant := range c.grants {
+		projectAccess, kindAccess, err := parseCertificateFields(grant)
+		if err != nil {
+			return false, err
+		}
+		user.Bindings = append(user.Bindings, certificateBinding(user.Username, projectAccess, kindAccess))
+	}
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+roleBindingColumns+`
+		FROM role_bindings
+		WHERE principal=$1;`, c.username,
+	)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		binding := &RoleBinding{}
+		err = rows.Scan(roleBindingFields(binding)...)
+		if err != nil {
+			return false, err
+		}
+		user.Bindings = append(user.Bindings, binding)
+	}
+	err = rows.Err()
+	if err != nil {
+		return false, err
+	}
+	return user.Can(PermCreateJobs, schedule.Template.Project, schedule.Template.Kind), nil
+}
+
+// RunScheduler creates the jobs of due schedules every interval until ctx
+// is done. Every replica may run it: only the one holding the scheduler lock
+// at a tick creates jobs.
+func (storage *WonderlandStorage) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		created, err := storage.RunDueSchedules(ctx)
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to run due schedules")
+		} else if created > 0 {
+			logrus.WithField("jobs", created).Info("Created scheduled jobs")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDueSchedules creates the jobs of the schedules which are due, applying
+// their overlap policy, and moves them to their next run. Schedules which
+// fail to run, such as those whose creator lost access, are paused without
+// holding back the others. It does nothing if another replica holds the
+// scheduler lock.
+func (storage *WonderlandStorage) RunDueSchedules(ctx context.Context) (created int, err error) {
+	ctx, span := startStorageSpan(ctx, "RunDueSchedules")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var leader bool
+	err = tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock($1);`, schedulerLockKey).Scan(&leader)
+	if err != nil || !leader {
+		tx.Rollback()
+		return 0, err
+	}
+
+	now := storage.now()
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+scheduleColumns+`, COALESCE(creator, ''), COALESCE(creator_grants, '{}'),
+			creator_serial, creator_grants IS NULL
+		FROM job_schedules
+		WHERE NOT paused AND next_run <= $1
+		ORDER BY next_run
+		FOR UPDATE;`, now)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	schedules := []*Schedule{}
+	creators := []scheduleCreator{}
+	for rows.Next() {
+		var creator scheduleCreator
+		schedule, err := scanSchedule(rows, &creator.username, pq.Array(&creator.grants), &creator.serial, &creator.unchecked)
+		if err != nil {
+			rows.Close()
+			tx.Rollback()
+			return 0, err
+		}
+		schedules = append(schedules, schedule)
+		creators = append(creators, creator)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	events := []*AuditEvent{}
+	runs := []*scheduleRun{}
+	for i, schedule := range schedules {
+		scheduleCtx := withRequestInfo(ctx, fmt.Sprintf("schedule %d", schedule.Id), "")
+		_, err = tx.ExecContext(ctx, `SAVEPOINT run_schedule;`)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		run, err := storage.runSchedule(scheduleCtx, tx, schedule, creators[i], now)
+		if err != nil {
+			logrus.WithError(err).WithField("schedule", schedule.Id).Warn("Pausing schedule which failed to run")
+			run, err = pauseFailedSchedule(scheduleCtx, tx, schedule)
+		}
+		if err == nil {
+			// so that savepoints do not pile up over the due schedules
+			_, err = tx.ExecContext(ctx, `RELEASE SAVEPOINT run_schedule;`)
+		}
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("schedule %d: %v", schedule.Id, err)
+		}
+		if run.created != nil {
+			created++
+		}
+		events = append(events, run.events...)
+		runs = append(runs, run)
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	storage.exportAuditEvents(events...)
+	for _, run := range runs {
+		if run.killed != nil {
+			countFinishedJob(run.previous, run.killed)
+		}
+	}
+	return created, nil
+}
+
+// scheduleRun is what running a due schedule changed.
+type scheduleRun struct {
+	created *Job
+	// killed is the previous job, replaced by created, and previous the same
+	// job before it was killed
+	killed   *Job
+	previous *Job
+	events   []*AuditEvent
+}
+
+// pauseFailedSchedule undoes what running schedule changed since the
+// run_schedule savepoint and pauses it.
+func pauseFailedSchedule(ctx context.Context, tx *sql.Tx, schedule *Schedule) (*scheduleRun, error) {
+	_, err := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT run_schedule;`)
+	if err != nil {
+		return nil, err
+	}
+	paused, err := scanSchedule(tx.QueryRowContext(ctx, `
+		UPDATE job_schedules
+		SET paused=true
+		WHERE id=$1
+		RETURNING `+scheduleColumns+`;`, schedule.Id,
+	))
+	if err != nil {
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, 0, paused.Template.Project, schedule, paused)
+	if err != nil {
+		return nil, err
+	}
+	return &scheduleRun{events: []*AuditEvent{event}}, nil
+}
+
+// runSchedule creates the job of a due schedule in tx.
+func (storage *WonderlandStorage) runSchedule(ctx context.Context, tx *sql.Tx, schedule *Schedule, creator scheduleCreator, now time.Time) (*scheduleRun, error) {
+	run := &scheduleRun{}
+	next, err := nextRun(schedule, now)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := creator.mayRun(ctx, tx, schedule)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%s may no longer create the jobs of the schedule", creator.username)
+	}
+
+	var previous *Job
+	if schedule.LastJobId != 0 {
+		previous, err = lockJob(ctx, tx, schedule.LastJobId)
+		switch {
+		case err == sql.ErrNoRows:
+			// purged or archived, so long finished
+			previous = nil
+		case err != nil:
+			return nil, err
+		}
+	}
+
+	if previous != nil && previous.DeletedAt == 0 && !isFinished(previous.Status) {
+		switch schedule.Overlap {
+		case Schedule_SKIP:
+			_, err = tx.ExecContext(ctx, `UPDATE job_schedules SET next_run=$1 WHERE id=$2;`, next, schedule.Id)
+			return run, err
+		case Schedule_QUEUE:
+			// stays due until the previous job has finished
+			return run, nil
+		case Schedule_REPLACE:
+			killed := &Job{}
+			err = tx.QueryRowContext(ctx, `
+				UPDATE jobs
+				SET
+					status=$1,
+					last_modified=$2
+				WHERE id=$3
+				RETURNING `+jobColumns+`;`, Job_KILLED, now, previous.Id,
+			).Scan(jobFields(killed)...)
+			if err != nil {
+				return nil, err
+			}
+			event, err := recordAuditEvent(ctx, tx, killed.Id, killed.Project, previous, killed)
+			if err == nil {
+				err = storage.enqueueWebhookDeliveries(ctx, tx, previous, killed)
+			}
+			if err != nil {
+				return nil, err
+			}
+			run.killed = killed
+			run.previous = previous
+			run.events = append(run.events, event)
+		}
+	}
+
+	run.created, err = insertJob(ctx, tx, schedule.Template, creator.username)
+	if err != nil {
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, run.created.Id, run.created.Project, nil, run.created)
+	if err != nil {
+		return nil, err
+	}
+	run.events = append(run.events, event)
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE job_schedules
+		SET next_run=$1, last_run=$2, last_job_id=$3
+		WHERE id=$4;`, next, now, run.created.Id, schedule.Id,
+	)
+	return run, err
+}
+
+func (storage *WonderlandStorage) CreateSchedule(ctx context.Context, schedule *Schedule, creator User) (created *Schedule, err error) {
+	ctx, span := startStorageSpan(ctx, "CreateSchedule")
+	defer func() { endSpan(span, err) }()
+
+	now := storage.now()
+	next, err := nextRun(schedule, now)
+	if err != nil {
+		return nil, err
+	}
+	template, err := auditMarshaler.MarshalToString(schedule.Template)
+	if err != nil {
+		return nil, err
+	}
+	// rechecked whenever the schedule runs
+	grants := []string{}
+	for _, grant := range creator.Grants {
+		grants = append(grants, grant.Project+"."+grant.Kind)
+	}
+	serial := ""
+	if creator.Certificate != nil {
+		serial = serialString(creator.Certificate)
+	}
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err = scanSchedule(tx.QueryRowContext(ctx, `
+		INSERT INTO job_schedules (project, kind, template, cron, timezone, overlap, paused, next_run, creator, created,
+			creator_grants, creator_serial)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING `+scheduleColumns+`;`,
+		schedule.Template.Project,
+		schedule.Template.Kind,
+		template,
+		schedule.Cron,
+		schedule.Timezone,
+		schedule.Overlap,
+		schedule.Paused,
+		next,
+		creator.Username,
+		now,
+		pq.Array(grants),
+		serial,
+	))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, 0, created.Template.Project, nil, created)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(event)
+	return created, nil
+}
+
+func (storage *WonderlandStorage) GetSchedule(ctx context.Context, id uint64) (schedule *Schedule, err error) {
+	ctx, span := startStorageSpan(ctx, "GetSchedule")
+	defer func() { endSpan(span, err) }()
+
+	return scanSchedule(storage.db.QueryRowContext(ctx, `
+		SELECT `+scheduleColumns+`
+		FROM job_schedules
+		WHERE id=$1;`, id,
+	))
+}
+
+// ListSchedules returns the schedules of project, of every project if it is
+// empty.
+func (storage *WonderlandStorage) ListSchedules(ctx context.Context, project string) (ret *ListOfSchedules, err error) {
+	ctx, span := startStorageSpan(ctx, "ListSchedules")
+	defer func() { endSpan(span, err) }()
+
+	strQuery := `SELECT ` + scheduleColumns + ` FROM job_schedules WHERE true`
+	args := []interface{}{}
+	if project != "" {
+		args = append(args, project)
+		strQuery += " AND project=$" + strconv.Itoa(len(args))
+	}
+	strQuery += ` ORDER BY id;`
+
+	rows, err := storage.db.QueryContext(ctx, strQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret = &ListOfSchedules{Schedules: []*Schedule{}}
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		ret.Schedules = append(ret.Schedules, schedule)
+	}
+	err = rows.Err()
+	return ret, err
+}
+
+// PauseSchedule pauses or resumes a schedule. Resumed schedules run next at
+// their first time from now on, runs missed while paused are not caught up.
+func (storage *WonderlandStorage) PauseSchedule(ctx context.Context, id uint64, paused bool) (schedule *Schedule, err error) {
+	ctx, span := startStorageSpan(ctx, "PauseSchedule")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := scanSchedule(tx.QueryRowContext(ctx, `
+		SELECT `+scheduleColumns+`
+		FROM job_schedules
+		WHERE id=$1
+		FOR UPDATE;`, id,
+	))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	next := time.Unix(before.NextRun, 0).UTC()
+	if before.Paused && !paused {
+		next, err = nextRun(before, storage.now())
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	schedule, err = scanSchedule(tx.QueryRowContext(ctx, `
+		UPDATE job_schedules
+		SET paused=$1, next_run=$2
+		WHERE id=$3
+		RETURNING `+scheduleColumns+`;`, paused, next, id,
+	))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, 0, schedule.Template.Project, before, schedule)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(event)
+	return schedule, nil
+}
+
+// DeleteSchedule stops a schedule. Jobs it already created are kept.
+func (storage *WonderlandStorage) DeleteSchedule(ctx context.Context, id uint64) (schedule *Schedule, err error) {
+	ctx, span := startStorageSpan(ctx, "DeleteSchedule")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err = scanSchedule(tx.QueryRowContext(ctx, `
+		DELETE FROM job_schedules
+		WHERE id=$1
+		RETURNING `+scheduleColumns+`;`, id,
+	))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, 0, schedule.Template.Project, schedule, nil)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(event)
+	return schedule, nil
+}
@@ -0,0 +1,49 @@
+package wonderland
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNextRun(t *testing.T) {
+	schedule := &Schedule{Cron: "30 2 * * *", Timezone: "Europe/Moscow"}
+	next, err := nextRun(schedule, time.Date(2018, 4, 30, 0, 0, 0, 0, time.UTC))
+	checkTestErr(err, t)
+	// 02:30 in Moscow is 23:30 UTC of the day before
+	if !next.Equal(time.Date(2018, 4, 30, 23, 30, 0, 0, time.UTC)) {
+		t.Log(next)
+		t.Fail()
+	}
+
+	next, err = nextRun(&Schedule{Cron: "@hourly"}, time.Date(2018, 4, 30, 10, 0, 0, 0, time.UTC))
+	checkTestErr(err, t)
+	if !next.Equal(time.Date(2018, 4, 30, 11, 0, 0, 0, time.UTC)) {
+		t.Log(next)
+		t.Fail()
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	invalid := []*Schedule{
+		{Cron: "@daily"},
+		{Cron: "every day", Template: &Job{}},
+		{Cron: "@daily", Timezone: "Mars/Olympus", Template: &Job{}},
+		{Cron: "@daily", Overlap: 7, Template: &Job{}},
+	}
+	for _, schedule := range invalid {
+		if status.Code(validateSchedule(schedule, time.Now())) != codes.InvalidArgument {
+			t.Log(schedule)
+			t.Fail()
+		}
+	}
+
+	schedule := &Schedule{Cron: "@daily", Template: &Job{Project: "lhcb", Kind: "docker", Input: "in", Status: Job_RUNNING, Id: 3}}
+	checkTestErr(validateSchedule(schedule, time.Now()), t)
+	if schedule.Template.Status != Job_PENDING || schedule.Template.Id != 0 || schedule.Template.Input != "in" {
+		t.Log(schedule.Template)
+		t.Fail()
+	}
+}
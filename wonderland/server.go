@@ -1,67 +1,160 @@
 package wonderland
 
 import (
+	"database/sql"
 	"fmt"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+	"time"
 )
 
 type Server struct {
 	Storage   *WonderlandStorage
 	SecretKey []byte
+
+	// Tokens verifies bearer tokens and issues new ones with SecretKey.
+	// Token authentication is disabled when nil.
+	Tokens *TokenAuth
+
+	// Revocations lists the serials of client certificates which are not
+	// accepted anymore. No certificate is denied when nil.
+	Revocations *RevocationList
+
+	// CA issues client certificates. IssueCertificate and RenewCertificate
+	// are disabled when nil.
+	CA *CertificateAuthority
+
+	// RoleBindings is where the role bindings of authenticated users are
+	// looked up, usually Storage behind a RoleBindingCache. When nil only
+	// the role derived from the client certificate applies.
+	RoleBindings RoleBindingStore
+
+	// Archiver restores archived jobs. RestoreArchivedJob is disabled when
+	// nil.
+	Archiver *Archiver
+
+	drainInit sync.Once
+	drainOnce sync.Once
+	drain     chan struct{}
+}
+
+// Drain makes streaming RPCs return, so that a graceful stop of the gRPC
+// server does not wait for long-lived subscribers.
+func (s *Server) Drain() {
+	s.draining()
+	s.drainOnce.Do(func() { close(s.drain) })
+}
+
+// draining returns a channel closed by Drain. Streaming handlers should
+// select on it and return when it is closed.
+func (s *Server) draining() <-chan struct{} {
+	s.drainInit.Do(func() { s.drain = make(chan struct{}) })
+	return s.drain
 }
 
 func detailedInternalError(err error) error {
 	return grpc.Errorf(codes.Internal, fmt.Sprintf("Error processing job: %v", err))
 }
 
-func (s *Server) CreateJob(ctx context.Context, in *Job) (*Job, error) {
-	user := getAuthUserFromContext(ctx)
+func errJobDeleted(job *Job) error {
+	return grpc.Errorf(codes.FailedPrecondition, "Job %d is deleted", job.Id)
+}
 
-	// if worker - Cannot create jobs
-	if user.IsWorker() {
-		return nil, grpc.Errorf(codes.PermissionDenied, "Workers cannot create jobs")
+// authorizeJob reads the job with the given id and checks that user has perm
+// on it.
+func (s *Server) authorizeJob(ctx context.Context, user User, perm Permission, id uint64) (*Job, error) {
+	if !user.MayEver(perm) {
+		return nil, errNoAccess
 	}
-	// if user - Can create jobs in their project
-	in.Project = user.ProjectAccess
 
-	createdJob, err := s.Storage.CreateJob(in, user)
+	job, err := s.Storage.GetJob(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, grpc.Errorf(codes.NotFound, "Job %d does not exist", id)
+	}
 	if err != nil {
 		return nil, detailedInternalError(err)
 	}
+	if !user.Can(perm, job.Project, job.Kind) {
+		return nil, errNoAccess
+	}
+	return job, nil
+}
 
-	return createdJob, nil
+func (s *Server) CreateJob(ctx context.Context, in *Job) (*Job, error) {
+	// only CreateJobFromTemplate and CreateArrayJob record where a job came
+	// from
+	in.TemplateId = 0
+	in.TemplateVersion = 0
+	in.ArrayId = 0
+	in.ArrayIndex = 0
+	return s.createJob(ctx, in)
 }
 
-func (s *Server) GetJob(ctx context.Context, in *RequestWithId) (*Job, error) {
+func (s *Server) createJob(ctx context.Context, in *Job) (*Job, error) {
 	user := getAuthUserFromContext(ctx)
 
-	job, err := s.Storage.GetJob(in.Id)
+	// users with access to a single project may leave it out
+	if in.Project == "" {
+		project, err := user.onlyProject(PermCreateJobs)
+		if err != nil {
+			return nil, err
+		}
+		in.Project = project
+	}
+	if !user.Can(PermCreateJobs, in.Project, in.Kind) {
+		return nil, errNoAccess
+	}
+	err := validateIdempotencyKey(in.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	err = validateJobTimes(in)
+	if err != nil {
+		return nil, err
+	}
+	err = s.validateJobDocuments(ctx, in)
+	if err != nil {
+		return nil, err
+	}
 
+	createdJob, err := s.Storage.CreateJob(ctx, in, user)
+	if err == errIdempotencyKeyReused || err == errIdempotentJobGone {
+		return nil, err
+	}
 	if err != nil {
 		return nil, detailedInternalError(err)
 	}
-	// if user - Can get jobs from their project
-	// if worker - Can get jobs with proper kind
-	if !user.CanAccessJob(job) {
-		return nil, grpc.Errorf(codes.PermissionDenied, "No access")
-	}
 
-	return job, nil
+	return createdJob, nil
+}
+
+func (s *Server) GetJob(ctx context.Context, in *RequestWithId) (*Job, error) {
+	user := getAuthUserFromContext(ctx)
+
+	return s.authorizeJob(ctx, user, PermGetJobs, in.Id)
 }
 
 func (s *Server) ListJobs(ctx context.Context, in *ListJobsRequest) (*ListOfJobs, error) {
 	user := getAuthUserFromContext(ctx)
 
-	// if worker - Cannot list jobs
-	if user.IsWorker() {
-		return nil, grpc.Errorf(codes.PermissionDenied, "Workers cannot list jobs")
+	if in.Project == "" && !user.Can(PermListJobs, AnyScope, orAnyScope(in.Kind)) {
+		project, err := user.onlyProject(PermListJobs)
+		if err != nil {
+			return nil, err
+		}
+		in.Project = project
+	}
+	if !user.Can(PermListJobs, orAnyScope(in.Project), orAnyScope(in.Kind)) {
+		return nil, errNoAccess
+	}
+	if in.IncludeDeleted && !user.Can(PermListDeletedJobs, orAnyScope(in.Project), orAnyScope(in.Kind)) {
+		return nil, errNoAccess
 	}
-	// if user - Can list jobs by kind in their project
-	in.Project = user.ProjectAccess
 
-	ret, err := s.Storage.ListJobs(in.HowMany, in.Project, in.Kind)
+	ret, err := s.Storage.ListJobs(ctx, in)
 	if err != nil {
 		return nil, detailedInternalError(err)
 	}
@@ -71,13 +164,44 @@ func (s *Server) ListJobs(ctx context.Context, in *ListJobsRequest) (*ListOfJobs
 
 func (s *Server) ModifyJob(ctx context.Context, in *Job) (*Job, error) {
 	user := getAuthUserFromContext(ctx)
-	// if user - Can modify jobs in their project
-	// if worker - Can modify jobs with proper kind
-	if !user.CanAccessJob(in) {
-		return nil, grpc.Errorf(codes.PermissionDenied, "No access")
+
+	// access is checked against the stored job, as project and kind
+	// cannot be changed
+	job, err := s.authorizeJob(ctx, user, PermUpdateJobs, in.Id)
+	if err != nil {
+		return nil, err
+	}
+	if job.DeletedAt != 0 {
+		return nil, errJobDeleted(job)
+	}
+	// the worker of a job failed by the watchdog has to stop, while those who
+	// may submit its jobs can put it back to run again
+	restart := job.FailureReason != "" && in.Status == Job_PENDING && user.Can(PermCreateJobs, job.Project, job.Kind)
+	if job.FailureReason != "" && !restart {
+		return nil, errJobStopped(job)
+	}
+	// failed jobs report whatever output they have
+	if in.Status != Job_FAILED {
+		err = s.validateJobOutput(ctx, job, in.Output)
+		if status.Code(err) == codes.InvalidArgument && isFinished(in.Status) {
+			// rejecting the update would leave the job running for good
+			in.Status = Job_FAILED
+			in.Error = outputInvalid(err)
+			err = nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = validateJobError(in, user)
+	if err != nil {
+		return nil, err
 	}
 
-	ret, err := s.Storage.UpdateJob(in)
+	ret, err := s.Storage.UpdateJob(ctx, in, restart)
+	if status.Code(err) == codes.FailedPrecondition {
+		return nil, err
+	}
 	if err != nil {
 		return nil, detailedInternalError(err)
 	}
@@ -87,16 +211,22 @@ func (s *Server) ModifyJob(ctx context.Context, in *Job) (*Job, error) {
 
 func (s *Server) PullPendingJobs(ctx context.Context, in *ListJobsRequest) (*ListOfJobs, error) {
 	user := getAuthUserFromContext(ctx)
-	// if worker - Can pull jobs with proper kind
-	if user.IsWorker() {
-		in.Kind = user.KindAccess
+
+	if in.Kind == "" {
+		in.Kind = user.defaultKind()
+	}
+	if in.Project == "" && !user.Can(PermPullJobs, AnyScope, orAnyScope(in.Kind)) {
+		project, err := user.onlyProject(PermPullJobs)
+		if err != nil {
+			return nil, err
+		}
+		in.Project = project
 	}
-	// if user - Can pull jobs from their project
-	if user.IsUser() {
-		in.Project = user.ProjectAccess
+	if !user.Can(PermPullJobs, orAnyScope(in.Project), orAnyScope(in.Kind)) {
+		return nil, errNoAccess
 	}
 
-	pts, err := s.Storage.PullJobs(in.HowMany, in.Project, in.Kind)
+	pts, err := s.Storage.PullJobs(ctx, in.HowMany, in.Project, in.Kind)
 
 	if err != nil {
 		return nil, detailedInternalError(err)
@@ -107,13 +237,16 @@ func (s *Server) PullPendingJobs(ctx context.Context, in *ListJobsRequest) (*Lis
 
 func (s *Server) DeleteJob(ctx context.Context, in *RequestWithId) (*Job, error) {
 	user := getAuthUserFromContext(ctx)
-	// if worker - Cannot delete jobs
-	if user.IsWorker() {
-		return nil, grpc.Errorf(codes.PermissionDenied, "Workers cannot delete jobs")
+
+	job, err := s.authorizeJob(ctx, user, PermDeleteJobs, in.Id)
+	if err != nil {
+		return nil, err
+	}
+	if job.DeletedAt != 0 {
+		return nil, errJobDeleted(job)
 	}
-	// if user - Can delete jobs in their project
-	ret, err := s.Storage.DeleteJob(in.Id, user.ProjectAccess)
 
+	ret, err := s.Storage.DeleteJob(ctx, in.Id, job.Project)
 	if err != nil {
 		return nil, detailedInternalError(err)
 	}
@@ -123,13 +256,506 @@ func (s *Server) DeleteJob(ctx context.Context, in *RequestWithId) (*Job, error)
 
 func (s *Server) KillJob(ctx context.Context, in *RequestWithId) (*Job, error) {
 	user := getAuthUserFromContext(ctx)
-	// if worker - Cannot kill jobs
-	if user.IsWorker() {
-		return nil, grpc.Errorf(codes.PermissionDenied, "Workers cannot kill jobs")
+
+	job, err := s.authorizeJob(ctx, user, PermKillJobs, in.Id)
+	if err != nil {
+		return nil, err
+	}
+	if job.DeletedAt != 0 {
+		return nil, errJobDeleted(job)
+	}
+
+	ret, err := s.Storage.KillJob(ctx, in.Id, job.Project)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+// RescheduleJob moves the time a pending job may be pulled. Whoever may
+// submit a job may decide when it runs.
+func (s *Server) RescheduleJob(ctx context.Context, in *RescheduleJobRequest) (*Job, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if in.RunAfter < 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "run_after must not be negative")
+	}
+	job, err := s.authorizeJob(ctx, user, PermCreateJobs, in.Id)
+	if err != nil {
+		return nil, err
+	}
+	if job.DeletedAt != 0 {
+		return nil, errJobDeleted(job)
+	}
+	if job.Status != Job_PENDING {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "Job %d is %s, only pending jobs can be rescheduled", job.Id, job.Status)
+	}
+
+	ret, err := s.Storage.RescheduleJob(ctx, in.Id, in.RunAfter)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+// UndeleteJob restores a job from the trash. Whoever may delete a job may
+// also undelete it.
+func (s *Server) UndeleteJob(ctx context.Context, in *RequestWithId) (*Job, error) {
+	user := getAuthUserFromContext(ctx)
+
+	job, err := s.authorizeJob(ctx, user, PermDeleteJobs, in.Id)
+	if err != nil {
+		return nil, err
+	}
+	if job.DeletedAt == 0 {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "Job %d is not deleted", job.Id)
+	}
+
+	ret, err := s.Storage.UndeleteJob(ctx, in.Id, job.Project)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) CreateRoleBinding(ctx context.Context, in *RoleBinding) (*RoleBinding, error) {
+	user := getAuthUserFromContext(ctx)
+
+	err := validateRoleBinding(in)
+	if err != nil {
+		return nil, err
+	}
+	if !user.Can(PermManageBindings, in.Project, in.Kind) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Storage.CreateRoleBinding(ctx, in, user)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+	s.invalidateRoleBindings(ret.Principal)
+
+	return ret, nil
+}
+
+func (s *Server) ListRoleBindings(ctx context.Context, in *ListRoleBindingsRequest) (*ListOfRoleBindings, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if in.Project == "" && !user.Can(PermManageBindings, AnyScope, AnyScope) {
+		project, err := user.onlyProject(PermManageBindings)
+		if err != nil {
+			return nil, err
+		}
+		in.Project = project
+	}
+	if !user.Can(PermManageBindings, orAnyScope(in.Project), AnyScope) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Storage.ListRoleBindings(ctx, in.Principal, in.Project)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+// invalidateRoleBindings drops the cached bindings of principal, if they are
+// cached.
+func (s *Server) invalidateRoleBindings(principal string) {
+	if cache, ok := s.RoleBindings.(*RoleBindingCache); ok {
+		cache.Invalidate(principal)
+	}
+}
+
+func (s *Server) DeleteRoleBinding(ctx context.Context, in *RequestWithId) (*RoleBinding, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if !user.MayEver(PermManageBindings) {
+		return nil, errNoAccess
+	}
+	binding, err := s.Storage.GetRoleBinding(ctx, in.Id)
+	if err == sql.ErrNoRows {
+		return nil, grpc.Errorf(codes.NotFound, "Role binding %d does not exist", in.Id)
+	}
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+	if !user.Can(PermManageBindings, binding.Project, binding.Kind) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Storage.DeleteRoleBinding(ctx, in.Id)
+	if err == sql.ErrNoRows {
+		return nil, grpc.Errorf(codes.NotFound, "Role binding %d does not exist", in.Id)
+	}
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+	s.invalidateRoleBindings(ret.Principal)
+
+	return ret, nil
+}
+
+func (s *Server) IssueToken(ctx context.Context, in *IssueTokenRequest) (*Token, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if s.Tokens == nil {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "Token authentication is not enabled")
+	}
+	if in.Subject == "" || len(in.Grants) == 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "Subject and grants are required")
+	}
+	// only those who may grant a role may put it in a token
+	for _, field := range in.Grants {
+		projectAccess, kindAccess, err := parseCertificateFields(field)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "Invalid grant %q", field)
+		}
+		if !user.Can(PermManageBindings, projectAccess, kindAccess) {
+			return nil, errNoAccess
+		}
+	}
+
+	token, expiresAt, err := s.Tokens.Issue(in.Subject, in.Grants, time.Duration(in.TtlSeconds)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return &Token{Token: token, ExpiresAt: expiresAt.Unix()}, nil
+}
+
+func (s *Server) RevokeCertificate(ctx context.Context, in *RevokedCertificate) (*RevokedCertificate, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if !user.Can(PermManageCertificates, AnyScope, AnyScope) {
+		return nil, errNoAccess
+	}
+	serial, err := normalizeSerial(in.Serial)
+	if err != nil {
+		return nil, err
+	}
+	in.Serial = serial
+	in.RevokedBy = user.Username
+
+	ret, err := s.Storage.RevokeCertificate(ctx, in)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+	if s.Revocations != nil {
+		s.Revocations.set(ret.Serial, true)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) ListRevokedCertificates(ctx context.Context, in *ListRevokedCertificatesRequest) (*ListOfRevokedCertificates, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if !user.Can(PermManageCertificates, AnyScope, AnyScope) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Storage.ListRevokedCertificates(ctx)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) UnrevokeCertificate(ctx context.Context, in *RequestWithSerial) (*RevokedCertificate, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if !user.Can(PermManageCertificates, AnyScope, AnyScope) {
+		return nil, errNoAccess
+	}
+	serial, err := normalizeSerial(in.Serial)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := s.Storage.UnrevokeCertificate(ctx, serial)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+	if s.Revocations != nil {
+		s.Revocations.set(ret.Serial, false)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) IssueCertificate(ctx context.Context, in *IssueCertificateRequest) (*IssuedCertificate, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if s.CA == nil {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "Certificate authority is not configured")
+	}
+	csr, err := ParseCSR(in.Csr)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := ParseGrants(in.Grants)
+	if err != nil {
+		return nil, err
+	}
+	// only those who may grant a role may put it in a certificate
+	for _, grant := range grants {
+		if !user.Can(PermManageBindings, grant.Project, grant.Kind) {
+			return nil, errNoAccess
+		}
+	}
+	// the certificate also carries the bindings stored for its common name,
+	// which the caller must be able to grant as well
+	if s.RoleBindings != nil && !user.Can(PermManageCertificates, AnyScope, AnyScope) {
+		stored, err := loadRoleBindings(ctx, s.RoleBindings, csr.Subject.CommonName)
+		if err != nil {
+			return nil, err
+		}
+		for _, binding := range stored {
+			if !user.Can(PermManageBindings, binding.Project, binding.Kind) {
+				return nil, errNoAccess
+			}
+		}
+	}
+
+	cert, err := s.CA.Sign(csr, grants, time.Duration(in.LifetimeSeconds)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	ret := IssuedCertificateFrom(cert, user.Username)
+	err = s.Storage.RecordIssuedCertificate(ctx, ret, "")
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) RenewCertificate(ctx context.Context, in *RenewCertificateRequest) (*IssuedCertificate, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if s.CA == nil {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "Certificate authority is not configured")
+	}
+	// renewal is authenticated by the certificate being renewed
+	if user.Certificate == nil {
+		return nil, grpc.Errorf(codes.PermissionDenied, "Certificates can only be renewed with a client certificate")
+	}
+	csr, err := ParseCSR(in.Csr)
+	if err != nil {
+		return nil, err
+	}
+	if csr.Subject.CommonName != user.Username {
+		return nil, grpc.Errorf(codes.InvalidArgument, "CSR common name must be %s", user.Username)
+	}
+	fields := []string{}
+	for _, grant := range user.Grants {
+		fields = append(fields, grant.String())
+	}
+	grants, err := ParseGrants(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := s.CA.Sign(csr, grants, 0)
+	if err != nil {
+		return nil, err
+	}
+	ret := IssuedCertificateFrom(cert, user.Username)
+	err = s.Storage.RecordIssuedCertificate(ctx, ret, serialString(user.Certificate))
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest) (*ListOfAuditEvents, error) {
+	user := getAuthUserFromContext(ctx)
+
+	// events without a project, such as revocations, are only listed for
+	// all projects
+	if in.Project == "" && !user.Can(PermReadAudit, AnyScope, AnyScope) {
+		project, err := user.onlyProject(PermReadAudit)
+		if err != nil {
+			return nil, err
+		}
+		in.Project = project
+	}
+	if !user.Can(PermReadAudit, orAnyScope(in.Project), AnyScope) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Storage.ListAuditEvents(ctx, in)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) SummarizeFailures(ctx context.Context, in *SummarizeFailuresRequest) (*FailureSummary, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if in.Project == "" && !user.Can(PermListJobs, AnyScope, orAnyScope(in.Kind)) {
+		project, err := user.onlyProject(PermListJobs)
+		if err != nil {
+			return nil, err
+		}
+		in.Project = project
+	}
+	if !user.Can(PermListJobs, orAnyScope(in.Project), orAnyScope(in.Kind)) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Storage.SummarizeFailures(ctx, in)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) RestoreArchivedJob(ctx context.Context, in *RequestWithId) (*Job, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if s.Archiver == nil {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "Archiving is not configured")
+	}
+	// restoring is the counterpart of deleting
+	if !user.MayEver(PermDeleteJobs) {
+		return nil, errNoAccess
+	}
+	entry, err := s.Storage.GetArchiveEntry(ctx, in.Id)
+	if err == sql.ErrNoRows {
+		return nil, grpc.Errorf(codes.NotFound, "Job %d is not archived", in.Id)
+	}
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+	if !user.Can(PermDeleteJobs, entry.Project, entry.Kind) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Archiver.Restore(ctx, entry)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+// authorizeSchedule reads the schedule with the given id and checks that user
+// may create the jobs of its template.
+func (s *Server) authorizeSchedule(ctx context.Context, user User, id uint64) (*Schedule, error) {
+	if !user.MayEver(PermCreateJobs) {
+		return nil, errNoAccess
+	}
+
+	schedule, err := s.Storage.GetSchedule(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, grpc.Errorf(codes.NotFound, "Schedule %d does not exist", id)
+	}
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+	if !user.Can(PermCreateJobs, schedule.Template.Project, schedule.Template.Kind) {
+		return nil, errNoAccess
+	}
+	return schedule, nil
+}
+
+func (s *Server) CreateSchedule(ctx context.Context, in *Schedule) (*Schedule, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if !user.MayEver(PermCreateJobs) {
+		return nil, errNoAccess
+	}
+	err := validateSchedule(in, s.Storage.now())
+	if err != nil {
+		return nil, err
+	}
+	if in.Template.Project == "" {
+		project, err := user.onlyProject(PermCreateJobs)
+		if err != nil {
+			return nil, err
+		}
+		in.Template.Project = project
+	}
+	// the scheduler creates jobs on behalf of the creator of the schedule
+	if !user.Can(PermCreateJobs, in.Template.Project, in.Template.Kind) {
+		return nil, errNoAccess
+	}
+	err = s.validateJobDocuments(ctx, in.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := s.Storage.CreateSchedule(ctx, in, user)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) ListSchedules(ctx context.Context, in *ListSchedulesRequest) (*ListOfSchedules, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if in.Project == "" && !user.Can(PermListJobs, AnyScope, AnyScope) {
+		project, err := user.onlyProject(PermListJobs)
+		if err != nil {
+			return nil, err
+		}
+		in.Project = project
+	}
+	if !user.Can(PermListJobs, orAnyScope(in.Project), AnyScope) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Storage.ListSchedules(ctx, in.Project)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) PauseSchedule(ctx context.Context, in *PauseScheduleRequest) (*Schedule, error) {
+	user := getAuthUserFromContext(ctx)
+
+	_, err := s.authorizeSchedule(ctx, user, in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := s.Storage.PauseSchedule(ctx, in.Id, in.Paused)
+	if err == sql.ErrNoRows {
+		return nil, grpc.Errorf(codes.NotFound, "Schedule %d does not exist", in.Id)
+	}
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) DeleteSchedule(ctx context.Context, in *RequestWithId) (*Schedule, error) {
+	user := getAuthUserFromContext(ctx)
+
+	_, err := s.authorizeSchedule(ctx, user, in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := s.Storage.DeleteSchedule(ctx, in.Id)
+	if err == sql.ErrNoRows {
+		return nil, grpc.Errorf(codes.NotFound, "Schedule %d does not exist", in.Id)
 	}
-	// if user - Can kill jobs in their project
-	ret, err := s.Storage.KillJob(in.Id, user.ProjectAccess)
-	
 	if err != nil {
 		return nil, detailedInternalError(err)
 	}
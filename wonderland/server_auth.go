@@ -1,11 +1,17 @@
 package wonderland
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"go.opentelemetry.io/otel"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
+	"net/url"
 	"strings"
 )
 
@@ -17,6 +23,15 @@ func getAuthUserFromContext(ctx context.Context) User {
 	return User{}
 }
 
+// GrantsExtensionOID identifies an optional certificate extension holding
+// grants as an ASN.1 SEQUENCE OF UTF8String, each in the "project.kind"
+// format.
+var GrantsExtensionOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 50263, 1, 1}
+
+// grantURIScheme is the scheme of SAN URIs carrying grants, in the form
+// wonderland://project/<project>/kind/<kind>.
+const grantURIScheme = "wonderland"
+
 func parseCertificateFields(field string) (projectAccess string, kindAccess string, err error) {
 	fieldCopy := strings.Split(field, ".")
 	if len(fieldCopy) != 2 {
@@ -25,36 +40,201 @@ func parseCertificateFields(field string) (projectAccess string, kindAccess stri
 	return fieldCopy[0], fieldCopy[1], nil
 }
 
-func (s *Server) AuthFuncOverride(ctx context.Context, fullMethodName string) (context.Context, error) {
+func parseGrantURI(uri *url.URL) (Grant, error) {
+	parts := strings.Split(strings.TrimPrefix(uri.Path, "/"), "/")
+	if uri.Host != "project" || len(parts) != 3 || parts[0] == "" || parts[1] != "kind" || parts[2] == "" {
+		return Grant{}, grpc.Errorf(codes.DataLoss, "Error processing grant URI %s", uri)
+	}
+	return Grant{Project: parts[0], Kind: parts[2]}, nil
+}
+
+// grantsFromCertificate collects the grants of every Organization entry, SAN
+// URI with the wonderland scheme and grants extension of cert.
+func grantsFromCertificate(cert *x509.Certificate) ([]Grant, error) {
+	grants := []Grant{}
+	for _, org := range cert.Subject.Organization {
+		projectAccess, kindAccess, err := parseCertificateFields(org)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, Grant{Project: projectAccess, Kind: kindAccess})
+	}
+
+	for _, uri := range cert.URIs {
+		if uri.Scheme != grantURIScheme {
+			continue
+		}
+		grant, err := parseGrantURI(uri)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, grant)
+	}
+
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(GrantsExtensionOID) {
+			continue
+		}
+		var fields []string
+		_, err := asn1.Unmarshal(ext.Value, &fields)
+		if err != nil {
+			return nil, grpc.Errorf(codes.DataLoss, "Error processing grants extension: %v", err)
+		}
+		for _, field := range fields {
+			projectAccess, kindAccess, err := parseCertificateFields(field)
+			if err != nil {
+				return nil, err
+			}
+			grants = append(grants, Grant{Project: projectAccess, Kind: kindAccess})
+		}
+	}
+
+	if len(grants) == 0 {
+		return nil, grpc.Errorf(codes.DataLoss, "Certificate grants no access")
+	}
+	return grants, nil
+}
+
+func userFromCertificate(cert *x509.Certificate) (User, error) {
+	grants, err := grantsFromCertificate(cert)
+	if err != nil {
+		return User{}, err
+	}
+
+	user := User{
+		Username:    cert.Subject.CommonName,
+		Grants:      grants,
+		Certificate: cert,
+	}
+	for _, grant := range grants {
+		user.Bindings = append(user.Bindings, certificateBinding(user.Username, grant.Project, grant.Kind))
+	}
+	return user, nil
+}
+
+// AuthenticatePeer is the auth function for services other than Wonderland,
+// such as health checks and reflection. These do not expose jobs, but are
+// still only open to clients with a verified certificate or a valid token:
+// with token authentication enabled the TLS handshake accepts clients
+// without a certificate.
+func (s *Server) AuthenticatePeer(ctx context.Context) (context.Context, error) {
+	if token, err := grpc_auth.AuthFromMD(ctx, "bearer"); err == nil {
+		if _, err := s.authenticateToken(token); err == nil {
+			return ctx, nil
+		}
+	}
+	cert := peerCertificate(ctx)
+	if cert == nil {
+		return nil, grpc.Errorf(codes.Unauthenticated, "A client certificate or token is required")
+	}
+	if s.Revocations != nil && s.Revocations.IsRevoked(serialString(cert)) {
+		return nil, grpc.Errorf(codes.Unauthenticated, "Certificate %s is revoked", serialString(cert))
+	}
+	return ctx, nil
+}
+
+// peerCertificate returns the client certificate verified in the TLS
+// handshake, nil if the client did not present one.
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil
+	}
+	return verifiedCertificate(&tlsInfo.State)
+}
+
+// verifiedCertificate returns the client certificate of a TLS connection if
+// the handshake verified it, nil otherwise.
+func verifiedCertificate(state *tls.ConnectionState) *x509.Certificate {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.PeerCertificates) == 0 {
+		return nil
+	}
+	return state.PeerCertificates[0]
+}
+
+// authenticateRequest returns the user of a gRPC call or gateway request
+// with the given bearer token, if any, and verified client certificate, if
+// any.
+func (s *Server) authenticateRequest(ctx context.Context, token string, cert *x509.Certificate) (User, error) {
+	if token != "" {
+		user, err := s.authenticateToken(token)
+		if err == nil {
+			return user, nil
+		}
+		// a client with a verified certificate is not locked out by a bad
+		// or disabled token
+		if cert == nil {
+			return User{}, err
+		}
+	}
+
+	if cert == nil {
+		return User{}, grpc.Errorf(codes.Unauthenticated, "Error processing client certificate")
+	}
+	return s.authenticate(ctx, cert)
+}
+
+func (s *Server) AuthFuncOverride(ctx context.Context, fullMethodName string) (_ context.Context, err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "auth")
+	defer func() { endSpan(span, err) }()
+
 	allowedEndpoints := map[string]bool{}
 	if allow, ok := allowedEndpoints[fullMethodName]; allow && ok {
 		return ctx, nil
 	}
+	ctx = withRequestInfo(ctx, fullMethodName, peerAddress(ctx))
 
-	peer, ok := peer.FromContext(ctx)
-	if !ok {
-		return nil, grpc.Errorf(codes.Unauthenticated, "Error processing client certificate")
+	// without an authorization header there is no token
+	token, _ := grpc_auth.AuthFromMD(ctx, "bearer")
+	user, err := s.authenticateRequest(ctx, token, peerCertificate(ctx))
+	if err != nil {
+		return nil, err
 	}
+	return context.WithValue(ctx, "authorized-user", user), nil
+}
 
-	tlsInfo := peer.AuthInfo.(credentials.TLSInfo)
-	if len(tlsInfo.State.PeerCertificates) < 1 {
-		return nil, grpc.Errorf(codes.Unauthenticated, "Error processing client certificate")
+func peerAddress(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
 	}
+	return ""
+}
 
-	cert := tlsInfo.State.PeerCertificates[0]
-	if !ok {
-		return nil, grpc.Errorf(codes.Unauthenticated, "Error processing client certificate")
+// authenticateToken returns the user a bearer token was issued for.
+func (s *Server) authenticateToken(token string) (User, error) {
+	if s.Tokens == nil {
+		return User{}, grpc.Errorf(codes.Unauthenticated, "Token authentication is not enabled")
+	}
+	claims, err := s.Tokens.Verify(token)
+	if err != nil {
+		return User{}, grpc.Errorf(codes.Unauthenticated, "Invalid token: %v", err)
+	}
+	return userFromToken(claims)
+}
+
+// authenticate returns the user identified by cert along with the role
+// bindings stored for them.
+func (s *Server) authenticate(ctx context.Context, cert *x509.Certificate) (User, error) {
+	if s.Revocations != nil && s.Revocations.IsRevoked(serialString(cert)) {
+		return User{}, grpc.Errorf(codes.Unauthenticated, "Certificate %s is revoked", serialString(cert))
 	}
 
-	projectAccess, kindAccess, err := parseCertificateFields(cert.Subject.Organization[0])
+	user, err := userFromCertificate(cert)
 	if err != nil {
-		return nil, err
+		return User{}, err
+	}
+	if s.RoleBindings == nil {
+		return user, nil
 	}
 
-	user := User{
-		Username:      cert.Subject.CommonName,
-		KindAccess:    kindAccess,
-		ProjectAccess: projectAccess,
+	stored, err := loadRoleBindings(ctx, s.RoleBindings, user.Username)
+	if err != nil {
+		return User{}, err
 	}
-	return context.WithValue(ctx, "authorized-user", user), nil
+	user.Bindings = append(user.Bindings, stored...)
+	return user, nil
 }
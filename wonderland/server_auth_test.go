@@ -0,0 +1,157 @@
+package wonderland
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestGrantsFromCertificate(t *testing.T) {
+	uri, err := url.Parse("wonderland://project/lhcb/kind/docker")
+	checkTestErr(err, t)
+	other, err := url.Parse("spiffe://example.org/alice")
+	checkTestErr(err, t)
+	ext, err := asn1.Marshal([]string{"ship.ANY"})
+	checkTestErr(err, t)
+
+	cert := &x509.Certificate{
+		Subject:    pkix.Name{CommonName: "alice", Organization: []string{"ship-shield.ANY", "opera.ANY"}},
+		URIs:       []*url.URL{other, uri},
+		Extensions: []pkix.Extension{{Id: GrantsExtensionOID, Value: ext}},
+	}
+	grants, err := grantsFromCertificate(cert)
+	checkTestErr(err, t)
+
+	expected := []Grant{
+		{Project: "ship-shield", Kind: "ANY"},
+		{Project: "opera", Kind: "ANY"},
+		{Project: "lhcb", Kind: "docker"},
+		{Project: "ship", Kind: "ANY"},
+	}
+	if !reflect.DeepEqual(grants, expected) {
+		t.Errorf("got %v, expected %v", grants, expected)
+	}
+}
+
+func TestGrantsFromCertificateErrors(t *testing.T) {
+	badURI, err := url.Parse("wonderland://project/lhcb")
+	checkTestErr(err, t)
+
+	certs := []*x509.Certificate{
+		{Subject: pkix.Name{CommonName: "nobody"}},
+		{Subject: pkix.Name{Organization: []string{"ship-shield.ANY", "no-dot"}}},
+		{URIs: []*url.URL{badURI}},
+	}
+	for _, cert := range certs {
+		_, err := grantsFromCertificate(cert)
+		if status.Code(err) != codes.DataLoss {
+			t.Errorf("expected DataLoss, got %v", err)
+		}
+	}
+}
+
+func TestOnlyProject(t *testing.T) {
+	single, err := userFromCertificate(&x509.Certificate{Subject: pkix.Name{Organization: []string{"ship-shield.ANY"}}})
+	checkTestErr(err, t)
+	project, err := single.onlyProject(PermCreateJobs)
+	checkTestErr(err, t)
+	if project != "ship-shield" {
+		t.Fail()
+	}
+
+	several, err := userFromCertificate(&x509.Certificate{Subject: pkix.Name{Organization: []string{"ship-shield.ANY", "opera.ANY"}}})
+	checkTestErr(err, t)
+	_, err = several.onlyProject(PermCreateJobs)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fail()
+	}
+	if !several.Can(PermCreateJobs, "opera", "docker") || several.Can(PermCreateJobs, "lhcb", "docker") {
+		t.Fail()
+	}
+
+	worker, err := userFromCertificate(&x509.Certificate{Subject: pkix.Name{Organization: []string{"ANY.docker"}}})
+	checkTestErr(err, t)
+	_, err = worker.onlyProject(PermCreateJobs)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fail()
+	}
+	if worker.defaultKind() != "docker" {
+		t.Fail()
+	}
+}
+
+// peerContext returns the context of a request from a client which presented
+// cert, if any, and the bearer token, if any.
+func peerContext(cert *x509.Certificate, token string) context.Context {
+	state := tls.ConnectionState{}
+	if cert != nil {
+		state.PeerCertificates = []*x509.Certificate{cert}
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4242},
+		AuthInfo: credentials.TLSInfo{State: state},
+	})
+	if token != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "bearer "+token))
+	}
+	return ctx
+}
+
+func TestAuthenticatePeer(t *testing.T) {
+	auth, err := NewTokenAuth(testSecretKey, TokenConfig{})
+	checkTestErr(err, t)
+	s := &Server{Tokens: auth, Revocations: NewRevocationList()}
+	cert := &x509.Certificate{SerialNumber: big.NewInt(42), Subject: pkix.Name{CommonName: "alice", Organization: []string{"ship-shield.ANY"}}}
+	token, _, err := auth.Issue("ci", []string{"ship-shield.ANY"}, time.Minute)
+	checkTestErr(err, t)
+
+	for name, ctx := range map[string]context.Context{"certificate": peerContext(cert, ""), "token": peerContext(nil, token)} {
+		_, err = s.AuthenticatePeer(ctx)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	for name, ctx := range map[string]context.Context{"anonymous": peerContext(nil, ""), "bad token": peerContext(nil, "not-a-token")} {
+		_, err = s.AuthenticatePeer(ctx)
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	s.Revocations.set(serialString(cert), true)
+	_, err = s.AuthenticatePeer(peerContext(cert, ""))
+	if status.Code(err) != codes.Unauthenticated {
+		t.Error("revoked certificate accepted")
+	}
+}
+
+func TestAuthFallsBackToCertificate(t *testing.T) {
+	cert := &x509.Certificate{SerialNumber: big.NewInt(42), Subject: pkix.Name{CommonName: "alice", Organization: []string{"ship-shield.ANY"}}}
+
+	// token authentication is disabled
+	s := &Server{}
+	ctx, err := s.AuthFuncOverride(peerContext(cert, "stale-token"), "/Wonderland/ListJobs")
+	checkTestErr(err, t)
+	if err == nil && getAuthUserFromContext(ctx).Username != "alice" {
+		t.Fail()
+	}
+
+	_, err = s.AuthFuncOverride(peerContext(nil, "stale-token"), "/Wonderland/ListJobs")
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fail()
+	}
+}
@@ -1,35 +1,46 @@
 package wonderland
 
 import (
+	"bytes"
 	"database/sql"
-	_ "github.com/lib/pq"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/lib/pq"
+	"golang.org/x/net/context"
 	"strconv"
+	"strings"
 	"time"
 )
 
+const jobColumns = `id, project, status, metadata, input, output, kind, trace_parent,
+	COALESCE(EXTRACT(EPOCH FROM deleted_at)::bigint, 0), COALESCE(EXTRACT(EPOCH FROM run_after)::bigint, 0),
+	max_runtime, COALESCE(EXTRACT(EPOCH FROM deadline)::bigint, 0), failure_reason, error, progress, kind_version,
+	template_id, template_version, array_id, array_index`
+
 const PULLINGSTRQ_1 = `
-	WITH updatedPts AS (
-		WITH pulledPts AS (
-			SELECT id, project, kind
-			FROM jobs
-			WHERE status=$1
+	SELECT ` + jobColumns + `
+	FROM jobs
+	WHERE status=$1 AND deleted_at IS NULL AND (run_after IS NULL OR run_after <= $2)
+		AND (deadline IS NULL OR deadline > $2)
 `
 const PULLINGSTRQ_2 = `
-			FOR UPDATE SKIP LOCKED
-		)
-		UPDATE jobs pts
-		SET status=$2, last_modified=$3
-		FROM pulledPts
-		WHERE pulledPts.id=pts.id AND pulledPts.project=pts.project AND pulledPts.kind=pts.kind
-		RETURNING pts.id, pts.project, pts.status, pts.metadata, pts.input, pts.output, pts.kind
+	FOR UPDATE SKIP LOCKED;`
+const PULLINGSTRQ_3 = `
+	WITH updatedPts AS (
+		UPDATE jobs
+		SET status=$1, last_modified=$2, pulled_at=$2
+		WHERE id = ANY($3)
+		RETURNING ` + jobColumns + `
 	)
 	SELECT *
 	FROM updatedPts
 	ORDER BY id DESC;`
 const LISTSTRQ_1 = `
-	SELECT id, project, status, metadata, input, output, kind
+	SELECT ` + jobColumns + `
 	FROM jobs
-	WHERE
+	WHERE true
 `
 
 type WonderlandStorageConfig struct {
@@ -39,6 +50,21 @@ type WonderlandStorageConfig struct {
 type WonderlandStorage struct {
 	db     *sql.DB
 	Config WonderlandStorageConfig
+
+	// AuditSink receives every committed audit event, if set.
+	AuditSink *AuditFileSink
+
+	// IdempotencyKeyTTL is how long CreateJob idempotency keys are
+	// remembered, a day if zero.
+	IdempotencyKeyTTL time.Duration
+
+	// MaxJobLogSize is how many bytes of the newest logs are kept per job,
+	// 10 MiB if zero.
+	MaxJobLogSize int64
+
+	// Clock returns the current time, time.Now if nil. Tests replace it to
+	// control when scheduled jobs become due.
+	Clock func() time.Time
 }
 
 func NewWonderlandStorage(dbUri string) (*WonderlandStorage, error) {
@@ -57,6 +83,54 @@ func (storage *WonderlandStorage) Connect() error {
 	return err
 }
 
+// Ping checks that the database is reachable.
+func (storage *WonderlandStorage) Ping(ctx context.Context) error {
+	return storage.db.PingContext(ctx)
+}
+
+// Close closes the database connections. It should be called once no
+// requests are being served anymore.
+func (storage *WonderlandStorage) Close() error {
+	return storage.db.Close()
+}
+
+// jobFields returns pointers to the fields of job in the order of jobColumns.
+func jobFields(job *Job) []interface{} {
+	return []interface{}{
+		&job.Id,
+		&job.Project,
+		&job.Status,
+		&job.Metadata,
+		&job.Input,
+		&job.Output,
+		&job.Kind,
+		&job.TraceParent,
+		&job.DeletedAt,
+		&job.RunAfter,
+		&job.MaxRuntime,
+		&job.Deadline,
+		&job.FailureReason,
+		jobErrorColumn{&job.Error},
+		jobProgressColumn{&job.Progress},
+		&job.KindVersion,
+		&job.TemplateId,
+		&job.TemplateVersion,
+		&job.ArrayId,
+		&job.ArrayIndex,
+	}
+}
+
+// scanJSONB unmarshals a JSONB column value into msg.
+func scanJSONB(src interface{}, msg proto.Message) error {
+	switch v := src.(type) {
+	case []byte:
+		return jsonpb.Unmarshal(bytes.NewReader(v), msg)
+	case string:
+		return jsonpb.UnmarshalString(v, msg)
+	}
+	return fmt.Errorf("cannot scan %T into %T", src, msg)
+}
+
 func queryJobs(rows *sql.Rows) (*ListOfJobs, error) {
 	ret := &ListOfJobs{Jobs: []*Job{}}
 	var err error
@@ -64,15 +138,7 @@ func queryJobs(rows *sql.Rows) (*ListOfJobs, error) {
 	for rows.Next() {
 		job := &Job{}
 
-		err := rows.Scan(
-			&job.Id,
-			&job.Project,
-			&job.Status,
-			&job.Metadata,
-			&job.Input,
-			&job.Output,
-			&job.Kind,
-		)
+		err := rows.Scan(jobFields(job)...)
 
 		if err != nil {
 			return nil, err
@@ -82,132 +148,175 @@ func queryJobs(rows *sql.Rows) (*ListOfJobs, error) {
 	return ret, err
 }
 
-func getTime() time.Time {
+// lockJob reads the job with the given id in tx and locks it until tx ends.
+func lockJob(ctx context.Context, tx *sql.Tx, id uint64) (*Job, error) {
+	job := &Job{}
+	err := tx.QueryRowContext(ctx, `
+		SELECT `+jobColumns+`
+		FROM jobs
+		WHERE id=$1
+		FOR UPDATE;`, id,
+	).Scan(jobFields(job)...)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// now returns the current time in UTC as told by Clock.
+func (storage *WonderlandStorage) now() time.Time {
+	if storage.Clock != nil {
+		return storage.Clock().UTC()
+	}
 	return time.Now().UTC()
 }
 
-func (storage *WonderlandStorage) CreateJob(job *Job, creator User) (*Job, error) {
-	tx, err := storage.db.Begin()
+// timeOrNull converts seconds since the epoch to a timestamp, with 0 meaning
+// NULL.
+func timeOrNull(seconds int64) interface{} {
+	if seconds == 0 {
+		return nil
+	}
+	return time.Unix(seconds, 0).UTC()
+}
+
+// commit commits tx, rolling it back if that fails.
+func commit(ctx context.Context, tx *sql.Tx) error {
+	_, span := startStorageSpan(ctx, "Commit")
+	err := tx.Commit()
 	if err != nil {
-		return nil, err
+		tx.Rollback()
 	}
+	endSpan(span, err)
+	return err
+}
 
+// insertJob creates job in tx on behalf of creator.
+func insertJob(ctx context.Context, tx *sql.Tx, job *Job, creator string) (*Job, error) {
 	createdJob := &Job{}
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO jobs (project, status, metadata, creator, input, output, kind, trace_parent, run_after, max_runtime, deadline,
+			kind_version, template_id, template_version, array_id, array_index)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		RETURNING `+jobColumns+`;`,
+		job.Project, job.Status, job.Metadata, creator, job.Input, job.Output, job.Kind, traceParent(ctx),
+		timeOrNull(job.RunAfter), job.MaxRuntime, timeOrNull(job.Deadline), job.KindVersion, job.TemplateId,
+		job.TemplateVersion, job.ArrayId, job.ArrayIndex,
+	).Scan(jobFields(createdJob)...)
+	if err != nil {
+		return nil, err
+	}
+	return createdJob, nil
+}
 
-	err = tx.QueryRow(`
-		INSERT INTO jobs (project, status, metadata, creator, input, output, kind)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, project, status, metadata, input, output, kind;`,
-		job.Project, job.Status, job.Metadata, creator.Username, job.Input, job.Output, job.Kind,
-	).Scan(
-		&createdJob.Id,
-		&createdJob.Project,
-		&createdJob.Status,
-		&createdJob.Metadata,
-		&createdJob.Input,
-		&createdJob.Output,
-		&createdJob.Kind,
-	)
+func (storage *WonderlandStorage) CreateJob(ctx context.Context, job *Job, creator User) (createdJob *Job, err error) {
+	ctx, span := startStorageSpan(ctx, "CreateJob")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	err = tx.Commit()
+	createdJob, err = insertJob(ctx, tx, job, creator.Username)
 	if err != nil {
 		tx.Rollback()
+		return nil, err
+	}
+	if job.IdempotencyKey != "" {
+		stored, err := storage.storeIdempotencyKey(ctx, tx, job, createdJob)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if !stored {
+			// a retry, or a different job reusing the key
+			tx.Rollback()
+			return storage.jobForIdempotencyKey(ctx, job)
+		}
+		createdJob.IdempotencyKey = job.IdempotencyKey
+	}
+	event, err := recordAuditEvent(ctx, tx, createdJob.Id, createdJob.Project, nil, createdJob)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
 	}
-	return createdJob, err
+	storage.exportAuditEvents(event)
+	return createdJob, nil
 }
 
-func (storage *WonderlandStorage) GetJob(id uint64) (*Job, error) {
-	tx, err := storage.db.Begin()
+func (storage *WonderlandStorage) GetJob(ctx context.Context, id uint64) (job *Job, err error) {
+	ctx, span := startStorageSpan(ctx, "GetJob")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	job := &Job{}
+	job = &Job{}
 
-	strQuery := `SELECT id, project, status,  metadata, input, output, kind
+	strQuery := `SELECT ` + jobColumns + `
 					FROM jobs
 					WHERE id=$1;`
-	err = tx.QueryRow(strQuery, id).Scan(
-		&job.Id,
-		&job.Project,
-		&job.Status,
-		&job.Metadata,
-		&job.Input,
-		&job.Output,
-		&job.Kind,
-	)
+	err = tx.QueryRowContext(ctx, strQuery, id).Scan(jobFields(job)...)
 
 	if err != nil {
+		tx.Rollback()
 		return nil, err
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		tx.Rollback()
-	}
+	err = commit(ctx, tx)
 	return job, err
 }
 
-func (storage *WonderlandStorage) ListJobs(howmany uint32, project string, kind string) (*ListOfJobs, error) {
-	var rows *sql.Rows
-	projectFlag := false
-	kindFlag := false
-	limitFlag := false
-	inc := 1
+// ListJobs returns the jobs matching the non-empty filters of in. Deleted
+// jobs are only included if asked for.
+func (storage *WonderlandStorage) ListJobs(ctx context.Context, in *ListJobsRequest) (ret *ListOfJobs, err error) {
+	ctx, span := startStorageSpan(ctx, "ListJobs")
+	defer func() { endSpan(span, err) }()
 
 	strQuery := LISTSTRQ_1
+	args := []interface{}{}
 
-	if project != "" {
-		strQuery += " project=$"
-		strQuery += strconv.Itoa(inc)
-		inc++
-		projectFlag = true
-	}
-	if kind != "" {
-		if projectFlag {
-			strQuery += " AND kind=$"
-		} else {
-			strQuery += " kind=$"
-		}
-		strQuery += strconv.Itoa(inc)
-		inc++
-		kindFlag = true
+	if !in.IncludeDeleted {
+		strQuery += " AND deleted_at IS NULL"
 	}
 
-	if howmany != 0 {
-		strQuery += " LIMIT $"
-		strQuery += strconv.Itoa(inc)
-		inc++
-		limitFlag = true
+	if in.Project != "" {
+		args = append(args, in.Project)
+		strQuery += " AND project=$" + strconv.Itoa(len(args))
 	}
-	strQuery += `;`
-
-	var err error
-	if projectFlag {
-		if kindFlag {
-			if limitFlag {
-				rows, err = storage.db.Query(strQuery, project, kind, howmany)
-			} else {
-				rows, err = storage.db.Query(strQuery, project, kind)
-			}
-		} else if limitFlag {
-			rows, err = storage.db.Query(strQuery, project, howmany)
-		} else {
-			rows, err = storage.db.Query(strQuery, project)
-		}
-	} else if limitFlag {
-		rows, err = storage.db.Query(strQuery, kind, howmany)
+	if in.Kind != "" {
+		args = append(args, in.Kind)
+		strQuery += " AND kind=$" + strconv.Itoa(len(args))
 	}
+	if in.ArrayId != 0 {
+		args = append(args, in.ArrayId)
+		strQuery += " AND array_id=$" + strconv.Itoa(len(args))
+	}
+	if in.Scheduled {
+		args = append(args, Job_PENDING, storage.now())
+		strQuery += " AND status=$" + strconv.Itoa(len(args)-1) + " AND run_after > $" + strconv.Itoa(len(args))
+	}
+	if in.HowMany != 0 {
+		args = append(args, in.HowMany)
+		strQuery += " LIMIT $" + strconv.Itoa(len(args))
+	}
+	strQuery += `;`
 
+	rows, err := storage.db.QueryContext(ctx, strQuery, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	ret, err := queryJobs(rows)
+	ret, err = queryJobs(rows)
 	if err != nil {
 		return nil, err
 	}
@@ -215,179 +324,970 @@ func (storage *WonderlandStorage) ListJobs(howmany uint32, project string, kind
 	return ret, err
 }
 
-func (storage *WonderlandStorage) UpdateJob(job *Job) (*Job, error) {
-	tx, err := storage.db.Begin()
+// UpdateJob stores the changes of a job. Jobs failed or expired by the server
+// are only changed when restart is set, which clears their failure reason.
+func (storage *WonderlandStorage) UpdateJob(ctx context.Context, job *Job, restart bool) (resultJob *Job, err error) {
+	ctx, span := startStorageSpan(ctx, "UpdateJob")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	curTime := getTime()
-	resultJob := &Job{}
+	before, err := lockJob(ctx, tx, job.Id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	// the job may have been deleted or stopped by the watchdog since it was
+	// authorized
+	if before.DeletedAt != 0 {
+		tx.Rollback()
+		return nil, errJobDeleted(before)
+	}
+	if before.FailureReason != "" && !restart {
+		tx.Rollback()
+		return nil, errJobStopped(before)
+	}
 
-	err = tx.QueryRow(`
+	jobError, err := jobErrorJSON(job.Error)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	curTime := storage.now()
+	resultJob = &Job{}
+
+	err = tx.QueryRowContext(ctx, `
 		UPDATE jobs
 		SET
 			status=$1,
 			metadata=$2,
 			output=$3,
-			last_modified=$4
-		WHERE id=$5
-		RETURNING id, project, status, metadata, input, output, kind;`,
+			last_modified=$4,
+			error=$6::jsonb,
+			progress=CASE WHEN $1 IN ($7, $8) THEN progress END,
+			failure_reason=''
+		WHERE id=$5 AND deleted_at IS NULL AND (failure_reason='' OR $9)
+		RETURNING `+jobColumns+`;`,
 		job.Status,
 		job.Metadata,
 		job.Output,
 		curTime,
 		job.Id,
-	).Scan(
-		&resultJob.Id,
-		&resultJob.Project,
-		&resultJob.Status,
-		&resultJob.Metadata,
-		&resultJob.Input,
-		&resultJob.Output,
-		&resultJob.Kind,
-	)
+		jobError,
+		Job_PULLED,
+		Job_RUNNING,
+		restart,
+	).Scan(jobFields(resultJob)...)
 	if err != nil {
+		tx.Rollback()
 		return resultJob, err
 	}
-	err = tx.Commit()
+	event, err := recordAuditEvent(ctx, tx, resultJob.Id, resultJob.Project, before, resultJob)
+	if err == nil {
+		err = storage.enqueueWebhookDeliveries(ctx, tx, before, resultJob)
+	}
 	if err != nil {
 		tx.Rollback()
 		return resultJob, err
 	}
+	err = commit(ctx, tx)
+	if err != nil {
+		return resultJob, err
+	}
+	storage.exportAuditEvents(event)
 
+	linkJobTrace(ctx, resultJob)
+	countFinishedJob(before, resultJob)
 	return resultJob, err
 }
 
-func (storage *WonderlandStorage) PullJobs(howmany uint32, project string, kind string) (*ListOfJobs, error) {
-	tx, err := storage.db.Begin()
+func (storage *WonderlandStorage) PullJobs(ctx context.Context, howmany uint32, project string, kind string) (ret *ListOfJobs, err error) {
+	start := time.Now()
+	defer func() {
+		pullLatency.Observe(time.Since(start).Seconds())
+	}()
+
+	ctx, span := startStorageSpan(ctx, "PullJobs")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var rows *sql.Rows
-	projectFlag := false
-	kindFlag := false
-	limitFlag := false
-	curTime := getTime()
-	inc := 4
+	curTime := storage.now()
+	args := []interface{}{Job_PENDING, curTime}
 
 	strQuery := PULLINGSTRQ_1
 	if project != "" {
-		strQuery += " AND project=$"
-		strQuery += strconv.Itoa(inc)
-		inc++
-		projectFlag = true
+		args = append(args, project)
+		strQuery += " AND project=$" + strconv.Itoa(len(args))
 	}
 	if kind != "" {
-		strQuery += " AND kind=$"
-		strQuery += strconv.Itoa(inc)
-		inc++
-		kindFlag = true
+		args = append(args, kind)
+		strQuery += " AND kind=$" + strconv.Itoa(len(args))
 	}
 	if howmany != 0 {
-		strQuery += " LIMIT $"
-		strQuery += strconv.Itoa(inc)
-		inc++
-		limitFlag = true
+		args = append(args, howmany)
+		strQuery += " LIMIT $" + strconv.Itoa(len(args))
 	}
 	strQuery += PULLINGSTRQ_2
 
-	if projectFlag {
-		if kindFlag {
-			if limitFlag {
-				rows, err = tx.Query(strQuery, Job_PENDING, Job_PULLED, curTime, project, kind, howmany)
-			} else {
-				rows, err = tx.Query(strQuery, Job_PENDING, Job_PULLED, curTime, project, kind)
-			}
-		} else if limitFlag {
-			rows, err = tx.Query(strQuery, Job_PENDING, Job_PULLED, curTime, project, howmany)
-		} else {
-			rows, err = tx.Query(strQuery, Job_PENDING, Job_PULLED, curTime, project)
-		}
-	} else if limitFlag {
-		rows, err = tx.Query(strQuery, Job_PENDING, Job_PULLED, curTime, kind, howmany)
+	// the locked rows are what the audit events and webhooks compare against
+	queryCtx, querySpan := startStorageSpan(ctx, "PullJobs.SkipLocked")
+	var pending *ListOfJobs
+	rows, err := tx.QueryContext(queryCtx, strQuery, args...)
+	if err == nil {
+		pending, err = queryJobs(rows)
+		rows.Close()
 	}
-
+	endSpan(querySpan, err)
 	if err != nil {
+		tx.Rollback()
 		return nil, err
 	}
 
-	ret, err := queryJobs(rows)
+	ids := pq.Int64Array{}
+	before := map[uint64]*Job{}
+	for _, job := range pending.Jobs {
+		ids = append(ids, int64(job.Id))
+		before[job.Id] = job
+	}
+	rows, err = tx.QueryContext(ctx, PULLINGSTRQ_3, Job_PULLED, curTime, ids)
+	if err == nil {
+		ret, err = queryJobs(rows)
+		rows.Close()
+	}
 	if err != nil {
+		tx.Rollback()
 		return nil, err
 	}
 
-	err = tx.Commit()
+	events := []*AuditEvent{}
+	for _, job := range ret.Jobs {
+		event, err := recordAuditEvent(ctx, tx, job.Id, job.Project, before[job.Id], job)
+		if err == nil {
+			err = storage.enqueueWebhookDeliveries(ctx, tx, before[job.Id], job)
+		}
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	err = commit(ctx, tx)
 	if err != nil {
-		tx.Rollback()
 		return nil, err
 	}
+	storage.exportAuditEvents(events...)
 
+	for _, job := range ret.Jobs {
+		linkJobTrace(ctx, job)
+	}
 	return ret, err
 }
 
-func (storage *WonderlandStorage) DeleteJob(id uint64, userProject string) (*Job, error) {
-	tx, err := storage.db.Begin()
+// DeleteJob moves a job to the trash, hiding it from listing and pulling
+// until it is undeleted or purged.
+func (storage *WonderlandStorage) DeleteJob(ctx context.Context, id uint64, userProject string) (resultJob *Job, err error) {
+	ctx, span := startStorageSpan(ctx, "DeleteJob")
+	defer func() { endSpan(span, err) }()
+
+	return storage.setJobDeleted(ctx, id, userProject, true)
+}
+
+// UndeleteJob restores a job from the trash.
+func (storage *WonderlandStorage) UndeleteJob(ctx context.Context, id uint64, userProject string) (resultJob *Job, err error) {
+	ctx, span := startStorageSpan(ctx, "UndeleteJob")
+	defer func() { endSpan(span, err) }()
+
+	return storage.setJobDeleted(ctx, id, userProject, false)
+}
+
+func (storage *WonderlandStorage) setJobDeleted(ctx context.Context, id uint64, userProject string, deleted bool) (*Job, error) {
+	tx, err := storage.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	before, err := lockJob(ctx, tx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var deletedAt interface{}
+	if deleted {
+		deletedAt = storage.now()
+	}
 	resultJob := &Job{}
+	err = tx.QueryRowContext(ctx, `
+		UPDATE jobs
+		SET
+			deleted_at=$1
+		WHERE id=$2 AND project=$3 AND (deleted_at IS NULL)=$4
+		RETURNING `+jobColumns+`;`,
+		deletedAt,
+		id,
+		userProject,
+		deleted,
+	).Scan(jobFields(resultJob)...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, resultJob.Id, resultJob.Project, before, resultJob)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(event)
+	return resultJob, nil
+}
+
+// PurgeDeletedJobs removes the jobs deleted before the given time for good.
+func (storage *WonderlandStorage) PurgeDeletedJobs(ctx context.Context, deletedBefore time.Time) (purged int, err error) {
+	ctx, span := startStorageSpan(ctx, "PurgeDeletedJobs")
+	defer func() { endSpan(span, err) }()
 
-	err = tx.QueryRow(`
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `
 		DELETE FROM jobs
-		WHERE id=$1 AND project=$2
-		RETURNING id, project, kind;`, id, userProject,
-	).Scan(
-		&resultJob.Id,
-		&resultJob.Project,
-		&resultJob.Kind,
+		WHERE deleted_at < $1
+		RETURNING `+jobColumns+`;`, deletedBefore,
 	)
 	if err != nil {
-		return resultJob, err
+		tx.Rollback()
+		return 0, err
 	}
-	err = tx.Commit()
+	jobs, err := queryJobs(rows)
+	rows.Close()
 	if err != nil {
 		tx.Rollback()
+		return 0, err
 	}
-	return resultJob, err
+
+	events := []*AuditEvent{}
+	for _, job := range jobs.Jobs {
+		event, err := recordAuditEvent(ctx, tx, job.Id, job.Project, job, nil)
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		events = append(events, event)
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	storage.exportAuditEvents(events...)
+	return len(jobs.Jobs), nil
 }
 
-func (storage *WonderlandStorage) KillJob(id uint64, userProject string) (*Job, error) {
-	tx, err := storage.db.Begin()
+// KillJob kills a job of userProject. Like every other change of status, it
+// sets last_modified, which retention rules count from.
+func (storage *WonderlandStorage) KillJob(ctx context.Context, id uint64, userProject string) (resultJob *Job, err error) {
+	ctx, span := startStorageSpan(ctx, "KillJob")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resultJob := &Job{}
+	before, err := lockJob(ctx, tx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 
-	err = tx.QueryRow(`
+	resultJob = &Job{}
+
+	err = tx.QueryRowContext(ctx, `
 		UPDATE jobs
 		SET
-			status=$1
-		WHERE id=$2 AND project=$3
-		RETURNING id, project, status, metadata, input, output, kind;`,
+			status=$1,
+			last_modified=$4
+		WHERE id=$2 AND project=$3 AND deleted_at IS NULL
+		RETURNING `+jobColumns+`;`,
 		Job_KILLED,
 		id,
 		userProject,
-	).Scan(
-		&resultJob.Id,
-		&resultJob.Project,
-		&resultJob.Status,
-		&resultJob.Metadata,
-		&resultJob.Input,
-		&resultJob.Output,
-		&resultJob.Kind,
-	)
+		storage.now(),
+	).Scan(jobFields(resultJob)...)
 	if err != nil {
+		tx.Rollback()
 		return resultJob, err
 	}
-	err = tx.Commit()
+	event, err := recordAuditEvent(ctx, tx, resultJob.Id, resultJob.Project, before, resultJob)
+	if err == nil {
+		err = storage.enqueueWebhookDeliveries(ctx, tx, before, resultJob)
+	}
 	if err != nil {
 		tx.Rollback()
 		return resultJob, err
 	}
+	err = commit(ctx, tx)
+	if err != nil {
+		return resultJob, err
+	}
+	storage.exportAuditEvents(event)
 
+	linkJobTrace(ctx, resultJob)
+	countFinishedJob(before, resultJob)
 	return resultJob, err
-}
\ No newline at end of file
+}
+
+// ArchiveJobs moves up to limit jobs matching condition, an SQL condition
+// with the given arguments, out of the jobs table. write has to store the
+// jobs durably and return the name of the archive they are in.
+func (storage *WonderlandStorage) ArchiveJobs(ctx context.Context, condition string, args []interface{}, limit int, write func([]*archivedJob) (string, error)) (archived int, err error) {
+	ctx, span := startStorageSpan(ctx, "ArchiveJobs")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	args = append(args, limit)
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+jobColumns+`, COALESCE(creator, ''), COALESCE(created, last_modified), last_modified
+		FROM jobs
+		WHERE deleted_at IS NULL AND `+condition+`
+		ORDER BY id
+		LIMIT $`+strconv.Itoa(len(args))+`
+		FOR UPDATE SKIP LOCKED;`, args...)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	jobs := []*Job{}
+	records := []*archivedJob{}
+	for rows.Next() {
+		job := &Job{}
+		record := &archivedJob{}
+		err = rows.Scan(append(jobFields(job), &record.Creator, &record.Created, &record.LastModified)...)
+		if err != nil {
+			break
+		}
+		var content string
+		content, err = auditMarshaler.MarshalToString(job)
+		if err != nil {
+			break
+		}
+		record.Job = json.RawMessage(content)
+		jobs = append(jobs, job)
+		records = append(records, record)
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	rows.Close()
+	if err == nil && len(jobs) > 0 {
+		err = readArchivedHistory(ctx, tx, jobs, records)
+	}
+	if err != nil || len(jobs) == 0 {
+		tx.Rollback()
+		return 0, err
+	}
+
+	archive, err := write(records)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	events := []*AuditEvent{}
+	for _, job := range jobs {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO archived_jobs (id, project, kind, status, archive)
+			VALUES ($1, $2, $3, $4, $5);`,
+			job.Id, job.Project, job.Kind, job.Status, archive,
+		)
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `DELETE FROM jobs WHERE id=$1;`, job.Id)
+		}
+		var event *AuditEvent
+		if err == nil {
+			event, err = recordAuditEvent(ctx, tx, job.Id, job.Project, job, nil)
+		}
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		events = append(events, event)
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	storage.exportAuditEvents(events...)
+	return len(jobs), nil
+}
+
+// readArchivedHistory adds the logs and progress reports of jobs to their
+// records, in the order they were written.
+func readArchivedHistory(ctx context.Context, tx *sql.Tx, jobs []*Job, records []*archivedJob) error {
+	ids := pq.Int64Array{}
+	byID := map[uint64]*archivedJob{}
+	for i, job := range jobs {
+		ids = append(ids, int64(job.Id))
+		byID[job.Id] = records[i]
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT job_id, seq, stream, data, created
+		FROM job_logs
+		WHERE job_id = ANY($1)
+		ORDER BY job_id, seq;`, ids,
+	)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var jobID uint64
+		entry := &archivedLog{}
+		err = rows.Scan(&jobID, &entry.Seq, &entry.Stream, &entry.Data, &entry.Created)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		byID[jobID].Logs = append(byID[jobID].Logs, entry)
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return err
+	}
+
+	rows, err = tx.QueryContext(ctx, `
+		SELECT job_id, fraction, reported_at
+		FROM job_progress
+		WHERE job_id = ANY($1)
+		ORDER BY job_id, id;`, ids,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var jobID uint64
+		report := &archivedProgress{}
+		err = rows.Scan(&jobID, &report.Fraction, &report.ReportedAt)
+		if err != nil {
+			return err
+		}
+		byID[jobID].Progress = append(byID[jobID].Progress, report)
+	}
+	return rows.Err()
+}
+
+// GetArchiveEntry tells where the archived job with the given id is.
+func (storage *WonderlandStorage) GetArchiveEntry(ctx context.Context, id uint64) (entry *archiveEntry, err error) {
+	ctx, span := startStorageSpan(ctx, "GetArchiveEntry")
+	defer func() { endSpan(span, err) }()
+
+	entry = &archiveEntry{}
+	err = storage.db.QueryRowContext(ctx, `
+		SELECT id, project, kind, archive
+		FROM archived_jobs
+		WHERE id=$1;`, id,
+	).Scan(&entry.JobId, &entry.Project, &entry.Kind, &entry.Archive)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// RestoreArchivedJob puts an archived job back into the jobs table with its
+// original id and times, along with its logs and progress reports.
+func (storage *WonderlandStorage) RestoreArchivedJob(ctx context.Context, record *archivedJob) (restored *Job, err error) {
+	ctx, span := startStorageSpan(ctx, "RestoreArchivedJob")
+	defer func() { endSpan(span, err) }()
+
+	job, err := record.job()
+	if err != nil {
+		return nil, err
+	}
+
+	jobError, err := jobErrorJSON(job.Error)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	restored = &Job{}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO jobs (id, project, status, metadata, creator, input, output, kind, trace_parent, created, last_modified,
+			max_runtime, deadline, failure_reason, error, kind_version, template_id, template_version, array_id, array_index)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15::jsonb, $16, $17, $18, $19, $20)
+		RETURNING `+jobColumns+`;`,
+		job.Id, job.Project, job.Status, job.Metadata, record.Creator, job.Input, job.Output, job.Kind, job.TraceParent,
+		record.Created, record.LastModified, job.MaxRuntime, timeOrNull(job.Deadline), job.FailureReason, jobError,
+		job.KindVersion, job.TemplateId, job.TemplateVersion, job.ArrayId, job.ArrayIndex,
+	).Scan(jobFields(restored)...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	for _, entry := range record.Logs {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO job_logs (job_id, seq, stream, data, created)
+			VALUES ($1, $2, $3, $4, $5);`,
+			job.Id, entry.Seq, entry.Stream, entry.Data, entry.Created,
+		)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if n := len(record.Logs); n > 0 {
+		// later chunks are numbered after the restored ones
+		_, err = tx.ExecContext(ctx, `UPDATE jobs SET log_seq=$1 WHERE id=$2;`, record.Logs[n-1].Seq, job.Id)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	for _, report := range record.Progress {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO job_progress (job_id, fraction, reported_at)
+			VALUES ($1, $2, $3);`,
+			job.Id, report.Fraction, report.ReportedAt,
+		)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM archived_jobs WHERE id=$1;`, job.Id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, restored.Id, restored.Project, nil, restored)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(event)
+	return restored, nil
+}
+
+// RescheduleJob changes when a pending job may be pulled, runAfter being
+// seconds since the epoch and 0 meaning right away.
+func (storage *WonderlandStorage) RescheduleJob(ctx context.Context, id uint64, runAfter int64) (resultJob *Job, err error) {
+	ctx, span := startStorageSpan(ctx, "RescheduleJob")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := lockJob(ctx, tx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	resultJob = &Job{}
+	err = tx.QueryRowContext(ctx, `
+		UPDATE jobs
+		SET
+			run_after=$1,
+			last_modified=$2
+		WHERE id=$3 AND status=$4 AND deleted_at IS NULL
+		RETURNING `+jobColumns+`;`,
+		timeOrNull(runAfter),
+		storage.now(),
+		id,
+		Job_PENDING,
+	).Scan(jobFields(resultJob)...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, resultJob.Id, resultJob.Project, before, resultJob)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(event)
+	return resultJob, nil
+}
+
+const roleBindingColumns = `id, principal, role, project, kind`
+
+func roleBindingFields(b *RoleBinding) []interface{} {
+	return []interface{}{
+		&b.Id,
+		&b.Principal,
+		&b.Role,
+		&b.Project,
+		&b.Kind,
+	}
+}
+
+func (storage *WonderlandStorage) CreateRoleBinding(ctx context.Context, binding *RoleBinding, creator User) (created *RoleBinding, err error) {
+	ctx, span := startStorageSpan(ctx, "CreateRoleBinding")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	created = &RoleBinding{}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO role_bindings (principal, role, project, kind, creator)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+roleBindingColumns+`;`,
+		binding.Principal, binding.Role, binding.Project, binding.Kind, creator.Username,
+	).Scan(roleBindingFields(created)...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, 0, created.Project, nil, created)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(event)
+	return created, nil
+}
+
+func (storage *WonderlandStorage) GetRoleBinding(ctx context.Context, id uint64) (binding *RoleBinding, err error) {
+	ctx, span := startStorageSpan(ctx, "GetRoleBinding")
+	defer func() { endSpan(span, err) }()
+
+	binding = &RoleBinding{}
+	err = storage.db.QueryRowContext(ctx, `
+		SELECT `+roleBindingColumns+`
+		FROM role_bindings
+		WHERE id=$1;`, id,
+	).Scan(roleBindingFields(binding)...)
+	if err != nil {
+		return nil, err
+	}
+	return binding, nil
+}
+
+// ListRoleBindings returns the bindings of principal in project. Empty
+// arguments match every principal or project.
+func (storage *WonderlandStorage) ListRoleBindings(ctx context.Context, principal string, project string) (ret *ListOfRoleBindings, err error) {
+	ctx, span := startStorageSpan(ctx, "ListRoleBindings")
+	defer func() { endSpan(span, err) }()
+
+	strQuery := `SELECT ` + roleBindingColumns + ` FROM role_bindings WHERE true`
+	args := []interface{}{}
+	if principal != "" {
+		args = append(args, principal)
+		strQuery += " AND principal=$" + strconv.Itoa(len(args))
+	}
+	if project != "" {
+		args = append(args, project)
+		strQuery += " AND project=$" + strconv.Itoa(len(args))
+	}
+	strQuery += ` ORDER BY id;`
+
+	rows, err := storage.db.QueryContext(ctx, strQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret = &ListOfRoleBindings{Bindings: []*RoleBinding{}}
+	for rows.Next() {
+		binding := &RoleBinding{}
+		err = rows.Scan(roleBindingFields(binding)...)
+		if err != nil {
+			return nil, err
+		}
+		ret.Bindings = append(ret.Bindings, binding)
+	}
+	err = rows.Err()
+	return ret, err
+}
+
+func (storage *WonderlandStorage) DeleteRoleBinding(ctx context.Context, id uint64) (binding *RoleBinding, err error) {
+	ctx, span := startStorageSpan(ctx, "DeleteRoleBinding")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	binding = &RoleBinding{}
+	err = tx.QueryRowContext(ctx, `
+		DELETE FROM role_bindings
+		WHERE id=$1
+		RETURNING `+roleBindingColumns+`;`, id,
+	).Scan(roleBindingFields(binding)...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, 0, binding.Project, binding, nil)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(event)
+	return binding, nil
+}
+
+const revokedCertificateColumns = `serial, reason, revoked_by`
+
+func revokedCertificateFields(c *RevokedCertificate) []interface{} {
+	return []interface{}{
+		&c.Serial,
+		&c.Reason,
+		&c.RevokedBy,
+	}
+}
+
+func (storage *WonderlandStorage) RevokeCertificate(ctx context.Context, in *RevokedCertificate) (revoked *RevokedCertificate, err error) {
+	ctx, span := startStorageSpan(ctx, "RevokeCertificate")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// a certificate may be revoked again to change the reason
+	var before *RevokedCertificate
+	previous := &RevokedCertificate{}
+	err = tx.QueryRowContext(ctx, `
+		SELECT `+revokedCertificateColumns+`
+		FROM revoked_certificates
+		WHERE serial=$1
+		FOR UPDATE;`, in.Serial,
+	).Scan(revokedCertificateFields(previous)...)
+	switch err {
+	case nil:
+		before = previous
+	case sql.ErrNoRows:
+	default:
+		tx.Rollback()
+		return nil, err
+	}
+
+	revoked = &RevokedCertificate{}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO revoked_certificates (serial, reason, revoked_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (serial) DO UPDATE SET reason=EXCLUDED.reason, revoked_by=EXCLUDED.revoked_by
+		RETURNING `+revokedCertificateColumns+`;`,
+		in.Serial, in.Reason, in.RevokedBy,
+	).Scan(revokedCertificateFields(revoked)...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, 0, "", before, revoked)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(event)
+	return revoked, nil
+}
+
+func (storage *WonderlandStorage) ListRevokedCertificates(ctx context.Context) (ret *ListOfRevokedCertificates, err error) {
+	ctx, span := startStorageSpan(ctx, "ListRevokedCertificates")
+	defer func() { endSpan(span, err) }()
+
+	rows, err := storage.db.QueryContext(ctx, `
+		SELECT `+revokedCertificateColumns+`
+		FROM revoked_certificates
+		ORDER BY created;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret = &ListOfRevokedCertificates{Certificates: []*RevokedCertificate{}}
+	for rows.Next() {
+		revoked := &RevokedCertificate{}
+		err = rows.Scan(revokedCertificateFields(revoked)...)
+		if err != nil {
+			return nil, err
+		}
+		ret.Certificates = append(ret.Certificates, revoked)
+	}
+	err = rows.Err()
+	return ret, err
+}
+
+func (storage *WonderlandStorage) UnrevokeCertificate(ctx context.Context, serial string) (revoked *RevokedCertificate, err error) {
+	ctx, span := startStorageSpan(ctx, "UnrevokeCertificate")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked = &RevokedCertificate{}
+	err = tx.QueryRowContext(ctx, `
+		DELETE FROM revoked_certificates
+		WHERE serial=$1
+		RETURNING `+revokedCertificateColumns+`;`, serial,
+	).Scan(revokedCertificateFields(revoked)...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, 0, "", revoked, nil)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(event)
+	return revoked, nil
+}
+
+// RecordIssuedCertificate stores the issuance of cert, renewing the
+// certificate with serial renewedFrom if that is not empty.
+func (storage *WonderlandStorage) RecordIssuedCertificate(ctx context.Context, cert *IssuedCertificate, renewedFrom string) (err error) {
+	ctx, span := startStorageSpan(ctx, "RecordIssuedCertificate")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO issued_certificates (serial, common_name, grants, not_after, issued_by, renewed_from)
+		VALUES ($1, $2, $3, $4, $5, $6);`,
+		cert.Serial,
+		cert.CommonName,
+		strings.Join(cert.Grants, ","),
+		time.Unix(cert.NotAfter, 0).UTC(),
+		cert.IssuedBy,
+		renewedFrom,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	event, err := recordAuditEvent(ctx, tx, 0, "", nil, cert)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return err
+	}
+	storage.exportAuditEvents(event)
+	return nil
+}
+
+// ListAuditEvents returns the newest audit events matching the non-empty
+// filters, at most howmany of them if that is not zero.
+func (storage *WonderlandStorage) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest) (ret *ListOfAuditEvents, err error) {
+	ctx, span := startStorageSpan(ctx, "ListAuditEvents")
+	defer func() { endSpan(span, err) }()
+
+	strQuery := `SELECT ` + auditEventColumns + ` FROM audit_events WHERE true`
+	args := []interface{}{}
+	if in.Project != "" {
+		args = append(args, in.Project)
+		strQuery += " AND project=$" + strconv.Itoa(len(args))
+	}
+	if in.Principal != "" {
+		args = append(args, in.Principal)
+		strQuery += " AND principal=$" + strconv.Itoa(len(args))
+	}
+	if in.JobId != 0 {
+		args = append(args, in.JobId)
+		strQuery += " AND job_id=$" + strconv.Itoa(len(args))
+	}
+	if in.Rpc != "" {
+		args = append(args, in.Rpc)
+		strQuery += " AND rpc=$" + strconv.Itoa(len(args))
+	}
+	if in.Since != 0 {
+		args = append(args, time.Unix(in.Since, 0).UTC())
+		strQuery += " AND created>=$" + strconv.Itoa(len(args))
+	}
+	strQuery += ` ORDER BY id DESC`
+	if in.HowMany != 0 {
+		args = append(args, in.HowMany)
+		strQuery += " LIMIT $" + strconv.Itoa(len(args))
+	}
+	strQuery += `;`
+
+	rows, err := storage.db.QueryContext(ctx, strQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret = &ListOfAuditEvents{Events: []*AuditEvent{}}
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		ret.Events = append(ret.Events, event)
+	}
+	err = rows.Err()
+	return ret, err
+}
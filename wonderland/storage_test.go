@@ -1,7 +1,17 @@
 package wonderland
 
 import (
+	"database/sql"
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 )
 
 func checkTestErr(err error, t *testing.T) {
@@ -27,7 +37,7 @@ func TestJobCRUD(t *testing.T) {
 		Kind:     "kind_test",
 	}
 
-	createdJob, err := storage.CreateJob(job, User{Username: "tester"})
+	createdJob, err := storage.CreateJob(context.Background(), job, User{Username: "tester"})
 	checkTestErr(err, t)
 
 	if createdJob == nil {
@@ -43,3 +53,645 @@ func TestJobCRUD(t *testing.T) {
 	}
 
 }
+
+func TestScheduledJobs(t *testing.T) {
+	initTestsConfig()
+	storage, err := NewWonderlandStorage(TestsConfig.DatabaseURI)
+	checkTestErr(err, t)
+
+	now := time.Now().UTC()
+	storage.Clock = func() time.Time { return now }
+	ctx := context.Background()
+	kind := fmt.Sprintf("scheduled_%d", now.UnixNano())
+
+	job, err := storage.CreateJob(ctx, &Job{
+		Project:  "test_project",
+		Kind:     kind,
+		RunAfter: now.Add(time.Hour).Unix(),
+	}, User{Username: "tester"})
+	checkTestErr(err, t)
+
+	pulled, err := storage.PullJobs(ctx, 0, "test_project", kind)
+	checkTestErr(err, t)
+	if len(pulled.Jobs) != 0 {
+		t.Fail()
+	}
+	scheduled, err := storage.ListJobs(ctx, &ListJobsRequest{Project: "test_project", Kind: kind, Scheduled: true})
+	checkTestErr(err, t)
+	if len(scheduled.Jobs) != 1 || scheduled.Jobs[0].RunAfter != job.RunAfter {
+		t.Fail()
+	}
+
+	now = now.Add(2 * time.Hour)
+	pulled, err = storage.PullJobs(ctx, 0, "test_project", kind)
+	checkTestErr(err, t)
+	if len(pulled.Jobs) != 1 || pulled.Jobs[0].Id != job.Id {
+		t.Fail()
+	}
+}
+
+func TestJobTimeouts(t *testing.T) {
+	initTestsConfig()
+	storage, err := NewWonderlandStorage(TestsConfig.DatabaseURI)
+	checkTestErr(err, t)
+
+	now := time.Now().UTC()
+	storage.Clock = func() time.Time { return now }
+	ctx := context.Background()
+	kind := fmt.Sprintf("timeouts_%d", now.UnixNano())
+
+	running, err := storage.CreateJob(ctx, &Job{Project: "test_project", Kind: kind, MaxRuntime: 60}, User{Username: "tester"})
+	checkTestErr(err, t)
+	_, err = storage.PullJobs(ctx, 0, "test_project", kind)
+	checkTestErr(err, t)
+	late, err := storage.CreateJob(ctx, &Job{
+		Project:  "test_project",
+		Kind:     kind,
+		Deadline: now.Add(time.Minute).Unix(),
+	}, User{Username: "tester"})
+	checkTestErr(err, t)
+
+	now = now.Add(2 * time.Minute)
+	// jobs past their deadline are never pulled, even before the watchdog
+	pulled, err := storage.PullJobs(ctx, 0, "test_project", kind)
+	checkTestErr(err, t)
+	if len(pulled.Jobs) != 0 {
+		t.Fail()
+	}
+
+	_, _, err = storage.FinishOverdueJobs(ctx)
+	checkTestErr(err, t)
+	job, err := storage.GetJob(ctx, running.Id)
+	checkTestErr(err, t)
+	if job.Status != Job_FAILED || job.FailureReason == "" {
+		t.Log(job)
+		t.Fail()
+	}
+	// the worker learns why its job was stopped
+	s := &Server{Storage: storage}
+	worker := User{Username: "worker", Bindings: []*RoleBinding{{Principal: "worker", Role: string(RoleWorker), Project: "test_project", Kind: AnyScope}}}
+	job.Status = Job_COMPLETED
+	_, err = s.ModifyJob(context.WithValue(ctx, "authorized-user", worker), job)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Log(err)
+		t.Fail()
+	}
+	job.Status = Job_PENDING
+	_, err = s.ModifyJob(context.WithValue(ctx, "authorized-user", worker), job)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Log(err)
+		t.Fail()
+	}
+	// while its submitter may put it back to run again
+	submitter := User{Username: "tester", Bindings: []*RoleBinding{{Principal: "tester", Role: string(RoleSubmitter), Project: "test_project", Kind: AnyScope}}}
+	restarted, err := s.ModifyJob(context.WithValue(ctx, "authorized-user", submitter), job)
+	checkTestErr(err, t)
+	if err == nil && (restarted.Status != Job_PENDING || restarted.FailureReason != "" || restarted.Error != nil) {
+		t.Log(restarted)
+		t.Fail()
+	}
+	job, err = storage.GetJob(ctx, late.Id)
+	checkTestErr(err, t)
+	if job.Status != Job_EXPIRED {
+		t.Log(job)
+		t.Fail()
+	}
+}
+
+func TestJobEvents(t *testing.T) {
+	initTestsConfig()
+	storage, err := NewWonderlandStorage(TestsConfig.DatabaseURI)
+	checkTestErr(err, t)
+
+	ctx := context.Background()
+	kind := fmt.Sprintf("events_%d", time.Now().UnixNano())
+
+	_, _, latest, err := storage.ListJobEvents(ctx, "test_project", kind, 0, 1)
+	checkTestErr(err, t)
+	job, err := storage.CreateJob(ctx, &Job{Project: "test_project", Kind: kind}, User{Username: "tester"})
+	checkTestErr(err, t)
+	_, err = storage.PullJobs(ctx, 0, "test_project", kind)
+	checkTestErr(err, t)
+	_, err = storage.KillJob(ctx, job.Id, "test_project")
+	checkTestErr(err, t)
+
+	events, _, _, err := storage.ListJobEvents(ctx, "test_project", kind, latest+1, 10)
+	checkTestErr(err, t)
+	expected := []JobEvent_Type{JobEvent_CREATED, JobEvent_STATUS_CHANGED, JobEvent_STATUS_CHANGED}
+	if len(events) != len(expected) {
+		t.Fatal(events)
+	}
+	for i, event := range events {
+		if event.Type != expected[i] || event.JobId != job.Id || (i > 0 && event.Sequence <= events[i-1].Sequence) {
+			t.Log(event)
+			t.Fail()
+		}
+	}
+	if events[2].PreviousStatus != Job_PULLED || events[2].Job.Status != Job_KILLED {
+		t.Fail()
+	}
+
+	_, err = storage.CompactJobEvents(ctx, time.Now().UTC().Add(time.Hour))
+	checkTestErr(err, t)
+	_, horizon, _, err := storage.ListJobEvents(ctx, "test_project", kind, 0, 10)
+	checkTestErr(err, t)
+	if horizon < events[2].Sequence {
+		t.Fail()
+	}
+}
+
+func TestRunDueSchedules(t *testing.T) {
+	initTestsConfig()
+	storage, err := NewWonderlandStorage(TestsConfig.DatabaseURI)
+	checkTestErr(err, t)
+
+	now := time.Now().UTC()
+	storage.Clock = func() time.Time { return now }
+	ctx := context.Background()
+	kind := fmt.Sprintf("schedules_%d", now.UnixNano())
+
+	creator := User{Username: "tester", Grants: []Grant{{Project: "test_project", Kind: AnyScope}}}
+	allowed, err := storage.CreateSchedule(ctx, &Schedule{
+		Cron:     "@hourly",
+		Template: &Job{Project: "test_project", Kind: kind},
+	}, creator)
+	checkTestErr(err, t)
+	// nobody may create the jobs of this one any more
+	denied, err := storage.CreateSchedule(ctx, &Schedule{
+		Cron:     "@hourly",
+		Template: &Job{Project: "test_project", Kind: kind},
+	}, User{Username: fmt.Sprintf("nobody_%d", now.UnixNano())})
+	checkTestErr(err, t)
+
+	now = now.Add(time.Hour)
+	_, err = storage.RunDueSchedules(ctx)
+	checkTestErr(err, t)
+
+	schedule, err := storage.GetSchedule(ctx, allowed.Id)
+	checkTestErr(err, t)
+	if schedule.LastJobId == 0 || schedule.Paused || schedule.NextRun <= allowed.NextRun {
+		t.Log(schedule)
+		t.Fail()
+	}
+	schedule, err = storage.GetSchedule(ctx, denied.Id)
+	checkTestErr(err, t)
+	if schedule.LastJobId != 0 || !schedule.Paused {
+		t.Log(schedule)
+		t.Fail()
+	}
+	jobs, err := storage.ListJobs(ctx, &ListJobsRequest{Project: "test_project", Kind: kind})
+	checkTestErr(err, t)
+	if len(jobs.Jobs) != 1 {
+		t.Log(jobs)
+		t.Fail()
+	}
+}
+
+func TestJobLogs(t *testing.T) {
+	initTestsConfig()
+	storage, err := NewWonderlandStorage(TestsConfig.DatabaseURI)
+	checkTestErr(err, t)
+
+	ctx := context.Background()
+	kind := fmt.Sprintf("logs_%d", time.Now().UnixNano())
+
+	job, err := storage.CreateJob(ctx, &Job{Project: "test_project", Kind: kind}, User{Username: "tester"})
+	checkTestErr(err, t)
+	// pending jobs have no worker writing logs yet
+	_, err = storage.AppendJobLog(ctx, &JobLogChunk{JobId: job.Id, Data: "early"})
+	if err != sql.ErrNoRows {
+		t.Log(err)
+		t.Fail()
+	}
+
+	_, err = storage.PullJobs(ctx, 0, "test_project", kind)
+	checkTestErr(err, t)
+	for _, data := range []string{"one", "two", "three"} {
+		_, err = storage.AppendJobLog(ctx, &JobLogChunk{JobId: job.Id, Stream: "stdout", Data: data})
+		checkTestErr(err, t)
+	}
+	chunks, err := storage.ListJobLogs(ctx, job.Id, 1, 0)
+	checkTestErr(err, t)
+	if len(chunks) != 2 || chunks[0].Seq != 2 || chunks[1].Data != "three" {
+		t.Log(chunks)
+		t.Fail()
+	}
+
+	_, err = storage.KillJob(ctx, job.Id, "test_project")
+	checkTestErr(err, t)
+	_, err = storage.AppendJobLog(ctx, &JobLogChunk{JobId: job.Id, Data: "late"})
+	if err != sql.ErrNoRows {
+		t.Log(err)
+		t.Fail()
+	}
+}
+
+func TestJobProgress(t *testing.T) {
+	initTestsConfig()
+	storage, err := NewWonderlandStorage(TestsConfig.DatabaseURI)
+	checkTestErr(err, t)
+
+	ctx := context.Background()
+	kind := fmt.Sprintf("progress_%d", time.Now().UnixNano())
+
+	job, err := storage.CreateJob(ctx, &Job{Project: "test_project", Kind: kind}, User{Username: "tester"})
+	checkTestErr(err, t)
+	_, err = storage.ReportProgress(ctx, job.Id, &Progress{Fraction: 0.1})
+	if err != sql.ErrNoRows {
+		t.Log(err)
+		t.Fail()
+	}
+
+	_, err = storage.PullJobs(ctx, 0, "test_project", kind)
+	checkTestErr(err, t)
+	reported, err := storage.ReportProgress(ctx, job.Id, &Progress{Fraction: 0.5})
+	checkTestErr(err, t)
+	if reported == nil || reported.UpdatedAt == 0 {
+		t.Fail()
+	}
+
+	_, err = storage.KillJob(ctx, job.Id, "test_project")
+	checkTestErr(err, t)
+	_, err = storage.ReportProgress(ctx, job.Id, &Progress{Fraction: 0.6})
+	if err != sql.ErrNoRows {
+		t.Log(err)
+		t.Fail()
+	}
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	initTestsConfig()
+	storage, err := NewWonderlandStorage(TestsConfig.DatabaseURI)
+	checkTestErr(err, t)
+
+	now := time.Now().UTC()
+	storage.Clock = func() time.Time { return now }
+	ctx := context.Background()
+	kind := fmt.Sprintf("webhooks_%d", now.UnixNano())
+
+	webhook := &Webhook{Project: "test_project", Url: "https://ci.example.com/", Kinds: []string{kind}, Statuses: []Job_Status{Job_KILLED}}
+	checkTestErr(validateWebhook(webhook), t)
+	webhook, err = storage.CreateWebhook(ctx, webhook, User{Username: "tester"})
+	checkTestErr(err, t)
+	defer storage.DeleteWebhook(ctx, webhook.Id)
+
+	job, err := storage.CreateJob(ctx, &Job{Project: "test_project", Kind: kind}, User{Username: "tester"})
+	checkTestErr(err, t)
+	_, err = storage.KillJob(ctx, job.Id, "test_project")
+	checkTestErr(err, t)
+
+	// only the kill matches the webhook
+	deliveries, err := storage.ListWebhookDeliveries(ctx, &ListWebhookDeliveriesRequest{WebhookId: webhook.Id})
+	checkTestErr(err, t)
+	if len(deliveries.Deliveries) != 1 || deliveries.Deliveries[0].JobId != job.Id || deliveries.Deliveries[0].Status != Job_KILLED {
+		t.Fatal(deliveries)
+	}
+	id := deliveries.Deliveries[0].Id
+
+	delivery, err := storage.recordWebhookAttempt(ctx, id, http.StatusBadGateway, fmt.Errorf("webhook responded with 502"), 2, time.Second)
+	checkTestErr(err, t)
+	if delivery.State != WebhookDelivery_PENDING || delivery.Attempts != 1 {
+		t.Log(delivery)
+		t.Fail()
+	}
+	delivery, err = storage.recordWebhookAttempt(ctx, id, http.StatusBadGateway, fmt.Errorf("webhook responded with 502"), 2, time.Second)
+	checkTestErr(err, t)
+	if delivery.State != WebhookDelivery_DEAD {
+		t.Log(delivery)
+		t.Fail()
+	}
+	delivery, err = storage.RetryWebhookDelivery(ctx, id)
+	checkTestErr(err, t)
+	if delivery.State != WebhookDelivery_PENDING || delivery.Attempts != 0 {
+		t.Log(delivery)
+		t.Fail()
+	}
+
+	// pending deliveries are kept however old they are
+	_, err = storage.DeleteOldWebhookDeliveries(ctx, now.Add(time.Hour))
+	checkTestErr(err, t)
+	_, err = storage.GetWebhookDelivery(ctx, id)
+	checkTestErr(err, t)
+	_, err = storage.recordWebhookAttempt(ctx, id, http.StatusOK, nil, 2, time.Second)
+	checkTestErr(err, t)
+	_, err = storage.DeleteOldWebhookDeliveries(ctx, now.Add(time.Hour))
+	checkTestErr(err, t)
+	_, err = storage.GetWebhookDelivery(ctx, id)
+	if err != sql.ErrNoRows {
+		t.Log(err)
+		t.Fail()
+	}
+}
+
+func TestKindVersions(t *testing.T) {
+	initTestsConfig()
+	storage, err := NewWonderlandStorage(TestsConfig.DatabaseURI)
+	checkTestErr(err, t)
+
+	s := &Server{Storage: storage}
+	admin := User{Username: "tester", Bindings: []*RoleBinding{{Principal: "tester", Role: string(RoleClusterAdmin), Project: AnyScope, Kind: AnyScope}}}
+	ctx := context.WithValue(context.Background(), "authorized-user", admin)
+	kind := fmt.Sprintf("kinds_%d", time.Now().UnixNano())
+
+	first, err := s.RegisterKind(ctx, &Kind{Project: "test_project", Name: kind, OutputSchema: `{"type": "integer"}`})
+	checkTestErr(err, t)
+	job, err := s.CreateJob(ctx, &Job{Project: "test_project", Kind: kind})
+	checkTestErr(err, t)
+	second, err := s.RegisterKind(ctx, &Kind{Project: "test_project", Name: kind, OutputSchema: `{"type": "string"}`})
+	checkTestErr(err, t)
+	if first.Version != 1 || second.Version != 2 || job.KindVersion != 1 {
+		t.Fatal(first, second, job)
+	}
+	latest, err := s.GetKind(ctx, &GetKindRequest{Project: "test_project", Name: kind})
+	checkTestErr(err, t)
+	if latest.Version != 2 {
+		t.Fail()
+	}
+
+	// the output is checked against the version the job was created with
+	_, err = storage.PullJobs(ctx, 0, "test_project", kind)
+	checkTestErr(err, t)
+	job.Status = Job_RUNNING
+	job.Output = `"done"`
+	_, err = s.ModifyJob(ctx, job)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Log(err)
+		t.Fail()
+	}
+	job.Output = `42`
+	_, err = s.ModifyJob(ctx, job)
+	checkTestErr(err, t)
+
+	// finishing with an invalid output fails the job instead
+	job.Status = Job_COMPLETED
+	job.Output = `"done"`
+	finished, err := s.ModifyJob(ctx, job)
+	checkTestErr(err, t)
+	if finished.Status != Job_FAILED || finished.Error == nil || finished.Error.Code != "OUTPUT_INVALID" {
+		t.Log(finished)
+		t.Fail()
+	}
+}
+
+func TestTemplateVersions(t *testing.T) {
+	initTestsConfig()
+	storage, err := NewWonderlandStorage(TestsConfig.DatabaseURI)
+	checkTestErr(err, t)
+
+	s := &Server{Storage: storage}
+	admin := User{Username: "tester", Bindings: []*RoleBinding{{Principal: "tester", Role: string(RoleClusterAdmin), Project: AnyScope, Kind: AnyScope}}}
+	ctx := context.WithValue(context.Background(), "authorized-user", admin)
+	name := fmt.Sprintf("template_%d", time.Now().UnixNano())
+
+	template := &JobTemplate{
+		Project:    "test_project",
+		Name:       name,
+		Kind:       "docker",
+		Input:      `{"run":"{{run}}"}`,
+		Parameters: []*TemplateParameter{{Name: "run"}},
+	}
+	first, err := s.CreateJobTemplate(ctx, template)
+	checkTestErr(err, t)
+	template.Input = `{"run":"{{run}}","version":2}`
+	second, err := s.CreateJobTemplate(ctx, template)
+	checkTestErr(err, t)
+	if first.Version != 1 || second.Version != 2 || first.Id != second.Id {
+		t.Fatal(first, second)
+	}
+
+	versions, err := s.ListJobTemplates(ctx, &ListJobTemplatesRequest{Project: "test_project", Name: name})
+	checkTestErr(err, t)
+	if len(versions.Templates) != 2 || versions.Templates[0].Version != 2 {
+		t.Log(versions)
+		t.Fail()
+	}
+
+	latest, err := s.CreateJobFromTemplate(ctx, &CreateJobFromTemplateRequest{Project: "test_project", Name: name, Parameters: map[string]string{"run": "2018A"}})
+	checkTestErr(err, t)
+	if latest.TemplateVersion != 2 || latest.Input != `{"run":"2018A","version":2}` {
+		t.Log(latest)
+		t.Fail()
+	}
+	pinned, err := s.CreateJobFromTemplate(ctx, &CreateJobFromTemplateRequest{Project: "test_project", Name: name, Version: 1, Parameters: map[string]string{"run": "2018A"}})
+	checkTestErr(err, t)
+	if pinned.TemplateId != first.Id || pinned.TemplateVersion != 1 || pinned.Input != `{"run":"2018A"}` {
+		t.Log(pinned)
+		t.Fail()
+	}
+
+	_, err = s.CreateJobFromTemplate(ctx, &CreateJobFromTemplateRequest{Project: "test_project", Name: name, Version: 3})
+	if status.Code(err) != codes.NotFound {
+		t.Log(err)
+		t.Fail()
+	}
+	// those who cannot list the templates of the project are not told
+	submitter := User{Username: "alice", Bindings: []*RoleBinding{{Principal: "alice", Role: string(RoleSubmitter), Project: "test_project", Kind: "other"}}}
+	_, err = s.CreateJobFromTemplate(context.WithValue(context.Background(), "authorized-user", submitter),
+		&CreateJobFromTemplateRequest{Project: "test_project", Name: name, Version: 3})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Log(err)
+		t.Fail()
+	}
+}
+
+func TestArrayJobs(t *testing.T) {
+	initTestsConfig()
+	storage, err := NewWonderlandStorage(TestsConfig.DatabaseURI)
+	checkTestErr(err, t)
+
+	s := &Server{Storage: storage}
+	admin := User{Username: "tester", Bindings: []*RoleBinding{{Principal: "tester", Role: string(RoleClusterAdmin), Project: AnyScope, Kind: AnyScope}}}
+	ctx := context.WithValue(context.Background(), "authorized-user", admin)
+	array := testArrayJob()
+	array.Base.Project = "test_project"
+	array.Base.Kind = fmt.Sprintf("array_%d", time.Now().UnixNano())
+
+	created, err := s.CreateArrayJob(ctx, array)
+	checkTestErr(err, t)
+	if created.Size_ != 6 || created.Status.Pending != 6 {
+		t.Fatal(created)
+	}
+	events, _, _, err := storage.ListJobEvents(ctx, "test_project", array.Base.Kind, 0, 100)
+	checkTestErr(err, t)
+	if len(events) != 6 {
+		t.Fatal(events)
+	}
+	for i, event := range events {
+		if event.Type != JobEvent_CREATED || event.Job.ArrayId != created.Id || event.Job.ArrayIndex != uint32(i) {
+			t.Errorf("event %d is %v", i, event)
+		}
+	}
+
+	_, err = storage.PullJobs(ctx, 1, "test_project", array.Base.Kind)
+	checkTestErr(err, t)
+	got, err := s.GetArrayJob(ctx, &RequestWithId{Id: created.Id})
+	checkTestErr(err, t)
+	if got.Status.Pending != 5 || got.Status.Pulled != 1 || got.Status.Done != 0 {
+		t.Log(got.Status)
+		t.Fail()
+	}
+
+	killed, err := s.KillArrayJob(ctx, &RequestWithId{Id: created.Id})
+	checkTestErr(err, t)
+	if killed.Status.Killed != 6 || killed.Status.Done != 6 {
+		t.Log(killed.Status)
+		t.Fail()
+	}
+
+	_, err = s.GetArrayJob(ctx, &RequestWithId{Id: created.Id + 1000000})
+	if status.Code(err) != codes.NotFound {
+		t.Log(err)
+		t.Fail()
+	}
+}
+
+func TestWebhookDeliveredOnce(t *testing.T) {
+	initTestsConfig()
+	storage, err := NewWonderlandStorage(TestsConfig.DatabaseURI)
+	checkTestErr(err, t)
+
+	var mu sync.Mutex
+	now := time.Now().UTC()
+	storage.Clock = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	// each request takes as long as the receiver may, on the clock of the
+	// storage
+	sent := map[string]int{}
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		sent[r.Header.Get(WebhookDeliveryHeader)]++
+		now = now.Add(webhookTimeout)
+		time.Sleep(10 * time.Millisecond)
+	}))
+	defer receiver.Close()
+
+	ctx := context.Background()
+	kind := fmt.Sprintf("webhooks_%d", now.UnixNano())
+	webhook := &Webhook{Project: "test_project", Url: receiver.URL, Kinds: []string{kind}, Statuses: []Job_Status{Job_KILLED}}
+	checkTestErr(validateWebhook(webhook), t)
+	webhook, err = storage.CreateWebhook(ctx, webhook, User{Username: "tester"})
+	checkTestErr(err, t)
+	defer storage.DeleteWebhook(ctx, webhook.Id)
+
+	jobs := 2*webhookBatchSize + 5
+	for i := 0; i < jobs; i++ {
+		job, err := storage.CreateJob(ctx, &Job{Project: "test_project", Kind: kind}, User{Username: "tester"})
+		checkTestErr(err, t)
+		_, err = storage.KillJob(ctx, job.Id, "test_project")
+		checkTestErr(err, t)
+	}
+
+	// another replica keeps claiming while the first one sends its batch
+	first, second := NewWebhookDispatcher(storage), NewWebhookDispatcher(storage)
+	first.AllowPrivateAddresses, second.AllowPrivateAddresses = true, true
+	done := make(chan error)
+	go func() {
+		_, _, err := first.DeliverDue(ctx)
+		done <- err
+	}()
+	for sending := true; sending; {
+		select {
+		case err = <-done:
+			checkTestErr(err, t)
+			sending = false
+		default:
+			_, _, err = second.DeliverDue(ctx)
+			checkTestErr(err, t)
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	for {
+		delivered, failed, err := first.DeliverDue(ctx)
+		checkTestErr(err, t)
+		if err != nil || delivered+failed == 0 {
+			break
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(sent) != jobs {
+		t.Errorf("%d of %d deliveries were sent", len(sent), jobs)
+	}
+	for id, count := range sent {
+		if count != 1 {
+			t.Errorf("delivery %s was sent %d times", id, count)
+		}
+	}
+}
+
+func TestMissingRowsAreNotFound(t *testing.T) {
+	initTestsConfig()
+	storage, err := NewWonderlandStorage(TestsConfig.DatabaseURI)
+	checkTestErr(err, t)
+
+	s := &Server{Storage: storage}
+	admin := User{Username: "tester", Bindings: []*RoleBinding{{Principal: "tester", Role: string(RoleClusterAdmin), Project: AnyScope, Kind: AnyScope}}}
+	ctx := context.WithValue(context.Background(), "authorized-user", admin)
+	missing := &RequestWithId{Id: math.MaxInt32}
+
+	calls := map[string]func() error{
+		"GetJob": func() error {
+			_, err := s.GetJob(ctx, missing)
+			return err
+		},
+		"ModifyJob": func() error {
+			_, err := s.ModifyJob(ctx, &Job{Id: missing.Id, Status: Job_RUNNING})
+			return err
+		},
+		"KillJob": func() error {
+			_, err := s.KillJob(ctx, missing)
+			return err
+		},
+		"DeleteJob": func() error {
+			_, err := s.DeleteJob(ctx, missing)
+			return err
+		},
+		"UndeleteJob": func() error {
+			_, err := s.UndeleteJob(ctx, missing)
+			return err
+		},
+		"RescheduleJob": func() error {
+			_, err := s.RescheduleJob(ctx, &RescheduleJobRequest{Id: missing.Id})
+			return err
+		},
+		"ReportProgress": func() error {
+			_, err := s.ReportProgress(ctx, &ReportProgressRequest{Id: missing.Id, Progress: &Progress{Fraction: 0.5}})
+			return err
+		},
+		"DeleteRoleBinding": func() error {
+			_, err := s.DeleteRoleBinding(ctx, missing)
+			return err
+		},
+		"PauseSchedule": func() error {
+			_, err := s.PauseSchedule(ctx, &PauseScheduleRequest{Id: missing.Id, Paused: true})
+			return err
+		},
+		"DeleteSchedule": func() error {
+			_, err := s.DeleteSchedule(ctx, missing)
+			return err
+		},
+		"DeleteWebhook": func() error {
+			_, err := s.DeleteWebhook(ctx, missing)
+			return err
+		},
+		"ListWebhookDeliveries": func() error {
+			_, err := s.ListWebhookDeliveries(ctx, &ListWebhookDeliveriesRequest{WebhookId: missing.Id})
+			return err
+		},
+		"RetryWebhookDelivery": func() error {
+			_, err := s.RetryWebhookDelivery(ctx, missing)
+			return err
+		},
+	}
+	for name, call := range calls {
+		err := call()
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,425 @@
+package wonderland
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// templateLockKey namespaces the advisory locks serializing the versions of
+// a template.
+const templateLockKey = 0x74706c74
+
+var (
+	parameterNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	placeholderPattern   = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+)
+
+// parseParameter returns the value of parameter given as text.
+func parseParameter(parameter *TemplateParameter, text string) (interface{}, error) {
+	switch parameter.Type {
+	case TemplateParameter_INTEGER:
+		return strconv.ParseInt(text, 10, 64)
+	case TemplateParameter_NUMBER:
+		value, err := strconv.ParseFloat(text, 64)
+		if err == nil && (math.IsNaN(value) || math.IsInf(value, 0)) {
+			err = fmt.Errorf("%s is not a finite number", text)
+		}
+		return value, err
+	case TemplateParameter_BOOLEAN:
+		return strconv.ParseBool(text)
+	}
+	return text, nil
+}
+
+func validateJobTemplate(template *JobTemplate) error {
+	if template.Name == "" {
+		return grpc.Errorf(codes.InvalidArgument, "Template name is required")
+	}
+	err := validateParameters(template.Parameters, template.Input, template.Metadata)
+	if err != nil {
+		return err
+	}
+	return validateJobTimes(&Job{MaxRuntime: template.MaxRuntime})
+}
+
+// validateParameters checks the declarations of parameters and that the
+// placeholders in documents only use declared parameters.
+func validateParameters(parameters []*TemplateParameter, documents ...string) error {
+	declared := map[string]bool{}
+	for _, parameter := range parameters {
+		if !parameterNamePattern.MatchString(parameter.Name) {
+			return grpc.Errorf(codes.InvalidArgument, "Parameter names must match %s", parameterNamePattern)
+		}
+		if declared[parameter.Name] {
+			return grpc.Errorf(codes.InvalidArgument, "Parameter %s is declared twice", parameter.Name)
+		}
+		declared[parameter.Name] = true
+		if _, ok := TemplateParameter_Type_name[int32(parameter.Type)]; !ok {
+			return grpc.Errorf(codes.InvalidArgument, "Invalid type %d of parameter %s", parameter.Type, parameter.Name)
+		}
+		if parameter.HasDefault {
+			_, err := parseParameter(parameter, parameter.DefaultValue)
+			if err != nil {
+				return grpc.Errorf(codes.InvalidArgument, "Default of parameter %s is not a valid %s", parameter.Name, parameter.Type)
+			}
+		}
+	}
+	for _, document := range documents {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(document, -1) {
+			if !declared[match[1]] {
+				return grpc.Errorf(codes.InvalidArgument, "Parameter %s is used but not declared", match[1])
+			}
+		}
+	}
+	return nil
+}
+
+// resolveParameters returns the values of parameters, given as text in
+// values or taken from their defaults.
+func resolveParameters(parameters []*TemplateParameter, values map[string]string) (map[string]interface{}, error) {
+	resolved := map[string]interface{}{}
+	for _, parameter := range parameters {
+		text, ok := values[parameter.Name]
+		if !ok {
+			if !parameter.HasDefault {
+				return nil, grpc.Errorf(codes.InvalidArgument, "Parameter %s is required", parameter.Name)
+			}
+			text = parameter.DefaultValue
+		}
+		value, err := parseParameter(parameter, text)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "Parameter %s must be a %s, not %q", parameter.Name, parameter.Type, text)
+		}
+		resolved[parameter.Name] = value
+	}
+	for name := range values {
+		if _, ok := resolved[name]; !ok {
+			return nil, grpc.Errorf(codes.InvalidArgument, "Unknown parameter %s", name)
+		}
+	}
+	return resolved, nil
+}
+
+// renderText replaces the placeholders in text by the text of their values.
+func renderText(text string, values map[string]interface{}) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		return fmt.Sprint(values[placeholderPattern.FindStringSubmatch(placeholder)[1]])
+	})
+}
+
+// renderValue replaces the placeholders in the strings of a decoded JSON
+// value. Strings which are just a placeholder become the typed value.
+func renderValue(value interface{}, values map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		match := placeholderPattern.FindStringSubmatch(v)
+		if match != nil && match[0] == v {
+			return values[match[1]]
+		}
+		return renderText(v, values)
+	case map[string]interface{}:
+		for name, child := range v {
+			v[name] = renderValue(child, values)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = renderValue(child, values)
+		}
+	}
+	return value
+}
+
+// renderDocument renders the input or metadata of a template, as JSON if it
+// is JSON and as text otherwise.
+func renderDocument(document string, values map[string]interface{}) (string, error) {
+	if !json.Valid([]byte(document)) {
+		return renderText(document, values), nil
+	}
+	decoder := json.NewDecoder(strings.NewReader(document))
+	// numbers are kept as written
+	decoder.UseNumber()
+	var decoded interface{}
+	err := decoder.Decode(&decoded)
+	if err != nil {
+		return "", err
+	}
+
+	var rendered bytes.Buffer
+	encoder := json.NewEncoder(&rendered)
+	encoder.SetEscapeHTML(false)
+	err = encoder.Encode(renderValue(decoded, values))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(rendered.String(), "\n"), nil
+}
+
+// renderJobTemplate returns the job described by template with the given
+// parameters.
+func renderJobTemplate(template *JobTemplate, parameters map[string]string) (*Job, error) {
+	values, err := resolveParameters(template.Parameters, parameters)
+	if err != nil {
+		return nil, err
+	}
+	job := &Job{
+		Project:         template.Project,
+		Kind:            template.Kind,
+		MaxRuntime:      template.MaxRuntime,
+		TemplateId:      template.Id,
+		TemplateVersion: template.Version,
+	}
+	job.Input, err = renderDocument(template.Input, values)
+	if err != nil {
+		return nil, err
+	}
+	job.Metadata, err = renderDocument(template.Metadata, values)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+const jobTemplateColumns = `id, project, name, version, template::text, EXTRACT(EPOCH FROM created)::bigint`
+
+// scanJobTemplate reads a row of jobTemplateColumns.
+func scanJobTemplate(row interface {
+	Scan(dest ...interface{}) error
+}) (*JobTemplate, error) {
+	template := &JobTemplate{}
+	var id uint64
+	var project, name, content string
+	var version uint32
+	var created int64
+	err := row.Scan(&id, &project, &name, &version, &content, &created)
+	if err != nil {
+		return nil, err
+	}
+	err = scanJSONB(content, template)
+	if err != nil {
+		return nil, err
+	}
+	template.Id = id
+	template.Project = project
+	template.Name = name
+	template.Version = version
+	template.Created = created
+	return template, nil
+}
+
+// CreateJobTemplate stores template as the next version of the template with
+// its name in its project.
+func (storage *WonderlandStorage) CreateJobTemplate(ctx context.Context, template *JobTemplate, creator User) (created *JobTemplate, err error) {
+	ctx, span := startStorageSpan(ctx, "CreateJobTemplate")
+	defer func() { endSpan(span, err) }()
+
+	// the identity of the template is kept in columns
+	content, err := auditMarshaler.MarshalToString(&JobTemplate{
+		Kind:        template.Kind,
+		Input:       template.Input,
+		Metadata:    template.Metadata,
+		MaxRuntime:  template.MaxRuntime,
+		Parameters:  template.Parameters,
+		Description: template.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1, hashtext($2 || '/' || $3));`,
+		templateLockKey, template.Project, template.Name,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	created, err = scanJobTemplate(tx.QueryRowContext(ctx, `
+		INSERT INTO job_templates (id, project, name, version, template, creator, created)
+		SELECT COALESCE(max(id), nextval('job_templates_id_seq')), $1, $2, COALESCE(max(version), 0) + 1, $3::jsonb, $4, $5
+		FROM job_templates
+		WHERE project=$1 AND name=$2
+		RETURNING `+jobTemplateColumns+`;`,
+		template.Project,
+		template.Name,
+		content,
+		creator.Username,
+		storage.now(),
+	))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, 0, created.Project, nil, created)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(event)
+	return created, nil
+}
+
+// GetJobTemplate returns a version of a template, the latest one if version
+// is 0.
+func (storage *WonderlandStorage) GetJobTemplate(ctx context.Context, project, name string, version uint32) (template *JobTemplate, err error) {
+	ctx, span := startStorageSpan(ctx, "GetJobTemplate")
+	defer func() { endSpan(span, err) }()
+
+	return scanJobTemplate(storage.db.QueryRowContext(ctx, `
+		SELECT `+jobTemplateColumns+`
+		FROM job_templates
+		WHERE project=$1 AND name=$2 AND (version=$3 OR $3=0)
+		ORDER BY version DESC
+		LIMIT 1;`, project, name, version,
+	))
+}
+
+// ListJobTemplates returns the latest version of the templates of project,
+// of every project if it is empty, or every version of the template with the
+// given name, newest first.
+func (storage *WonderlandStorage) ListJobTemplates(ctx context.Context, project, name string) (ret *ListOfJobTemplates, err error) {
+	ctx, span := startStorageSpan(ctx, "ListJobTemplates")
+	defer func() { endSpan(span, err) }()
+
+	strQuery := `
+		SELECT DISTINCT ON (project, name) ` + jobTemplateColumns + `
+		FROM job_templates
+		WHERE project=$1 OR $1=''
+		ORDER BY project, name, version DESC;`
+	args := []interface{}{project}
+	if name != "" {
+		strQuery = `
+			SELECT ` + jobTemplateColumns + `
+			FROM job_templates
+			WHERE project=$1 AND name=$2
+			ORDER BY version DESC;`
+		args = append(args, name)
+	}
+
+	rows, err := storage.db.QueryContext(ctx, strQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret = &ListOfJobTemplates{Templates: []*JobTemplate{}}
+	for rows.Next() {
+		template, err := scanJobTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		ret.Templates = append(ret.Templates, template)
+	}
+	err = rows.Err()
+	return ret, err
+}
+
+func (s *Server) CreateJobTemplate(ctx context.Context, in *JobTemplate) (*JobTemplate, error) {
+	user := getAuthUserFromContext(ctx)
+
+	err := validateJobTemplate(in)
+	if err != nil {
+		return nil, err
+	}
+	if in.Project == "" {
+		project, err := user.onlyProject(PermCreateJobs)
+		if err != nil {
+			return nil, err
+		}
+		in.Project = project
+	}
+	// templates are shared by whoever may create their jobs
+	if !user.Can(PermCreateJobs, in.Project, in.Kind) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Storage.CreateJobTemplate(ctx, in, user)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) ListJobTemplates(ctx context.Context, in *ListJobTemplatesRequest) (*ListOfJobTemplates, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if in.Project == "" && !user.Can(PermListJobs, AnyScope, AnyScope) {
+		project, err := user.onlyProject(PermListJobs)
+		if err != nil {
+			return nil, err
+		}
+		in.Project = project
+	}
+	if !user.Can(PermListJobs, orAnyScope(in.Project), AnyScope) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Storage.ListJobTemplates(ctx, in.Project, in.Name)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+// CreateJobFromTemplate renders a template with the given parameters and
+// creates the job like CreateJob, recording the template and its version in
+// the job.
+func (s *Server) CreateJobFromTemplate(ctx context.Context, in *CreateJobFromTemplateRequest) (*Job, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if !user.MayEver(PermCreateJobs) {
+		return nil, errNoAccess
+	}
+	if in.Project == "" {
+		project, err := user.onlyProject(PermCreateJobs)
+		if err != nil {
+			return nil, err
+		}
+		in.Project = project
+	}
+	// outsiders must not learn which templates exist
+	if !user.MayInProject(PermCreateJobs, in.Project) {
+		return nil, errNoAccess
+	}
+	template, err := s.Storage.GetJobTemplate(ctx, in.Project, in.Name, in.Version)
+	if err == sql.ErrNoRows && !user.Can(PermListJobs, in.Project, AnyScope) {
+		return nil, errNoAccess
+	}
+	if err == sql.ErrNoRows {
+		return nil, grpc.Errorf(codes.NotFound, "Template %s/%s does not exist", in.Project, in.Name)
+	}
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+	if !user.Can(PermCreateJobs, template.Project, template.Kind) {
+		return nil, errNoAccess
+	}
+
+	job, err := renderJobTemplate(template, in.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	job.IdempotencyKey = in.IdempotencyKey
+	job.RunAfter = in.RunAfter
+	return s.createJob(ctx, job)
+}
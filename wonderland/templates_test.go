@@ -0,0 +1,74 @@
+package wonderland
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testJobTemplate() *JobTemplate {
+	return &JobTemplate{
+		Id:       3,
+		Project:  "lhcb",
+		Name:     "simulation",
+		Version:  2,
+		Kind:     "docker",
+		Input:    `{"events": "{{events}}", "output": "/data/{{ run }}-<{{events}}>.root", "verbose": "{{verbose}}"}`,
+		Metadata: `run {{run}}`,
+		Parameters: []*TemplateParameter{
+			{Name: "events", Type: TemplateParameter_INTEGER, DefaultValue: "1000", HasDefault: true},
+			{Name: "run", Type: TemplateParameter_STRING},
+			{Name: "verbose", Type: TemplateParameter_BOOLEAN, DefaultValue: "false", HasDefault: true},
+		},
+	}
+}
+
+func TestValidateJobTemplate(t *testing.T) {
+	checkTestErr(validateJobTemplate(testJobTemplate()), t)
+
+	invalid := []func(*JobTemplate){
+		func(template *JobTemplate) { template.Name = "" },
+		func(template *JobTemplate) { template.Parameters[0].Name = "1st" },
+		func(template *JobTemplate) { template.Parameters[1].Name = "events" },
+		func(template *JobTemplate) { template.Parameters[0].Type = 42 },
+		func(template *JobTemplate) { template.Parameters[0].DefaultValue = "many" },
+		func(template *JobTemplate) { template.Metadata = "{{seed}}" },
+		func(template *JobTemplate) { template.MaxRuntime = -1 },
+	}
+	for i, change := range invalid {
+		template := testJobTemplate()
+		change(template)
+		if status.Code(validateJobTemplate(template)) != codes.InvalidArgument {
+			t.Errorf("change %d should make the template invalid", i)
+		}
+	}
+}
+
+func TestRenderJobTemplate(t *testing.T) {
+	job, err := renderJobTemplate(testJobTemplate(), map[string]string{"run": "2018A", "verbose": "true"})
+	checkTestErr(err, t)
+	expected := &Job{
+		Project:         "lhcb",
+		Kind:            "docker",
+		Input:           `{"events":1000,"output":"/data/2018A-<1000>.root","verbose":true}`,
+		Metadata:        "run 2018A",
+		TemplateId:      3,
+		TemplateVersion: 2,
+	}
+	if job.String() != expected.String() {
+		t.Error(job)
+	}
+
+	invalid := []map[string]string{
+		{},
+		{"run": "2018A", "events": "many"},
+		{"run": "2018A", "seed": "42"},
+	}
+	for _, parameters := range invalid {
+		_, err = renderJobTemplate(testJobTemplate(), parameters)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%v should be invalid", parameters)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package wonderland
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// TLSFiles are the files the server TLS configuration is built from. CRLFile
+// is optional.
+type TLSFiles struct {
+	ServerCert string
+	ServerKey  string
+	CACert     string
+	CRLFile    string
+}
+
+// TLSReloader rebuilds the server TLS configuration whenever one of its files
+// changes, so that certificates can be rotated and revoked without a restart.
+type TLSReloader struct {
+	files      TLSFiles
+	clientAuth tls.ClientAuthType
+
+	mu      sync.RWMutex
+	current *tls.Config
+	modTime map[string]time.Time
+}
+
+func NewTLSReloader(files TLSFiles, clientAuth tls.ClientAuthType) (*TLSReloader, error) {
+	r := &TLSReloader{
+		files:      files,
+		clientAuth: clientAuth,
+	}
+	err := r.reload()
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// TLSConfig returns a configuration that always uses the latest loaded files.
+func (r *TLSReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		ClientAuth: r.clientAuth,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return r.current, nil
+		},
+	}
+}
+
+// Run checks the files for changes every interval until ctx is done. Files
+// that fail to load are logged and the previous configuration is kept.
+func (r *TLSReloader) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !r.changed() {
+			continue
+		}
+		err := r.reload()
+		if err != nil {
+			logrus.WithError(err).Error("Failed to reload TLS files, keeping the previous ones")
+			continue
+		}
+		logrus.Info("Reloaded TLS files")
+	}
+}
+
+func (r *TLSReloader) paths() []string {
+	paths := []string{r.files.ServerCert, r.files.ServerKey, r.files.CACert}
+	if r.files.CRLFile != "" {
+		paths = append(paths, r.files.CRLFile)
+	}
+	return paths
+}
+
+func (r *TLSReloader) changed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, path := range r.paths() {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Equal(r.modTime[path]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *TLSReloader) reload() error {
+	modTime := map[string]time.Time{}
+	for _, path := range r.paths() {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		modTime[path] = info.ModTime()
+	}
+
+	peerCert, err := tls.LoadX509KeyPair(r.files.ServerCert, r.files.ServerKey)
+	if err != nil {
+		return err
+	}
+
+	caCert, err := ioutil.ReadFile(r.files.CACert)
+	if err != nil {
+		return err
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCert) {
+		return fmt.Errorf("no certificates found in %s", r.files.CACert)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{peerCert},
+		ClientCAs:    caCertPool,
+		ClientAuth:   r.clientAuth,
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+
+	if r.files.CRLFile != "" {
+		revoked, err := loadCRL(r.files.CRLFile, caCert)
+		if err != nil {
+			return err
+		}
+		config.VerifyPeerCertificate = func(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+			for _, chain := range verifiedChains {
+				if revoked[serialString(chain[0])] {
+					return fmt.Errorf("certificate %s is revoked", serialString(chain[0]))
+				}
+			}
+			return nil
+		}
+	}
+
+	r.mu.Lock()
+	r.current = config
+	r.modTime = modTime
+	r.mu.Unlock()
+	return nil
+}
+
+// loadCRL returns the serials listed in a PEM or DER encoded CRL, which has to
+// be signed by one of the CA certificates.
+func loadCRL(path string, caPEM []byte) (map[string]bool, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(content); block != nil {
+		content = block.Bytes
+	}
+	crl, err := x509.ParseRevocationList(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CRL %s: %v", path, err)
+	}
+
+	signed := false
+	for block, rest := pem.Decode(caPEM); block != nil; block, rest = pem.Decode(rest) {
+		ca, err := x509.ParseCertificate(block.Bytes)
+		if err == nil && crl.CheckSignatureFrom(ca) == nil {
+			signed = true
+			break
+		}
+	}
+	if !signed {
+		return nil, fmt.Errorf("CRL %s is not signed by the CA", path)
+	}
+
+	revoked := map[string]bool{}
+	for _, entry := range crl.RevokedCertificateEntries {
+		revoked[entry.SerialNumber.Text(16)] = true
+	}
+	return revoked, nil
+}
+
+// serialString formats the serial number of cert the way it is stored in the
+// denylist: lowercase hexadecimal without separators.
+func serialString(cert *x509.Certificate) string {
+	return cert.SerialNumber.Text(16)
+}
@@ -0,0 +1,174 @@
+package wonderland
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	checkTestErr(err, t)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "wonderland"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	checkTestErr(err, t)
+	cert, err := x509.ParseCertificate(der)
+	checkTestErr(err, t)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a PEM encoded certificate and key signed by the CA.
+func (ca *testCA) issue(t *testing.T, serial int64, cn string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	checkTestErr(err, t)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"ship-shield.ANY"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	checkTestErr(err, t)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	checkTestErr(err, t)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func (ca *testCA) crl(t *testing.T, serials ...int64) []byte {
+	template := &x509.RevocationList{Number: big.NewInt(1), ThisUpdate: time.Now(), NextUpdate: time.Now().Add(time.Hour)}
+	for _, serial := range serials {
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries,
+			x509.RevocationListEntry{SerialNumber: big.NewInt(serial), RevocationTime: time.Now()})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, template, ca.cert, ca.key)
+	checkTestErr(err, t)
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+}
+
+func writeTestFile(t *testing.T, path string, content []byte, modTime time.Time) {
+	checkTestErr(ioutil.WriteFile(path, content, 0600), t)
+	checkTestErr(os.Chtimes(path, modTime, modTime), t)
+}
+
+func TestTLSReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wonderland-tls")
+	checkTestErr(err, t)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCA(t)
+	files := TLSFiles{
+		ServerCert: filepath.Join(dir, "server.crt"),
+		ServerKey:  filepath.Join(dir, "server.key"),
+		CACert:     filepath.Join(dir, "ca.crt"),
+		CRLFile:    filepath.Join(dir, "ca.crl"),
+	}
+	past := time.Now().Add(-time.Minute)
+	certPEM, keyPEM := ca.issue(t, 10, "server")
+	writeTestFile(t, files.ServerCert, certPEM, past)
+	writeTestFile(t, files.ServerKey, keyPEM, past)
+	writeTestFile(t, files.CACert, ca.pem, past)
+	writeTestFile(t, files.CRLFile, ca.crl(t, 12), past)
+
+	reloader, err := NewTLSReloader(files, tls.RequireAndVerifyClientCert)
+	checkTestErr(err, t)
+	if reloader.changed() {
+		t.Fail()
+	}
+
+	config, err := reloader.TLSConfig().GetConfigForClient(nil)
+	checkTestErr(err, t)
+	leaf, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+	checkTestErr(err, t)
+	if leaf.SerialNumber.Int64() != 10 {
+		t.Fail()
+	}
+
+	client, _ := ca.issue(t, 11, "client")
+	revoked, _ := ca.issue(t, 12, "revoked")
+	for serial, certPEM := range map[int64][]byte{11: client, 12: revoked} {
+		block, _ := pem.Decode(certPEM)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		checkTestErr(err, t)
+		err = config.VerifyPeerCertificate(nil, [][]*x509.Certificate{{cert, ca.cert}})
+		if (err != nil) != (serial == 12) {
+			t.Errorf("unexpected verification result for serial %d: %v", serial, err)
+		}
+	}
+
+	// rotate the server certificate
+	certPEM, keyPEM = ca.issue(t, 20, "server")
+	writeTestFile(t, files.ServerCert, certPEM, time.Now())
+	writeTestFile(t, files.ServerKey, keyPEM, time.Now())
+	if !reloader.changed() {
+		t.Fail()
+	}
+	checkTestErr(reloader.reload(), t)
+
+	config, err = reloader.TLSConfig().GetConfigForClient(nil)
+	checkTestErr(err, t)
+	leaf, err = x509.ParseCertificate(config.Certificates[0].Certificate[0])
+	checkTestErr(err, t)
+	if leaf.SerialNumber.Int64() != 20 {
+		t.Fail()
+	}
+
+	// a CRL signed by another CA is refused
+	writeTestFile(t, files.CRLFile, newTestCA(t).crl(t, 11), time.Now())
+	if reloader.reload() == nil {
+		t.Fail()
+	}
+}
+
+func TestRevokedCertificateIsRejected(t *testing.T) {
+	s := &Server{Revocations: NewRevocationList()}
+	cert := &x509.Certificate{
+		SerialNumber: big.NewInt(0xab),
+		Subject:      pkix.Name{CommonName: "alice", Organization: []string{"ship-shield.ANY"}},
+	}
+
+	_, err := s.authenticate(context.Background(), cert)
+	checkTestErr(err, t)
+
+	serial, err := normalizeSerial("00:AB")
+	checkTestErr(err, t)
+	s.Revocations.set(serial, true)
+	_, err = s.authenticate(context.Background(), cert)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fail()
+	}
+
+	_, err = normalizeSerial("not-hex")
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fail()
+	}
+}
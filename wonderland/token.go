@@ -0,0 +1,261 @@
+package wonderland
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	defaultTokenTTL    = time.Hour
+	defaultTokenMaxTTL = 7 * 24 * time.Hour
+)
+
+// TokenConfig configures bearer token authentication. HS256 tokens are
+// checked with the server SecretKey, RS256 and ES256 tokens with the PEM
+// encoded PublicKeys or the keys of a JWKS file.
+type TokenConfig struct {
+	Audience   string        `yaml:"audience"`
+	Issuer     string        `yaml:"issuer"`
+	PublicKeys []string      `yaml:"public_keys"`
+	JWKSFile   string        `yaml:"jwks_file"`
+	MaxTTL     time.Duration `yaml:"max_ttl"`
+}
+
+// TokenClaims are the claims of Wonderland bearer tokens. Subject becomes
+// the username, and each of Grants ("project.kind") is mapped to a role the
+// same way certificate grants are.
+type TokenClaims struct {
+	Grants []string `json:"grants"`
+	jwt.StandardClaims
+}
+
+// TokenAuth verifies and issues bearer tokens.
+type TokenAuth struct {
+	secretKey  []byte
+	config     TokenConfig
+	publicKeys []interface{}
+	jwks       map[string]interface{}
+}
+
+func NewTokenAuth(secretKey []byte, config TokenConfig) (*TokenAuth, error) {
+	if config.MaxTTL == 0 {
+		config.MaxTTL = defaultTokenMaxTTL
+	}
+	a := &TokenAuth{
+		secretKey: secretKey,
+		config:    config,
+		jwks:      map[string]interface{}{},
+	}
+
+	for _, path := range config.PublicKeys {
+		key, err := loadPublicKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("loading token key %s: %v", path, err)
+		}
+		a.publicKeys = append(a.publicKeys, key)
+	}
+
+	if config.JWKSFile != "" {
+		content, err := ioutil.ReadFile(config.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		a.jwks, err = parseJWKS(content)
+		if err != nil {
+			return nil, fmt.Errorf("loading JWKS %s: %v", config.JWKSFile, err)
+		}
+	}
+	return a, nil
+}
+
+func loadPublicKey(path string) (interface{}, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(content); err == nil {
+		return key, nil
+	}
+	key, err := jwt.ParseECPublicKeyFromPEM(content)
+	if err != nil {
+		return nil, err
+	}
+	// ES256 is only defined on P-256
+	if key.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("EC key is on %s, not P-256", key.Curve.Params().Name)
+	}
+	return key, nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS returns the RSA and P-256 keys of a JSON Web Key Set by key id.
+func parseJWKS(content []byte) (map[string]interface{}, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	err := json.Unmarshal(content, &set)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]interface{}{}
+	for _, k := range set.Keys {
+		switch {
+		case k.Kty == "RSA":
+			n, err := decodeJWKInt(k.N)
+			if err != nil {
+				return nil, err
+			}
+			e, err := decodeJWKInt(k.E)
+			if err != nil {
+				return nil, err
+			}
+			keys[k.Kid] = &rsa.PublicKey{N: n, E: int(e.Int64())}
+		case k.Kty == "EC" && k.Crv == "P-256":
+			x, err := decodeJWKInt(k.X)
+			if err != nil {
+				return nil, err
+			}
+			y, err := decodeJWKInt(k.Y)
+			if err != nil {
+				return nil, err
+			}
+			if !elliptic.P256().IsOnCurve(x, y) {
+				return nil, fmt.Errorf("key %s is not a point on P-256", k.Kid)
+			}
+			keys[k.Kid] = &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		}
+	}
+	return keys, nil
+}
+
+func decodeJWKInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// keyFor returns the key checking the signature of token. The key type has
+// to match the signing method, so that a public key can never be used as an
+// HMAC secret.
+func (a *TokenAuth) keyFor(token *jwt.Token) (interface{}, error) {
+	switch token.Method {
+	case jwt.SigningMethodHS256:
+		if len(a.secretKey) == 0 {
+			return nil, fmt.Errorf("HS256 tokens are not accepted")
+		}
+		return a.secretKey, nil
+	case jwt.SigningMethodRS256, jwt.SigningMethodES256:
+	default:
+		return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+	}
+
+	candidates := a.publicKeys
+	if kid, ok := token.Header["kid"].(string); ok {
+		if key, ok := a.jwks[kid]; ok {
+			candidates = append([]interface{}{key}, candidates...)
+		}
+	}
+	for _, key := range candidates {
+		switch k := key.(type) {
+		case *rsa.PublicKey:
+			if token.Method == jwt.SigningMethodRS256 {
+				return key, nil
+			}
+		case *ecdsa.PublicKey:
+			if token.Method == jwt.SigningMethodES256 && k.Curve == elliptic.P256() {
+				return key, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no key for %v token", token.Header["alg"])
+}
+
+// Verify checks the signature, expiry, audience and issuer of a token.
+func (a *TokenAuth) Verify(tokenString string) (*TokenClaims, error) {
+	claims := &TokenClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, a.keyFor)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claims.VerifyExpiresAt(jwt.TimeFunc().Unix(), true) {
+		return nil, fmt.Errorf("token has no expiry")
+	}
+	if a.config.Audience != "" && !claims.VerifyAudience(a.config.Audience, true) {
+		return nil, fmt.Errorf("token audience is not %q", a.config.Audience)
+	}
+	if a.config.Issuer != "" && !claims.VerifyIssuer(a.config.Issuer, true) {
+		return nil, fmt.Errorf("token issuer is not %q", a.config.Issuer)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("token has no subject")
+	}
+	return claims, nil
+}
+
+// Issue signs an HS256 token for subject with the given grants.
+func (a *TokenAuth) Issue(subject string, grants []string, ttl time.Duration) (string, time.Time, error) {
+	if len(a.secretKey) == 0 {
+		return "", time.Time{}, grpc.Errorf(codes.FailedPrecondition, "Issuing tokens needs a secret key")
+	}
+	if ttl == 0 {
+		ttl = defaultTokenTTL
+	}
+	if ttl < 0 || ttl > a.config.MaxTTL {
+		return "", time.Time{}, grpc.Errorf(codes.InvalidArgument, "Token lifetime must be at most %v", a.config.MaxTTL)
+	}
+
+	now := jwt.TimeFunc()
+	expiresAt := now.Add(ttl)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &TokenClaims{
+		Grants: grants,
+		StandardClaims: jwt.StandardClaims{
+			Subject:   subject,
+			Audience:  a.config.Audience,
+			Issuer:    a.config.Issuer,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+	})
+	signed, err := token.SignedString(a.secretKey)
+	return signed, expiresAt, err
+}
+
+// userFromToken returns the user a token was issued for. Unlike certificate
+// users, token users get no stored role bindings: a token is limited to the
+// grants it carries.
+func userFromToken(claims *TokenClaims) (User, error) {
+	user := User{Username: claims.Subject}
+	for _, field := range claims.Grants {
+		projectAccess, kindAccess, err := parseCertificateFields(field)
+		if err != nil {
+			return User{}, grpc.Errorf(codes.Unauthenticated, "Invalid grant %q in token", field)
+		}
+		user.Grants = append(user.Grants, Grant{Project: projectAccess, Kind: kindAccess})
+		user.Bindings = append(user.Bindings, certificateBinding(user.Username, projectAccess, kindAccess))
+	}
+	return user, nil
+}
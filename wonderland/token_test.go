@@ -0,0 +1,191 @@
+package wonderland
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testSecretKey = []byte("test-secret")
+
+func testClaims(audience string, expiresIn time.Duration) *TokenClaims {
+	return &TokenClaims{
+		Grants: []string{"ship-shield.ANY"},
+		StandardClaims: jwt.StandardClaims{
+			Subject:   "ci",
+			Audience:  audience,
+			ExpiresAt: time.Now().Add(expiresIn).Unix(),
+		},
+	}
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims *TokenClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = "test"
+	signed, err := token.SignedString(key)
+	checkTestErr(err, t)
+	return signed
+}
+
+func TestTokenIssueAndVerify(t *testing.T) {
+	auth, err := NewTokenAuth(testSecretKey, TokenConfig{Audience: "wonderland"})
+	checkTestErr(err, t)
+
+	token, expiresAt, err := auth.Issue("ci", []string{"ship-shield.ANY", "ANY.docker"}, time.Minute)
+	checkTestErr(err, t)
+	if time.Until(expiresAt) > time.Minute {
+		t.Fail()
+	}
+
+	claims, err := auth.Verify(token)
+	checkTestErr(err, t)
+	user, err := userFromToken(claims)
+	checkTestErr(err, t)
+	if user.Username != "ci" || !user.Can(PermCreateJobs, "ship-shield", "docker") || !user.Can(PermPullJobs, AnyScope, "docker") {
+		t.Fail()
+	}
+	if user.Can(PermCreateJobs, "opera", "docker") {
+		t.Fail()
+	}
+
+	_, _, err = auth.Issue("ci", []string{"ship-shield.ANY"}, 30*24*time.Hour)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fail()
+	}
+}
+
+func TestTokenRejected(t *testing.T) {
+	auth, err := NewTokenAuth(testSecretKey, TokenConfig{Audience: "wonderland"})
+	checkTestErr(err, t)
+
+	valid := signTestToken(t, jwt.SigningMethodHS256, testSecretKey, testClaims("wonderland", time.Hour))
+	parts := strings.Split(valid, ".")
+	tamperedClaims := base64.RawURLEncoding.EncodeToString([]byte(`{"grants":["ANY.ANY"],"sub":"ci","aud":"wonderland","exp":4102444800}`))
+
+	noExpiry := testClaims("wonderland", 0)
+	noExpiry.ExpiresAt = 0
+
+	tokens := map[string]string{
+		"expired":        signTestToken(t, jwt.SigningMethodHS256, testSecretKey, testClaims("wonderland", -time.Minute)),
+		"wrong audience": signTestToken(t, jwt.SigningMethodHS256, testSecretKey, testClaims("other", time.Hour)),
+		"wrong key":      signTestToken(t, jwt.SigningMethodHS256, []byte("other-secret"), testClaims("wonderland", time.Hour)),
+		"tampered":       parts[0] + "." + tamperedClaims + "." + parts[2],
+		"unsigned":       signTestToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, testClaims("wonderland", time.Hour)),
+		"no expiry":      signTestToken(t, jwt.SigningMethodHS256, testSecretKey, noExpiry),
+		"garbage":        "not-a-token",
+	}
+	for name, token := range tokens {
+		_, err := auth.Verify(token)
+		if err == nil {
+			t.Errorf("%s token was accepted", name)
+		}
+	}
+	_, err = auth.Verify(valid)
+	checkTestErr(err, t)
+}
+
+func TestTokenPublicKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wonderland-tokens")
+	checkTestErr(err, t)
+	defer os.RemoveAll(dir)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	checkTestErr(err, t)
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	checkTestErr(err, t)
+	pemPath := filepath.Join(dir, "rsa.pem")
+	checkTestErr(ioutil.WriteFile(pemPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600), t)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	checkTestErr(err, t)
+	jwks, err := json.Marshal(map[string]interface{}{"keys": []map[string]string{{
+		"kty": "EC",
+		"kid": "test",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(ecKey.X.Bytes()),
+		"y":   base64.RawURLEncoding.EncodeToString(ecKey.Y.Bytes()),
+	}}})
+	checkTestErr(err, t)
+	jwksPath := filepath.Join(dir, "jwks.json")
+	checkTestErr(ioutil.WriteFile(jwksPath, jwks, 0600), t)
+
+	auth, err := NewTokenAuth(nil, TokenConfig{PublicKeys: []string{pemPath}, JWKSFile: jwksPath})
+	checkTestErr(err, t)
+
+	_, err = auth.Verify(signTestToken(t, jwt.SigningMethodRS256, rsaKey, testClaims("", time.Hour)))
+	checkTestErr(err, t)
+	_, err = auth.Verify(signTestToken(t, jwt.SigningMethodES256, ecKey, testClaims("", time.Hour)))
+	checkTestErr(err, t)
+
+	// the public key must not be usable as an HMAC secret
+	_, err = auth.Verify(signTestToken(t, jwt.SigningMethodHS256, der, testClaims("", time.Hour)))
+	if err == nil {
+		t.Fail()
+	}
+}
+
+func TestIssueTokenRequiresBindingAccess(t *testing.T) {
+	auth, err := NewTokenAuth(testSecretKey, TokenConfig{})
+	checkTestErr(err, t)
+	s := &Server{Tokens: auth}
+
+	submitter := User{Bindings: []*RoleBinding{certificateBinding("alice", "ship-shield", AnyScope)}}
+	ctx := context.WithValue(context.Background(), "authorized-user", submitter)
+	_, err = s.IssueToken(ctx, &IssueTokenRequest{Subject: "ci", Grants: []string{"ship-shield.ANY"}})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fail()
+	}
+
+	admin := User{Bindings: []*RoleBinding{{Principal: "bob", Role: string(RoleProjectAdmin), Project: "ship-shield", Kind: AnyScope}}}
+	ctx = context.WithValue(context.Background(), "authorized-user", admin)
+	token, err := s.IssueToken(ctx, &IssueTokenRequest{Subject: "ci", Grants: []string{"ship-shield.ANY"}})
+	checkTestErr(err, t)
+	if token == nil || token.Token == "" {
+		t.FailNow()
+	}
+	_, err = s.IssueToken(ctx, &IssueTokenRequest{Subject: "ci", Grants: []string{"ANY.ANY"}})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fail()
+	}
+}
+
+func TestTokenKeysOnP256Only(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wonderland-tokens")
+	checkTestErr(err, t)
+	defer os.RemoveAll(dir)
+
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	checkTestErr(err, t)
+	der, err := x509.MarshalPKIXPublicKey(&p384Key.PublicKey)
+	checkTestErr(err, t)
+	pemPath := filepath.Join(dir, "p384.pem")
+	checkTestErr(ioutil.WriteFile(pemPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600), t)
+	_, err = NewTokenAuth(nil, TokenConfig{PublicKeys: []string{pemPath}})
+	if err == nil {
+		t.Error("P-384 key accepted for ES256")
+	}
+
+	// P-384 coordinates given as a P-256 key
+	_, err = parseJWKS([]byte(`{"keys": [{"kty": "EC", "kid": "test", "crv": "P-256", "x": "` +
+		base64.RawURLEncoding.EncodeToString(p384Key.X.Bytes()) + `", "y": "` +
+		base64.RawURLEncoding.EncodeToString(p384Key.Y.Bytes()) + `"}]}`))
+	if err == nil {
+		t.Error("point off P-256 accepted")
+	}
+}
@@ -0,0 +1,139 @@
+package wonderland
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/context"
+)
+
+const tracerName = "github.com/wonderlandcompute/server/wonderland"
+
+// TracingConfig selects where spans are exported to. Exporter is one of
+// "stdout", "file" (pretty printed JSON written to File) or "otlp" (an
+// OpenTelemetry collector listening on Endpoint). Tracing is disabled when
+// Exporter is empty.
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter"`
+	File        string  `yaml:"file"`
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+func newSpanExporter(ctx context.Context, config TracingConfig) (sdktrace.SpanExporter, io.Closer, error) {
+	switch config.Exporter {
+	case "stdout":
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		return exporter, nil, err
+	case "file":
+		f, err := os.OpenFile(config.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, nil, err
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		return exporter, f, err
+	case "otlp":
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(ctx, opts...)
+		return exporter, nil, err
+	}
+	return nil, nil, fmt.Errorf("unknown tracing exporter %q", config.Exporter)
+}
+
+// SetupTracing installs the global tracer provider and the W3C trace context
+// propagator. The returned function flushes pending spans and must be called
+// before exiting.
+func SetupTracing(ctx context.Context, config TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if config.Exporter == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, closer, err := newSpanExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	sampler := sdktrace.AlwaysSample()
+	if config.SampleRatio > 0 {
+		sampler = sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sampler),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			closer.Close()
+		}
+		return err
+	}, nil
+}
+
+func startStorageSpan(ctx context.Context, statement string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "storage."+statement,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", statement),
+		),
+	)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// traceParent returns the W3C traceparent header of the span in ctx, or an
+// empty string when ctx is not being traced.
+func traceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// linkJobTrace records a span linked to the trace in which job was created,
+// so that its pull and completion can be found from the submission and the
+// other way around.
+func linkJobTrace(ctx context.Context, job *Job) {
+	parent := trace.SpanFromContext(ctx)
+	if !parent.IsRecording() {
+		return
+	}
+
+	opts := []trace.SpanStartOption{
+		trace.WithAttributes(
+			attribute.Int64("wonderland.job.id", int64(job.Id)),
+			attribute.String("wonderland.job.status", job.Status.String()),
+		),
+	}
+	submission := trace.SpanContextFromContext(
+		propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{"traceparent": job.TraceParent}),
+	)
+	if submission.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: submission}))
+	}
+
+	_, span := parent.TracerProvider().Tracer(tracerName).Start(ctx, "job", opts...)
+	span.End()
+}
@@ -0,0 +1,43 @@
+package wonderland
+
+import (
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"golang.org/x/net/context"
+)
+
+func TestLinkJobTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	submitCtx, submitSpan := tracer.Start(context.Background(), "submit")
+	job := &Job{Id: 42, TraceParent: traceParent(submitCtx)}
+	submitSpan.End()
+	if job.TraceParent == "" {
+		t.Fatal("traceparent was not recorded")
+	}
+
+	pullCtx, pullSpan := tracer.Start(context.Background(), "pull")
+	linkJobTrace(pullCtx, job)
+	pullSpan.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	link := spans[1]
+	if link.Parent().SpanID() != pullSpan.SpanContext().SpanID() {
+		t.Fail()
+	}
+	if len(link.Links()) != 1 || link.Links()[0].SpanContext.SpanID() != submitSpan.SpanContext().SpanID() {
+		t.Fail()
+	}
+}
+
+func TestTraceParentWithoutSpan(t *testing.T) {
+	if traceParent(context.Background()) != "" {
+		t.Fail()
+	}
+}
@@ -0,0 +1,37 @@
+package wonderland
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// PurgeTrash removes jobs deleted more than retention ago every interval
+// until ctx is done. Until then deleted jobs can be restored with
+// UndeleteJob. Expired idempotency keys are dropped along the way.
+func (storage *WonderlandStorage) PurgeTrash(ctx context.Context, retention time.Duration, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// purges are recorded in the audit log like API changes
+	ctx = withRequestInfo(ctx, "purge-trash", "")
+	for {
+		purged, err := storage.PurgeDeletedJobs(ctx, storage.now().Add(-retention))
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to purge deleted jobs")
+		} else if purged > 0 {
+			logrus.WithField("jobs", purged).Info("Purged deleted jobs")
+		}
+		err = storage.DeleteExpiredIdempotencyKeys(ctx)
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to delete expired idempotency keys")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
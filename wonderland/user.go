@@ -1,34 +1,100 @@
 package wonderland
 
+import (
+	"crypto/x509"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// Grant gives access to the jobs of Project and Kind, as carried by a client
+// certificate in the "project.kind" convention.
+type Grant struct {
+	Project string
+	Kind    string
+}
+
 type User struct {
-	Username      string
-	ProjectAccess string
-	KindAccess    string
+	Username string
+	Grants   []Grant
+	// Certificate is the client certificate the user authenticated with,
+	// nil for token users.
+	Certificate *x509.Certificate
+
+	// Bindings holds the roles derived from Grants followed by the role
+	// bindings stored for Username.
+	Bindings []*RoleBinding
 }
 
-func (u *User) IsUser() bool {
-	// if user
-	if u.ProjectAccess != "ANY" {
-		return true
+// Can tells whether any of the user's roles grants perm on jobs of the given
+// project and kind.
+func (u *User) Can(perm Permission, project string, kind string) bool {
+	for _, b := range u.Bindings {
+		if b.hasPermission(perm) && scopeMatches(b.Project, project) && scopeMatches(b.Kind, kind) {
+			return true
+		}
 	}
 	return false
 }
-func (u *User) IsWorker() bool {
-	// if worker
-	if u.ProjectAccess == "ANY" && u.KindAccess != "ANY" {
-		return true
+
+// MayEver tells whether any of the user's roles grants perm, regardless of
+// scope. It lets requests be rejected before the job they refer to is read.
+func (u *User) MayEver(perm Permission) bool {
+	for _, b := range u.Bindings {
+		if b.hasPermission(perm) {
+			return true
+		}
 	}
 	return false
 }
 
-func (u *User) CanAccessJob(in *Job) bool {
-	// if worker
-	if u.IsWorker() && in.Kind != u.KindAccess {
-		return false
+// MayInProject tells whether any of the user's roles grants perm on some of
+// the jobs of project.
+func (u *User) MayInProject(perm Permission, project string) bool {
+	for _, b := range u.Bindings {
+		if b.hasPermission(perm) && scopeMatches(b.Project, project) {
+			return true
+		}
 	}
-	// if user
-	if u.IsUser() && in.Project != u.ProjectAccess {
-		return false
+	return false
+}
+
+// onlyProject returns the project of a request that does not name one: the
+// single project in which the user has perm. Users with access to several
+// projects have to choose.
+func (u *User) onlyProject(perm Permission) (string, error) {
+	if !u.MayEver(perm) {
+		return "", errNoAccess
+	}
+
+	project := ""
+	for _, b := range u.Bindings {
+		if !b.hasPermission(perm) || b.Project == AnyScope || b.Project == project {
+			continue
+		}
+		if project != "" {
+			return "", grpc.Errorf(codes.InvalidArgument, "Project is required, access is granted to several projects")
+		}
+		project = b.Project
+	}
+	if project == "" {
+		return "", grpc.Errorf(codes.InvalidArgument, "Project is required")
+	}
+	return project, nil
+}
+
+// defaultKind returns the kind of jobs pulled by the user when the request
+// does not name one: the single kind they may pull, if any.
+func (u *User) defaultKind() string {
+	kind := ""
+	for _, b := range u.Bindings {
+		if !b.hasPermission(PermPullJobs) || b.Kind == AnyScope || b.Kind == kind {
+			continue
+		}
+		if kind != "" {
+			return ""
+		}
+		kind = b.Kind
 	}
-	return true
+	return kind
 }
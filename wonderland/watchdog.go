@@ -0,0 +1,185 @@
+package wonderland
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// watchdogBatchSize is how many overdue jobs are finished per transaction.
+const watchdogBatchSize = 1000
+
+// validateJobTimes checks the scheduling fields of a new job.
+func validateJobTimes(job *Job) error {
+	if job.RunAfter < 0 || job.Deadline < 0 {
+		return grpc.Errorf(codes.InvalidArgument, "run_after and deadline must not be negative")
+	}
+	if job.MaxRuntime < 0 {
+		return grpc.Errorf(codes.InvalidArgument, "max_runtime must not be negative")
+	}
+	if job.Deadline != 0 && job.Deadline <= job.RunAfter {
+		return grpc.Errorf(codes.InvalidArgument, "deadline must be after run_after")
+	}
+	return nil
+}
+
+// errJobStopped tells workers to stop working on a job the server has
+// failed or expired.
+func errJobStopped(job *Job) error {
+	return grpc.Errorf(codes.FailedPrecondition, "Job %d is %s: %s", job.Id, job.Status, job.FailureReason)
+}
+
+func maxRuntimeExceeded(job *Job) *JobError {
+	return &JobError{
+		Code:      "MAX_RUNTIME_EXCEEDED",
+		Message:   fmt.Sprintf("max_runtime of %v exceeded", time.Duration(job.MaxRuntime)*time.Second),
+		Retryable: true,
+	}
+}
+
+func deadlinePassed(job *Job) *JobError {
+	return &JobError{
+		Code:    "DEADLINE_PASSED",
+		Message: "deadline passed before the job was pulled",
+	}
+}
+
+// RunWatchdog fails jobs running longer than their max_runtime and expires
+// pending jobs past their deadline every interval until ctx is done.
+func (storage *WonderlandStorage) RunWatchdog(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// the watchdog is recorded in the audit log like API changes
+	ctx = withRequestInfo(ctx, "watchdog", "")
+	for {
+		failed, expired, err := storage.FinishOverdueJobs(ctx)
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to finish overdue jobs")
+		} else if failed > 0 || expired > 0 {
+			logrus.WithFields(logrus.Fields{"failed": failed, "expired": expired}).Info("Finished overdue jobs")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// FinishOverdueJobs fails the pulled and running jobs which exceeded their
+// max_runtime and expires the pending jobs whose deadline passed.
+func (storage *WonderlandStorage) FinishOverdueJobs(ctx context.Context) (failed int, expired int, err error) {
+	now := storage.now()
+	for {
+		args := []interface{}{pq.Array([]int64{int64(Job_PULLED), int64(Job_RUNNING)}), now}
+		n, err := storage.finishJobs(ctx, `status = ANY($1) AND max_runtime > 0
+			AND pulled_at + max_runtime * interval '1 second' < $2`, args, Job_FAILED, maxRuntimeExceeded)
+		failed += n
+		if err != nil {
+			return failed, expired, err
+		}
+		if n < watchdogBatchSize {
+			break
+		}
+	}
+	for {
+		args := []interface{}{Job_PENDING, now}
+		n, err := storage.finishJobs(ctx, `status=$1 AND deadline < $2`, args, Job_EXPIRED, deadlinePassed)
+		expired += n
+		if err != nil {
+			return failed, expired, err
+		}
+		if n < watchdogBatchSize {
+			break
+		}
+	}
+	return failed, expired, nil
+}
+
+// finishJobs sets the status of a batch of jobs matching condition, an SQL
+// condition with the given arguments, recording the reason.
+func (storage *WonderlandStorage) finishJobs(ctx context.Context, condition string, args []interface{}, status Job_Status, reason func(*Job) *JobError) (finished int, err error) {
+	ctx, span := startStorageSpan(ctx, "FinishJobs")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	args = append(args, watchdogBatchSize)
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+jobColumns+`
+		FROM jobs
+		WHERE deleted_at IS NULL AND `+condition+`
+		ORDER BY id
+		LIMIT $`+strconv.Itoa(len(args))+`
+		FOR UPDATE SKIP LOCKED;`, args...)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	overdue, err := queryJobs(rows)
+	if err == nil {
+		err = rows.Err()
+	}
+	rows.Close()
+	if err != nil || len(overdue.Jobs) == 0 {
+		tx.Rollback()
+		return 0, err
+	}
+
+	now := storage.now()
+	events := []*AuditEvent{}
+	jobs := []*Job{}
+	for _, before := range overdue.Jobs {
+		jobError := reason(before)
+		jobError.Worker = "server"
+		var content interface{}
+		content, err = jobErrorJSON(jobError)
+		job := &Job{}
+		if err == nil {
+			err = tx.QueryRowContext(ctx, `
+				UPDATE jobs
+				SET
+					status=$1,
+					failure_reason=$2,
+					error=$3::jsonb,
+					last_modified=$4
+				WHERE id=$5
+				RETURNING `+jobColumns+`;`, status, jobError.Message, content, now, before.Id,
+			).Scan(jobFields(job)...)
+		}
+		var event *AuditEvent
+		if err == nil {
+			event, err = recordAuditEvent(ctx, tx, job.Id, job.Project, before, job)
+		}
+		if err == nil {
+			err = storage.enqueueWebhookDeliveries(ctx, tx, before, job)
+		}
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		events = append(events, event)
+		jobs = append(jobs, job)
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	storage.exportAuditEvents(events...)
+	for i, job := range jobs {
+		countFinishedJob(overdue.Jobs[i], job)
+	}
+	return len(jobs), nil
+}
@@ -0,0 +1,32 @@
+package wonderland
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateJobTimes(t *testing.T) {
+	checkTestErr(validateJobTimes(&Job{}), t)
+	checkTestErr(validateJobTimes(&Job{RunAfter: 1000, Deadline: 2000, MaxRuntime: 60}), t)
+
+	invalid := []*Job{
+		{MaxRuntime: -1},
+		{Deadline: -1},
+		{RunAfter: 2000, Deadline: 1000},
+	}
+	for _, job := range invalid {
+		if status.Code(validateJobTimes(job)) != codes.InvalidArgument {
+			t.Log(job)
+			t.Fail()
+		}
+	}
+}
+
+func TestMaxRuntimeExceeded(t *testing.T) {
+	if reason := maxRuntimeExceeded(&Job{MaxRuntime: 90}); reason.Message != "max_runtime of 1m30s exceeded" {
+		t.Log(reason.Message)
+		t.Fail()
+	}
+}
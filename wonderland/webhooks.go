@@ -0,0 +1,855 @@
+package wonderland
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	defaultWebhookMaxAttempts = 10
+	defaultWebhookBackoff     = 10 * time.Second
+	maxWebhookBackoff         = time.Hour
+	webhookBatchSize          = 10
+	webhookTimeout            = 10 * time.Second
+	// delivered and dead deliveries are kept for defaultWebhookDeliveryRetention,
+	// pruned every webhookPruneInterval
+	defaultWebhookDeliveryRetention = 7 * 24 * time.Hour
+	webhookPruneInterval            = time.Hour
+	// webhookLease is how long a claimed delivery is hidden from other
+	// replicas while it is being sent. The deliveries of a batch are sent one
+	// after another, so it covers sending all of them plus a margin for
+	// recording the outcomes.
+	webhookLease = webhookBatchSize*webhookTimeout + time.Minute
+
+	// WebhookSignatureHeader carries "sha256=" and the hex HMAC-SHA256 of
+	// the request body keyed with the secret of the webhook.
+	WebhookSignatureHeader = "X-Wonderland-Signature"
+	WebhookDeliveryHeader  = "X-Wonderland-Delivery"
+)
+
+// validateWebhook checks a new webhook and generates its secret if missing.
+func validateWebhook(webhook *Webhook) error {
+	target, err := url.Parse(webhook.Url)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return grpc.Errorf(codes.InvalidArgument, "Webhook URL must be an absolute http or https URL")
+	}
+	for _, status := range webhook.Statuses {
+		if _, ok := Job_Status_name[int32(status)]; !ok {
+			return grpc.Errorf(codes.InvalidArgument, "Invalid status %d", status)
+		}
+	}
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+		_, err = rand.Read(secret)
+		if err != nil {
+			return err
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+	return nil
+}
+
+// webhookMatches tells whether job passes the filters of webhook.
+func webhookMatches(webhook *Webhook, job *Job) bool {
+	if len(webhook.Statuses) > 0 {
+		found := false
+		for _, status := range webhook.Statuses {
+			found = found || status == job.Status
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(webhook.Kinds) > 0 {
+		found := false
+		for _, kind := range webhook.Kinds {
+			found = found || kind == job.Kind
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(webhook.Labels) > 0 {
+		metadata := map[string]interface{}{}
+		if json.Unmarshal([]byte(job.Metadata), &metadata) != nil {
+			return false
+		}
+		for name, value := range webhook.Labels {
+			if metadata[name] != value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// webhookPayload is the body POSTed to webhooks.
+type webhookPayload struct {
+	Event          string          `json:"event"`
+	PreviousStatus string          `json:"previous_status,omitempty"`
+	Job            json.RawMessage `json:"job"`
+}
+
+// webhookBackoff returns how long to wait before the next attempt after the
+// given number of failed ones.
+func webhookBackoff(base time.Duration, attempts uint32) time.Duration {
+	backoff := base
+	for i := uint32(1); i < attempts && backoff < maxWebhookBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxWebhookBackoff {
+		return maxWebhookBackoff
+	}
+	return backoff
+}
+
+// signWebhookPayload returns the value of WebhookSignatureHeader for body.
+func signWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+const webhookColumns = `id, project, url, statuses, kinds, labels, EXTRACT(EPOCH FROM created)::bigint`
+
+// scanWebhook reads a row of webhookColumns. Secrets are never read back.
+func scanWebhook(row interface {
+	Scan(dest ...interface{}) error
+}) (*Webhook, error) {
+	webhook := &Webhook{}
+	var statuses pq.Int64Array
+	var labels []byte
+	err := row.Scan(
+		&webhook.Id,
+		&webhook.Project,
+		&webhook.Url,
+		&statuses,
+		pq.Array(&webhook.Kinds),
+		&labels,
+		&webhook.Created,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		webhook.Statuses = append(webhook.Statuses, Job_Status(status))
+	}
+	err = json.Unmarshal(labels, &webhook.Labels)
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+const webhookDeliveryColumns = `id, webhook_id, job_id, status, state, attempts, EXTRACT(EPOCH FROM created)::bigint,
+	EXTRACT(EPOCH FROM next_attempt)::bigint, COALESCE(EXTRACT(EPOCH FROM delivered_at)::bigint, 0), last_error,
+	last_response_code`
+
+func webhookDeliveryFields(d *WebhookDelivery) []interface{} {
+	return []interface{}{
+		&d.Id,
+		&d.WebhookId,
+		&d.JobId,
+		&d.Status,
+		&d.State,
+		&d.Attempts,
+		&d.Created,
+		&d.NextAttempt,
+		&d.DeliveredAt,
+		&d.LastError,
+		&d.LastResponseCode,
+	}
+}
+
+// enqueueWebhookDeliveries adds a delivery to the outbox in tx for every
+// webhook matching the status change of a job from before to after, so that
+// notifications are sent exactly for committed changes.
+func (storage *WonderlandStorage) enqueueWebhookDeliveries(ctx context.Context, tx *sql.Tx, before, after *Job) error {
+	if before != nil && before.Status == after.Status {
+		return nil
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+webhookColumns+`
+		FROM webhooks
+		WHERE project=$1;`, after.Project,
+	)
+	if err != nil {
+		return err
+	}
+	webhooks := []*Webhook{}
+	for rows.Next() {
+		var webhook *Webhook
+		webhook, err = scanWebhook(rows)
+		if err != nil {
+			break
+		}
+		if webhookMatches(webhook, after) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	rows.Close()
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	job, err := auditMarshaler.MarshalToString(after)
+	if err != nil {
+		return err
+	}
+	payload := webhookPayload{Event: "job.status_changed", Job: json.RawMessage(job)}
+	if before != nil {
+		payload.PreviousStatus = before.Status.String()
+	}
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	now := storage.now()
+	for _, webhook := range webhooks {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO webhook_deliveries (webhook_id, job_id, status, payload, created, next_attempt)
+			VALUES ($1, $2, $3, $4, $5, $5);`,
+			webhook.Id, after.Id, after.Status, string(content), now,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (storage *WonderlandStorage) CreateWebhook(ctx context.Context, webhook *Webhook, creator User) (created *Webhook, err error) {
+	ctx, span := startStorageSpan(ctx, "CreateWebhook")
+	defer func() { endSpan(span, err) }()
+
+	statuses := pq.Int64Array{}
+	for _, status := range webhook.Statuses {
+		statuses = append(statuses, int64(status))
+	}
+	labels, err := json.Marshal(webhook.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err = scanWebhook(tx.QueryRowContext(ctx, `
+		INSERT INTO webhooks (project, url, statuses, kinds, labels, secret, creator, created)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING `+webhookColumns+`;`,
+		webhook.Project,
+		webhook.Url,
+		statuses,
+		pq.Array(webhook.Kinds),
+		string(labels),
+		webhook.Secret,
+		creator.Username,
+		storage.now(),
+	))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	// the secret is left out of the audit log
+	event, err := recordAuditEvent(ctx, tx, 0, created.Project, nil, created)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(event)
+	created.Secret = webhook.Secret
+	return created, nil
+}
+
+func (storage *WonderlandStorage) GetWebhook(ctx context.Context, id uint64) (webhook *Webhook, err error) {
+	ctx, span := startStorageSpan(ctx, "GetWebhook")
+	defer func() { endSpan(span, err) }()
+
+	return scanWebhook(storage.db.QueryRowContext(ctx, `
+		SELECT `+webhookColumns+`
+		FROM webhooks
+		WHERE id=$1;`, id,
+	))
+}
+
+// ListWebhooks returns the webhooks of project, of every project if it is
+// empty.
+func (storage *WonderlandStorage) ListWebhooks(ctx context.Context, project string) (ret *ListOfWebhooks, err error) {
+	ctx, span := startStorageSpan(ctx, "ListWebhooks")
+	defer func() { endSpan(span, err) }()
+
+	strQuery := `SELECT ` + webhookColumns + ` FROM webhooks WHERE true`
+	args := []interface{}{}
+	if project != "" {
+		args = append(args, project)
+		strQuery += " AND project=$" + strconv.Itoa(len(args))
+	}
+	strQuery += ` ORDER BY id;`
+
+	rows, err := storage.db.QueryContext(ctx, strQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret = &ListOfWebhooks{Webhooks: []*Webhook{}}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		ret.Webhooks = append(ret.Webhooks, webhook)
+	}
+	err = rows.Err()
+	return ret, err
+}
+
+// DeleteWebhook removes a webhook along with its delivery log.
+func (storage *WonderlandStorage) DeleteWebhook(ctx context.Context, id uint64) (webhook *Webhook, err error) {
+	ctx, span := startStorageSpan(ctx, "DeleteWebhook")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err = scanWebhook(tx.QueryRowContext(ctx, `
+		DELETE FROM webhooks
+		WHERE id=$1
+		RETURNING `+webhookColumns+`;`, id,
+	))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	event, err := recordAuditEvent(ctx, tx, 0, webhook.Project, webhook, nil)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	storage.exportAuditEvents(event)
+	return webhook, nil
+}
+
+// ListWebhookDeliveries returns the newest deliveries of a webhook, at most
+// in.HowMany of them if that is not zero.
+func (storage *WonderlandStorage) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest) (ret *ListOfWebhookDeliveries, err error) {
+	ctx, span := startStorageSpan(ctx, "ListWebhookDeliveries")
+	defer func() { endSpan(span, err) }()
+
+	strQuery := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE webhook_id=$1`
+	args := []interface{}{in.WebhookId}
+	if in.OnlyDead {
+		args = append(args, WebhookDelivery_DEAD)
+		strQuery += " AND state=$" + strconv.Itoa(len(args))
+	}
+	strQuery += ` ORDER BY id DESC`
+	if in.HowMany != 0 {
+		args = append(args, in.HowMany)
+		strQuery += " LIMIT $" + strconv.Itoa(len(args))
+	}
+	strQuery += `;`
+
+	rows, err := storage.db.QueryContext(ctx, strQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ret = &ListOfWebhookDeliveries{Deliveries: []*WebhookDelivery{}}
+	for rows.Next() {
+		delivery := &WebhookDelivery{}
+		err = rows.Scan(webhookDeliveryFields(delivery)...)
+		if err != nil {
+			return nil, err
+		}
+		ret.Deliveries = append(ret.Deliveries, delivery)
+	}
+	err = rows.Err()
+	return ret, err
+}
+
+func (storage *WonderlandStorage) GetWebhookDelivery(ctx context.Context, id uint64) (delivery *WebhookDelivery, err error) {
+	ctx, span := startStorageSpan(ctx, "GetWebhookDelivery")
+	defer func() { endSpan(span, err) }()
+
+	delivery = &WebhookDelivery{}
+	err = storage.db.QueryRowContext(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries
+		WHERE id=$1;`, id,
+	).Scan(webhookDeliveryFields(delivery)...)
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// RetryWebhookDelivery makes a dead delivery due again with a fresh set of
+// attempts.
+func (storage *WonderlandStorage) RetryWebhookDelivery(ctx context.Context, id uint64) (delivery *WebhookDelivery, err error) {
+	ctx, span := startStorageSpan(ctx, "RetryWebhookDelivery")
+	defer func() { endSpan(span, err) }()
+
+	delivery = &WebhookDelivery{}
+	err = storage.db.QueryRowContext(ctx, `
+		UPDATE webhook_deliveries
+		SET state=$1, attempts=0, next_attempt=$2
+		WHERE id=$3 AND state=$4
+		RETURNING `+webhookDeliveryColumns+`;`,
+		WebhookDelivery_PENDING, storage.now(), id, WebhookDelivery_DEAD,
+	).Scan(webhookDeliveryFields(delivery)...)
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// claimedDelivery is a due delivery along with what is needed to send it.
+type claimedDelivery struct {
+	*WebhookDelivery
+	url     string
+	secret  string
+	payload []byte
+}
+
+// claimWebhookDeliveries leases up to limit due deliveries, so that other
+// replicas leave them alone while they are being sent.
+func (storage *WonderlandStorage) claimWebhookDeliveries(ctx context.Context, limit int) (claimed []*claimedDelivery, err error) {
+	ctx, span := startStorageSpan(ctx, "ClaimWebhookDeliveries")
+	defer func() { endSpan(span, err) }()
+
+	now := storage.now()
+	rows, err := storage.db.QueryContext(ctx, `
+		WITH due AS (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE state=$1 AND next_attempt <= $2
+			ORDER BY next_attempt
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt=$4
+		FROM due, webhooks w
+		WHERE d.id=due.id AND w.id=d.webhook_id
+		RETURNING d.id, w.url, w.secret, d.payload::text;`,
+		WebhookDelivery_PENDING, now, limit, now.Add(webhookLease),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	claimed = []*claimedDelivery{}
+	for rows.Next() {
+		delivery := &claimedDelivery{WebhookDelivery: &WebhookDelivery{}}
+		var payload string
+		err = rows.Scan(&delivery.Id, &delivery.url, &delivery.secret, &payload)
+		if err != nil {
+			return nil, err
+		}
+		delivery.payload = []byte(payload)
+		claimed = append(claimed, delivery)
+	}
+	err = rows.Err()
+	return claimed, err
+}
+
+// recordWebhookAttempt stores the outcome of sending a delivery: delivered,
+// due again after a backoff, or dead after maxAttempts.
+func (storage *WonderlandStorage) recordWebhookAttempt(ctx context.Context, id uint64, code int, sendErr error, maxAttempts uint32, backoff time.Duration) (delivery *WebhookDelivery, err error) {
+	ctx, span := startStorageSpan(ctx, "RecordWebhookAttempt")
+	defer func() { endSpan(span, err) }()
+
+	tx, err := storage.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var attempts uint32
+	err = tx.QueryRowContext(ctx, `
+		SELECT attempts + 1
+		FROM webhook_deliveries
+		WHERE id=$1
+		FOR UPDATE;`, id,
+	).Scan(&attempts)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	now := storage.now()
+	state := WebhookDelivery_DELIVERED
+	lastError := ""
+	var deliveredAt interface{} = now
+	nextAttempt := now
+	if sendErr != nil {
+		lastError = sendErr.Error()
+		deliveredAt = nil
+		state = WebhookDelivery_PENDING
+		nextAttempt = now.Add(webhookBackoff(backoff, attempts))
+		if attempts >= maxAttempts {
+			state = WebhookDelivery_DEAD
+		}
+	}
+
+	delivery = &WebhookDelivery{}
+	err = tx.QueryRowContext(ctx, `
+		UPDATE webhook_deliveries
+		SET state=$1, attempts=$2, next_attempt=$3, delivered_at=$4, last_error=$5, last_response_code=$6
+		WHERE id=$7
+		RETURNING `+webhookDeliveryColumns+`;`,
+		state, attempts, nextAttempt, deliveredAt, lastError, code, id,
+	).Scan(webhookDeliveryFields(delivery)...)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = commit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// DeleteOldWebhookDeliveries forgets the delivered and dead deliveries
+// created before the given time.
+func (storage *WonderlandStorage) DeleteOldWebhookDeliveries(ctx context.Context, before time.Time) (deleted int64, err error) {
+	ctx, span := startStorageSpan(ctx, "DeleteOldWebhookDeliveries")
+	defer func() { endSpan(span, err) }()
+
+	result, err := storage.db.ExecContext(ctx, `
+		DELETE FROM webhook_deliveries
+		WHERE state <> $1 AND created < $2;`, WebhookDelivery_PENDING, before,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// errWebhookAddress is the error of connections to addresses webhooks may not
+// reach.
+var errWebhookAddress = fmt.Errorf("webhook address is not public")
+
+// publicAddress tells whether ip may be reached by webhooks: loopback,
+// private, link-local and other special addresses are internal to the
+// network of the server.
+func publicAddress(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// WebhookDispatcher sends the deliveries of the webhook outbox.
+type WebhookDispatcher struct {
+	storage *WonderlandStorage
+	client  *http.Client
+
+	// MaxAttempts is how often a delivery is tried before it is dead.
+	MaxAttempts uint32
+	// Backoff is the wait after the first failed attempt, doubled after
+	// every further one up to an hour.
+	Backoff time.Duration
+	// Retention is how long delivered and dead deliveries are kept.
+	Retention time.Duration
+	// AllowPrivateAddresses lets webhooks reach loopback, private and
+	// link-local addresses. Otherwise URLs resolving to them fail, so that
+	// webhooks cannot probe the network of the server.
+	AllowPrivateAddresses bool
+}
+
+func NewWebhookDispatcher(storage *WonderlandStorage) *WebhookDispatcher {
+	d := &WebhookDispatcher{
+		storage:     storage,
+		MaxAttempts: defaultWebhookMaxAttempts,
+		Backoff:     defaultWebhookBackoff,
+		Retention:   defaultWebhookDeliveryRetention,
+	}
+	// the address is checked once resolved, right before connecting, so
+	// that DNS cannot point an accepted name elsewhere
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if !d.AllowPrivateAddresses && (ip == nil || !publicAddress(ip)) {
+				return errWebhookAddress
+			}
+			return nil
+		},
+	}
+	d.client = &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+		// redirects are failed responses like any other non-2xx
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return d
+}
+
+// Run sends due deliveries every interval and prunes old ones until ctx is
+// done.
+func (d *WebhookDispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var pruned time.Time
+	for {
+		delivered, failed, err := d.DeliverDue(ctx)
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to deliver webhooks")
+		} else if delivered > 0 || failed > 0 {
+			logrus.WithFields(logrus.Fields{"delivered": delivered, "failed": failed}).Info("Sent webhook deliveries")
+		}
+
+		if time.Since(pruned) >= webhookPruneInterval {
+			deleted, err := d.storage.DeleteOldWebhookDeliveries(ctx, d.storage.now().Add(-d.Retention))
+			if err != nil {
+				logrus.WithError(err).Warn("Failed to delete old webhook deliveries")
+			} else {
+				pruned = time.Now()
+				if deleted > 0 {
+					logrus.WithField("deliveries", deleted).Info("Deleted old webhook deliveries")
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue sends a batch of due deliveries and records the outcomes.
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context) (delivered int, failed int, err error) {
+	claimed, err := d.storage.claimWebhookDeliveries(ctx, webhookBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, delivery := range claimed {
+		code, sendErr := d.send(ctx, delivery)
+		recorded, err := d.storage.recordWebhookAttempt(ctx, delivery.Id, code, sendErr, d.MaxAttempts, d.Backoff)
+		if err != nil {
+			return delivered, failed, err
+		}
+		switch recorded.State {
+		case WebhookDelivery_DELIVERED:
+			delivered++
+		case WebhookDelivery_DEAD:
+			failed++
+			logrus.WithFields(logrus.Fields{"delivery": recorded.Id, "webhook": recorded.WebhookId}).
+				WithError(sendErr).Warn("Giving up on webhook delivery")
+		default:
+			failed++
+		}
+	}
+	return delivered, failed, nil
+}
+
+// send POSTs a delivery and returns the response status code. Responses
+// other than 2xx are errors. Response bodies are discarded, so that they
+// never show up in the delivery log.
+func (d *WebhookDispatcher) send(ctx context.Context, delivery *claimedDelivery) (int, error) {
+	request, err := http.NewRequest(http.MethodPost, delivery.url, bytes.NewReader(delivery.payload))
+	if err != nil {
+		return 0, err
+	}
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(delivery.Id, 10))
+	request.Header.Set(WebhookSignatureHeader, signWebhookPayload(delivery.secret, delivery.payload))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	// drain a little of the body so that the connection can be reused
+	io.Copy(ioutil.Discard, io.LimitReader(response.Body, 4096))
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("webhook responded with %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+// authorizeWebhook reads the webhook with the given id and checks that user
+// may manage the webhooks of its project.
+func (s *Server) authorizeWebhook(ctx context.Context, user User, id uint64) (*Webhook, error) {
+	if !user.MayEver(PermManageWebhooks) {
+		return nil, errNoAccess
+	}
+
+	webhook, err := s.Storage.GetWebhook(ctx, id)
+	if err == sql.ErrNoRows {
+		return nil, grpc.Errorf(codes.NotFound, "Webhook %d does not exist", id)
+	}
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+	if !user.Can(PermManageWebhooks, webhook.Project, AnyScope) {
+		return nil, errNoAccess
+	}
+	return webhook, nil
+}
+
+// CreateWebhook subscribes a URL to the status changes of the jobs of a
+// project. The response is the only place the secret is ever returned.
+func (s *Server) CreateWebhook(ctx context.Context, in *Webhook) (*Webhook, error) {
+	user := getAuthUserFromContext(ctx)
+
+	err := validateWebhook(in)
+	if err != nil {
+		return nil, err
+	}
+	if in.Project == "" {
+		project, err := user.onlyProject(PermManageWebhooks)
+		if err != nil {
+			return nil, err
+		}
+		in.Project = project
+	}
+	if !user.Can(PermManageWebhooks, in.Project, AnyScope) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Storage.CreateWebhook(ctx, in, user)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) ListWebhooks(ctx context.Context, in *ListWebhooksRequest) (*ListOfWebhooks, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if in.Project == "" && !user.Can(PermManageWebhooks, AnyScope, AnyScope) {
+		project, err := user.onlyProject(PermManageWebhooks)
+		if err != nil {
+			return nil, err
+		}
+		in.Project = project
+	}
+	if !user.Can(PermManageWebhooks, orAnyScope(in.Project), AnyScope) {
+		return nil, errNoAccess
+	}
+
+	ret, err := s.Storage.ListWebhooks(ctx, in.Project)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) DeleteWebhook(ctx context.Context, in *RequestWithId) (*Webhook, error) {
+	user := getAuthUserFromContext(ctx)
+
+	_, err := s.authorizeWebhook(ctx, user, in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := s.Storage.DeleteWebhook(ctx, in.Id)
+	if err == sql.ErrNoRows {
+		return nil, grpc.Errorf(codes.NotFound, "Webhook %d does not exist", in.Id)
+	}
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+func (s *Server) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest) (*ListOfWebhookDeliveries, error) {
+	user := getAuthUserFromContext(ctx)
+
+	_, err := s.authorizeWebhook(ctx, user, in.WebhookId)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := s.Storage.ListWebhookDeliveries(ctx, in)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
+
+// RetryWebhookDelivery sends a dead delivery again.
+func (s *Server) RetryWebhookDelivery(ctx context.Context, in *RequestWithId) (*WebhookDelivery, error) {
+	user := getAuthUserFromContext(ctx)
+
+	if !user.MayEver(PermManageWebhooks) {
+		return nil, errNoAccess
+	}
+	delivery, err := s.Storage.GetWebhookDelivery(ctx, in.Id)
+	if err == sql.ErrNoRows {
+		return nil, grpc.Errorf(codes.NotFound, "Webhook delivery %d does not exist", in.Id)
+	}
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+	_, err = s.authorizeWebhook(ctx, user, delivery.WebhookId)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.State != WebhookDelivery_DEAD {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "Delivery %d is %s, only dead deliveries are retried", delivery.Id, delivery.State)
+	}
+
+	ret, err := s.Storage.RetryWebhookDelivery(ctx, in.Id)
+	if err != nil {
+		return nil, detailedInternalError(err)
+	}
+
+	return ret, nil
+}
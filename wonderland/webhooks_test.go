@@ -0,0 +1,124 @@
+package wonderland
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateWebhook(t *testing.T) {
+	webhook := &Webhook{Url: "https://ci.example.com/hooks/wonderland", Statuses: []Job_Status{Job_FAILED}}
+	checkTestErr(validateWebhook(webhook), t)
+	if len(webhook.Secret) != 64 {
+		t.Fail()
+	}
+
+	invalid := []*Webhook{
+		{Url: ""},
+		{Url: "/hooks/wonderland"},
+		{Url: "ftp://ci.example.com/"},
+		{Url: "https://ci.example.com/", Statuses: []Job_Status{42}},
+	}
+	for _, webhook := range invalid {
+		if status.Code(validateWebhook(webhook)) != codes.InvalidArgument {
+			t.Fail()
+		}
+	}
+}
+
+func TestWebhookMatches(t *testing.T) {
+	job := &Job{Kind: "build", Status: Job_FAILED, Metadata: `{"branch": "main", "attempt": 2}`}
+
+	matching := []*Webhook{
+		{},
+		{Statuses: []Job_Status{Job_COMPLETED, Job_FAILED}},
+		{Kinds: []string{"build"}},
+		{Labels: map[string]string{"branch": "main"}},
+	}
+	for _, webhook := range matching {
+		if !webhookMatches(webhook, job) {
+			t.Errorf("%v should match", webhook)
+		}
+	}
+
+	other := []*Webhook{
+		{Statuses: []Job_Status{Job_COMPLETED}},
+		{Kinds: []string{"deploy"}},
+		{Labels: map[string]string{"branch": "dev"}},
+		{Labels: map[string]string{"attempt": "2"}},
+	}
+	for _, webhook := range other {
+		if webhookMatches(webhook, job) {
+			t.Errorf("%v should not match", webhook)
+		}
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	expected := map[uint32]time.Duration{
+		1:  10 * time.Second,
+		2:  20 * time.Second,
+		4:  80 * time.Second,
+		20: time.Hour,
+	}
+	for attempts, backoff := range expected {
+		if webhookBackoff(10*time.Second, attempts) != backoff {
+			t.Errorf("backoff after %d attempts is %v", attempts, webhookBackoff(10*time.Second, attempts))
+		}
+	}
+}
+
+func TestWebhookSend(t *testing.T) {
+	payload := []byte(`{"event":"job.status_changed"}`)
+	responseCode := http.StatusNoContent
+	// the handler runs in another goroutine, which must not fail the test
+	received := make(chan bool, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		received <- err == nil && r.Header.Get(WebhookSignatureHeader) == signWebhookPayload("secret", body) &&
+			r.Header.Get(WebhookDeliveryHeader) == "7" && string(body) == string(payload)
+		w.WriteHeader(responseCode)
+	}))
+	defer receiver.Close()
+
+	d := NewWebhookDispatcher(nil)
+	delivery := &claimedDelivery{WebhookDelivery: &WebhookDelivery{Id: 7}, url: receiver.URL, secret: "secret", payload: payload}
+	// the receiver listens on a loopback address
+	_, err := d.send(context.Background(), delivery)
+	if err == nil || len(received) != 0 {
+		t.Fail()
+	}
+
+	d.AllowPrivateAddresses = true
+	code, err := d.send(context.Background(), delivery)
+	checkTestErr(err, t)
+	if code != http.StatusNoContent || !<-received {
+		t.Fail()
+	}
+
+	responseCode = http.StatusBadGateway
+	code, err = d.send(context.Background(), delivery)
+	if err == nil || code != http.StatusBadGateway || !<-received {
+		t.Fail()
+	}
+}
+
+func TestPublicAddress(t *testing.T) {
+	for _, address := range []string{"127.0.0.1", "10.1.2.3", "192.168.0.1", "169.254.169.254", "::1", "fe80::1", "fd00::1", "0.0.0.0"} {
+		if publicAddress(net.ParseIP(address)) {
+			t.Errorf("%s is not public", address)
+		}
+	}
+	for _, address := range []string{"8.8.8.8", "2001:4860:4860::8888"} {
+		if !publicAddress(net.ParseIP(address)) {
+			t.Errorf("%s is public", address)
+		}
+	}
+}
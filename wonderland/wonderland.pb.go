@@ -6,6 +6,7 @@ package wonderland
 import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	math "math"
 )
 
@@ -34,6 +35,8 @@ const (
 	Job_FAILED    Job_Status = 3
 	Job_COMPLETED Job_Status = 4
 	Job_KILLED    Job_Status = 5
+	// the deadline passed before the job was pulled
+	Job_EXPIRED Job_Status = 6
 )
 
 var Job_Status_name = map[int32]string{
@@ -43,6 +46,7 @@ var Job_Status_name = map[int32]string{
 	3: "FAILED",
 	4: "COMPLETED",
 	5: "KILLED",
+	6: "EXPIRED",
 }
 
 var Job_Status_value = map[string]int32{
@@ -52,6 +56,7 @@ var Job_Status_value = map[string]int32{
 	"FAILED":    3,
 	"COMPLETED": 4,
 	"KILLED":    5,
+	"EXPIRED":   6,
 }
 
 func (x Job_Status) String() string {
@@ -62,17 +67,190 @@ func (Job_Status) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_5ffb90dacc1dd129, []int{0, 0}
 }
 
+// what to do when the job of the previous run is not finished yet
+type Schedule_Overlap int32
+
+const (
+	// skip this run
+	Schedule_SKIP Schedule_Overlap = 0
+	// create the job once the previous one has finished
+	Schedule_QUEUE Schedule_Overlap = 1
+	// kill the previous job and create a new one
+	Schedule_REPLACE Schedule_Overlap = 2
+)
+
+var Schedule_Overlap_name = map[int32]string{
+	0: "SKIP",
+	1: "QUEUE",
+	2: "REPLACE",
+}
+
+var Schedule_Overlap_value = map[string]int32{
+	"SKIP":    0,
+	"QUEUE":   1,
+	"REPLACE": 2,
+}
+
+func (x Schedule_Overlap) String() string {
+	return proto.EnumName(Schedule_Overlap_name, int32(x))
+}
+
+func (Schedule_Overlap) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{26, 0}
+}
+
+type WebhookDelivery_State int32
+
+const (
+	WebhookDelivery_PENDING   WebhookDelivery_State = 0
+	WebhookDelivery_DELIVERED WebhookDelivery_State = 1
+	// given up on after too many failed attempts
+	WebhookDelivery_DEAD WebhookDelivery_State = 2
+)
+
+var WebhookDelivery_State_name = map[int32]string{
+	0: "PENDING",
+	1: "DELIVERED",
+	2: "DEAD",
+}
+
+var WebhookDelivery_State_value = map[string]int32{
+	"PENDING":   0,
+	"DELIVERED": 1,
+	"DEAD":      2,
+}
+
+func (x WebhookDelivery_State) String() string {
+	return proto.EnumName(WebhookDelivery_State_name, int32(x))
+}
+
+func (WebhookDelivery_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{33, 0}
+}
+
+type TemplateParameter_Type int32
+
+const (
+	TemplateParameter_STRING  TemplateParameter_Type = 0
+	TemplateParameter_INTEGER TemplateParameter_Type = 1
+	TemplateParameter_NUMBER  TemplateParameter_Type = 2
+	TemplateParameter_BOOLEAN TemplateParameter_Type = 3
+)
+
+var TemplateParameter_Type_name = map[int32]string{
+	0: "STRING",
+	1: "INTEGER",
+	2: "NUMBER",
+	3: "BOOLEAN",
+}
+
+var TemplateParameter_Type_value = map[string]int32{
+	"STRING":  0,
+	"INTEGER": 1,
+	"NUMBER":  2,
+	"BOOLEAN": 3,
+}
+
+func (x TemplateParameter_Type) String() string {
+	return proto.EnumName(TemplateParameter_Type_name, int32(x))
+}
+
+func (TemplateParameter_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{40, 0}
+}
+
+type JobEvent_Type int32
+
+const (
+	// the job was created, or restored from the archive
+	JobEvent_CREATED JobEvent_Type = 0
+	// the status changed
+	JobEvent_STATUS_CHANGED JobEvent_Type = 1
+	// other fields changed, such as metadata or run_after
+	JobEvent_UPDATED JobEvent_Type = 2
+	// the job was moved to the trash
+	JobEvent_DELETED JobEvent_Type = 3
+	// the job was restored from the trash
+	JobEvent_RESTORED JobEvent_Type = 4
+	// the job was removed from the database, purged from the trash or
+	// archived
+	JobEvent_PURGED JobEvent_Type = 5
+)
+
+var JobEvent_Type_name = map[int32]string{
+	0: "CREATED",
+	1: "STATUS_CHANGED",
+	2: "UPDATED",
+	3: "DELETED",
+	4: "RESTORED",
+	5: "PURGED",
+}
+
+var JobEvent_Type_value = map[string]int32{
+	"CREATED":        0,
+	"STATUS_CHANGED": 1,
+	"UPDATED":        2,
+	"DELETED":        3,
+	"RESTORED":       4,
+	"PURGED":         5,
+}
+
+func (x JobEvent_Type) String() string {
+	return proto.EnumName(JobEvent_Type_name, int32(x))
+}
+
+func (JobEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{49, 0}
+}
+
 type Job struct {
-	Project              string     `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
-	Id                   uint64     `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
-	Kind                 string     `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
-	Status               Job_Status `protobuf:"varint,4,opt,name=status,proto3,enum=Job_Status" json:"status,omitempty"`
-	Input                string     `protobuf:"bytes,5,opt,name=input,proto3" json:"input,omitempty"`
-	Output               string     `protobuf:"bytes,6,opt,name=output,proto3" json:"output,omitempty"`
-	Metadata             string     `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Project  string     `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Id       uint64     `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Kind     string     `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Status   Job_Status `protobuf:"varint,4,opt,name=status,proto3,enum=Job_Status" json:"status,omitempty"`
+	Input    string     `protobuf:"bytes,5,opt,name=input,proto3" json:"input,omitempty"`
+	Output   string     `protobuf:"bytes,6,opt,name=output,proto3" json:"output,omitempty"`
+	Metadata string     `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// W3C traceparent of the CreateJob call, set by the server
+	TraceParent string `protobuf:"bytes,8,opt,name=trace_parent,json=traceParent,proto3" json:"trace_parent,omitempty"`
+	// time the job was deleted, in seconds since the epoch, 0 unless it is
+	// in the trash
+	DeletedAt int64 `protobuf:"varint,9,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// CreateJob requests with the same key in a project create a single job:
+	// repeating a request returns the job created first. Only kept on
+	// creation.
+	IdempotencyKey string `protobuf:"bytes,10,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// the job is not pulled before this time, in seconds since the epoch;
+	// 0 means right away
+	RunAfter int64 `protobuf:"varint,11,opt,name=run_after,json=runAfter,proto3" json:"run_after,omitempty"`
+	// seconds the job may take once pulled before the server fails it; 0
+	// means no limit
+	MaxRuntime int64 `protobuf:"varint,12,opt,name=max_runtime,json=maxRuntime,proto3" json:"max_runtime,omitempty"`
+	// the job expires unless pulled before this time, in seconds since the
+	// epoch; 0 means never
+	Deadline int64 `protobuf:"varint,13,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// why the server failed or expired the job, set by the server
+	FailureReason string `protobuf:"bytes,14,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	// why the job failed, set by workers with ModifyJob or by the server;
+	// cleared when the job leaves FAILED
+	Error *JobError `protobuf:"bytes,15,opt,name=error,proto3" json:"error,omitempty"`
+	// last progress reported by the worker of a pulled or running job
+	Progress *Progress `protobuf:"bytes,16,opt,name=progress,proto3" json:"progress,omitempty"`
+	// version of the registered kind whose schemas the input and output
+	// are validated against, set by the server; 0 if the kind was not
+	// registered when the job was created
+	KindVersion uint32 `protobuf:"varint,17,opt,name=kind_version,json=kindVersion,proto3" json:"kind_version,omitempty"`
+	// the template the job was created from with CreateJobFromTemplate,
+	// set by the server
+	TemplateId      uint64 `protobuf:"varint,18,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	TemplateVersion uint32 `protobuf:"varint,19,opt,name=template_version,json=templateVersion,proto3" json:"template_version,omitempty"`
+	// the array job the job belongs to and its index in it, set by the
+	// server
+	ArrayId              uint64   `protobuf:"varint,20,opt,name=array_id,json=arrayId,proto3" json:"array_id,omitempty"`
+	ArrayIndex           uint32   `protobuf:"varint,21,opt,name=array_index,json=arrayIndex,proto3" json:"array_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Job) Reset()         { *m = Job{} }
@@ -149,376 +327,4969 @@ func (m *Job) GetMetadata() string {
 	return ""
 }
 
-type ListOfJobs struct {
-	Jobs                 []*Job   `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Job) GetTraceParent() string {
+	if m != nil {
+		return m.TraceParent
+	}
+	return ""
 }
 
-func (m *ListOfJobs) Reset()         { *m = ListOfJobs{} }
-func (m *ListOfJobs) String() string { return proto.CompactTextString(m) }
-func (*ListOfJobs) ProtoMessage()    {}
-func (*ListOfJobs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5ffb90dacc1dd129, []int{1}
+func (m *Job) GetDeletedAt() int64 {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return 0
 }
 
-func (m *ListOfJobs) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListOfJobs.Unmarshal(m, b)
+func (m *Job) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
 }
-func (m *ListOfJobs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListOfJobs.Marshal(b, m, deterministic)
+
+func (m *Job) GetRunAfter() int64 {
+	if m != nil {
+		return m.RunAfter
+	}
+	return 0
 }
-func (m *ListOfJobs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListOfJobs.Merge(m, src)
+
+func (m *Job) GetMaxRuntime() int64 {
+	if m != nil {
+		return m.MaxRuntime
+	}
+	return 0
 }
-func (m *ListOfJobs) XXX_Size() int {
-	return xxx_messageInfo_ListOfJobs.Size(m)
+
+func (m *Job) GetDeadline() int64 {
+	if m != nil {
+		return m.Deadline
+	}
+	return 0
 }
-func (m *ListOfJobs) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListOfJobs.DiscardUnknown(m)
+
+func (m *Job) GetFailureReason() string {
+	if m != nil {
+		return m.FailureReason
+	}
+	return ""
 }
 
-var xxx_messageInfo_ListOfJobs proto.InternalMessageInfo
+func (m *Job) GetError() *JobError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
 
-func (m *ListOfJobs) GetJobs() []*Job {
+func (m *Job) GetProgress() *Progress {
 	if m != nil {
-		return m.Jobs
+		return m.Progress
 	}
 	return nil
 }
 
-type RequestWithId struct {
-	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+func (m *Job) GetKindVersion() uint32 {
+	if m != nil {
+		return m.KindVersion
+	}
+	return 0
+}
+
+func (m *Job) GetTemplateId() uint64 {
+	if m != nil {
+		return m.TemplateId
+	}
+	return 0
+}
+
+func (m *Job) GetTemplateVersion() uint32 {
+	if m != nil {
+		return m.TemplateVersion
+	}
+	return 0
+}
+
+func (m *Job) GetArrayId() uint64 {
+	if m != nil {
+		return m.ArrayId
+	}
+	return 0
+}
+
+func (m *Job) GetArrayIndex() uint32 {
+	if m != nil {
+		return m.ArrayIndex
+	}
+	return 0
+}
+
+// Progress tells how far along a job is.
+type Progress struct {
+	// share of the work done, from 0 to 1; step / total_steps if left out
+	Fraction   float64 `protobuf:"fixed64,1,opt,name=fraction,proto3" json:"fraction,omitempty"`
+	Step       uint64  `protobuf:"varint,2,opt,name=step,proto3" json:"step,omitempty"`
+	TotalSteps uint64  `protobuf:"varint,3,opt,name=total_steps,json=totalSteps,proto3" json:"total_steps,omitempty"`
+	Message    string  `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// set by the server, in seconds since the epoch
+	UpdatedAt int64 `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// when the job is expected to be done, estimated by the server from the
+	// reports so far, in seconds since the epoch; 0 if unknown
+	Eta                  int64    `protobuf:"varint,6,opt,name=eta,proto3" json:"eta,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RequestWithId) Reset()         { *m = RequestWithId{} }
-func (m *RequestWithId) String() string { return proto.CompactTextString(m) }
-func (*RequestWithId) ProtoMessage()    {}
-func (*RequestWithId) Descriptor() ([]byte, []int) {
+func (m *Progress) Reset()         { *m = Progress{} }
+func (m *Progress) String() string { return proto.CompactTextString(m) }
+func (*Progress) ProtoMessage()    {}
+func (*Progress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{1}
+}
+
+func (m *Progress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Progress.Unmarshal(m, b)
+}
+func (m *Progress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Progress.Marshal(b, m, deterministic)
+}
+func (m *Progress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Progress.Merge(m, src)
+}
+func (m *Progress) XXX_Size() int {
+	return xxx_messageInfo_Progress.Size(m)
+}
+func (m *Progress) XXX_DiscardUnknown() {
+	xxx_messageInfo_Progress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Progress proto.InternalMessageInfo
+
+func (m *Progress) GetFraction() float64 {
+	if m != nil {
+		return m.Fraction
+	}
+	return 0
+}
+
+func (m *Progress) GetStep() uint64 {
+	if m != nil {
+		return m.Step
+	}
+	return 0
+}
+
+func (m *Progress) GetTotalSteps() uint64 {
+	if m != nil {
+		return m.TotalSteps
+	}
+	return 0
+}
+
+func (m *Progress) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Progress) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+func (m *Progress) GetEta() int64 {
+	if m != nil {
+		return m.Eta
+	}
+	return 0
+}
+
+type ReportProgressRequest struct {
+	Id                   uint64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Progress             *Progress `protobuf:"bytes,2,opt,name=progress,proto3" json:"progress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ReportProgressRequest) Reset()         { *m = ReportProgressRequest{} }
+func (m *ReportProgressRequest) String() string { return proto.CompactTextString(m) }
+func (*ReportProgressRequest) ProtoMessage()    {}
+func (*ReportProgressRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5ffb90dacc1dd129, []int{2}
 }
 
-func (m *RequestWithId) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RequestWithId.Unmarshal(m, b)
+func (m *ReportProgressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportProgressRequest.Unmarshal(m, b)
 }
-func (m *RequestWithId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RequestWithId.Marshal(b, m, deterministic)
+func (m *ReportProgressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportProgressRequest.Marshal(b, m, deterministic)
 }
-func (m *RequestWithId) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RequestWithId.Merge(m, src)
+func (m *ReportProgressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportProgressRequest.Merge(m, src)
 }
-func (m *RequestWithId) XXX_Size() int {
-	return xxx_messageInfo_RequestWithId.Size(m)
+func (m *ReportProgressRequest) XXX_Size() int {
+	return xxx_messageInfo_ReportProgressRequest.Size(m)
 }
-func (m *RequestWithId) XXX_DiscardUnknown() {
-	xxx_messageInfo_RequestWithId.DiscardUnknown(m)
+func (m *ReportProgressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportProgressRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RequestWithId proto.InternalMessageInfo
+var xxx_messageInfo_ReportProgressRequest proto.InternalMessageInfo
 
-func (m *RequestWithId) GetId() uint64 {
+func (m *ReportProgressRequest) GetId() uint64 {
 	if m != nil {
 		return m.Id
 	}
 	return 0
 }
 
-type ListJobsRequest struct {
-	HowMany              uint32   `protobuf:"varint,1,opt,name=how_many,json=howMany,proto3" json:"how_many,omitempty"`
-	Project              string   `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
-	Kind                 string   `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+func (m *ReportProgressRequest) GetProgress() *Progress {
+	if m != nil {
+		return m.Progress
+	}
+	return nil
+}
+
+// JobError describes the failure of a job.
+type JobError struct {
+	// short machine readable cause, such as OUT_OF_MEMORY
+	Code    string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// whether running the job again may succeed
+	Retryable bool `protobuf:"varint,3,opt,name=retryable,proto3" json:"retryable,omitempty"`
+	// the worker the job failed on, the reporting principal if not set
+	Worker string `protobuf:"bytes,4,opt,name=worker,proto3" json:"worker,omitempty"`
+	// stack trace or log excerpt
+	Details              string   `protobuf:"bytes,5,opt,name=details,proto3" json:"details,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListJobsRequest) Reset()         { *m = ListJobsRequest{} }
-func (m *ListJobsRequest) String() string { return proto.CompactTextString(m) }
-func (*ListJobsRequest) ProtoMessage()    {}
-func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+func (m *JobError) Reset()         { *m = JobError{} }
+func (m *JobError) String() string { return proto.CompactTextString(m) }
+func (*JobError) ProtoMessage()    {}
+func (*JobError) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5ffb90dacc1dd129, []int{3}
 }
 
-func (m *ListJobsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListJobsRequest.Unmarshal(m, b)
+func (m *JobError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobError.Unmarshal(m, b)
 }
-func (m *ListJobsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListJobsRequest.Marshal(b, m, deterministic)
+func (m *JobError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobError.Marshal(b, m, deterministic)
 }
-func (m *ListJobsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListJobsRequest.Merge(m, src)
+func (m *JobError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobError.Merge(m, src)
 }
-func (m *ListJobsRequest) XXX_Size() int {
-	return xxx_messageInfo_ListJobsRequest.Size(m)
+func (m *JobError) XXX_Size() int {
+	return xxx_messageInfo_JobError.Size(m)
 }
-func (m *ListJobsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListJobsRequest.DiscardUnknown(m)
+func (m *JobError) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobError.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListJobsRequest proto.InternalMessageInfo
+var xxx_messageInfo_JobError proto.InternalMessageInfo
 
-func (m *ListJobsRequest) GetHowMany() uint32 {
+func (m *JobError) GetCode() string {
 	if m != nil {
-		return m.HowMany
+		return m.Code
 	}
-	return 0
+	return ""
 }
 
-func (m *ListJobsRequest) GetProject() string {
+func (m *JobError) GetMessage() string {
 	if m != nil {
-		return m.Project
+		return m.Message
 	}
 	return ""
 }
 
-func (m *ListJobsRequest) GetKind() string {
+func (m *JobError) GetRetryable() bool {
 	if m != nil {
-		return m.Kind
+		return m.Retryable
+	}
+	return false
+}
+
+func (m *JobError) GetWorker() string {
+	if m != nil {
+		return m.Worker
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*Job)(nil), "Job")
-	proto.RegisterType((*ListOfJobs)(nil), "ListOfJobs")
-	proto.RegisterType((*RequestWithId)(nil), "RequestWithId")
-	proto.RegisterType((*ListJobsRequest)(nil), "ListJobsRequest")
-	proto.RegisterEnum("Job_Status", Job_Status_name, Job_Status_value)
+func (m *JobError) GetDetails() string {
+	if m != nil {
+		return m.Details
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+type SummarizeFailuresRequest struct {
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Kind    string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// only jobs failed at or after this time, in seconds since the epoch;
+	// 0 means the last day
+	Since                int64    `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *SummarizeFailuresRequest) Reset()         { *m = SummarizeFailuresRequest{} }
+func (m *SummarizeFailuresRequest) String() string { return proto.CompactTextString(m) }
+func (*SummarizeFailuresRequest) ProtoMessage()    {}
+func (*SummarizeFailuresRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{4}
+}
 
-// WonderlandClient is the client API for Wonderland service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type WonderlandClient interface {
-	CreateJob(ctx context.Context, in *Job, opts ...grpc.CallOption) (*Job, error)
-	GetJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Job, error)
-	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListOfJobs, error)
-	ModifyJob(ctx context.Context, in *Job, opts ...grpc.CallOption) (*Job, error)
-	PullPendingJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListOfJobs, error)
+func (m *SummarizeFailuresRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SummarizeFailuresRequest.Unmarshal(m, b)
+}
+func (m *SummarizeFailuresRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SummarizeFailuresRequest.Marshal(b, m, deterministic)
+}
+func (m *SummarizeFailuresRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SummarizeFailuresRequest.Merge(m, src)
+}
+func (m *SummarizeFailuresRequest) XXX_Size() int {
+	return xxx_messageInfo_SummarizeFailuresRequest.Size(m)
+}
+func (m *SummarizeFailuresRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SummarizeFailuresRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SummarizeFailuresRequest proto.InternalMessageInfo
+
+func (m *SummarizeFailuresRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *SummarizeFailuresRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *SummarizeFailuresRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+// FailureGroup counts the failed jobs of a project and kind with the same
+// error code.
+type FailureGroup struct {
+	Project   string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Kind      string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Code      string `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Count     uint64 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Retryable uint64 `protobuf:"varint,5,opt,name=retryable,proto3" json:"retryable,omitempty"`
+	// the most recent failure of the group
+	LastFailed           int64    `protobuf:"varint,6,opt,name=last_failed,json=lastFailed,proto3" json:"last_failed,omitempty"`
+	LastJobId            uint64   `protobuf:"varint,7,opt,name=last_job_id,json=lastJobId,proto3" json:"last_job_id,omitempty"`
+	LastMessage          string   `protobuf:"bytes,8,opt,name=last_message,json=lastMessage,proto3" json:"last_message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FailureGroup) Reset()         { *m = FailureGroup{} }
+func (m *FailureGroup) String() string { return proto.CompactTextString(m) }
+func (*FailureGroup) ProtoMessage()    {}
+func (*FailureGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{5}
+}
+
+func (m *FailureGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FailureGroup.Unmarshal(m, b)
+}
+func (m *FailureGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FailureGroup.Marshal(b, m, deterministic)
+}
+func (m *FailureGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FailureGroup.Merge(m, src)
+}
+func (m *FailureGroup) XXX_Size() int {
+	return xxx_messageInfo_FailureGroup.Size(m)
+}
+func (m *FailureGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_FailureGroup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FailureGroup proto.InternalMessageInfo
+
+func (m *FailureGroup) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *FailureGroup) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *FailureGroup) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *FailureGroup) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *FailureGroup) GetRetryable() uint64 {
+	if m != nil {
+		return m.Retryable
+	}
+	return 0
+}
+
+func (m *FailureGroup) GetLastFailed() int64 {
+	if m != nil {
+		return m.LastFailed
+	}
+	return 0
+}
+
+func (m *FailureGroup) GetLastJobId() uint64 {
+	if m != nil {
+		return m.LastJobId
+	}
+	return 0
+}
+
+func (m *FailureGroup) GetLastMessage() string {
+	if m != nil {
+		return m.LastMessage
+	}
+	return ""
+}
+
+type FailureSummary struct {
+	Groups               []*FailureGroup `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *FailureSummary) Reset()         { *m = FailureSummary{} }
+func (m *FailureSummary) String() string { return proto.CompactTextString(m) }
+func (*FailureSummary) ProtoMessage()    {}
+func (*FailureSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{6}
+}
+
+func (m *FailureSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FailureSummary.Unmarshal(m, b)
+}
+func (m *FailureSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FailureSummary.Marshal(b, m, deterministic)
+}
+func (m *FailureSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FailureSummary.Merge(m, src)
+}
+func (m *FailureSummary) XXX_Size() int {
+	return xxx_messageInfo_FailureSummary.Size(m)
+}
+func (m *FailureSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_FailureSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FailureSummary proto.InternalMessageInfo
+
+func (m *FailureSummary) GetGroups() []*FailureGroup {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+// JobLogChunk is a piece of the log of a job, as written by its worker.
+type JobLogChunk struct {
+	JobId uint64 `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// position of the chunk in the log of its job, set by the server
+	Seq uint64 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	// output the chunk was written to, such as stdout or stderr
+	Stream string `protobuf:"bytes,3,opt,name=stream,proto3" json:"stream,omitempty"`
+	Data   string `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// set by the server, in seconds since the epoch
+	Created              int64    `protobuf:"varint,5,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobLogChunk) Reset()         { *m = JobLogChunk{} }
+func (m *JobLogChunk) String() string { return proto.CompactTextString(m) }
+func (*JobLogChunk) ProtoMessage()    {}
+func (*JobLogChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{7}
+}
+
+func (m *JobLogChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobLogChunk.Unmarshal(m, b)
+}
+func (m *JobLogChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobLogChunk.Marshal(b, m, deterministic)
+}
+func (m *JobLogChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobLogChunk.Merge(m, src)
+}
+func (m *JobLogChunk) XXX_Size() int {
+	return xxx_messageInfo_JobLogChunk.Size(m)
+}
+func (m *JobLogChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobLogChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobLogChunk proto.InternalMessageInfo
+
+func (m *JobLogChunk) GetJobId() uint64 {
+	if m != nil {
+		return m.JobId
+	}
+	return 0
+}
+
+func (m *JobLogChunk) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *JobLogChunk) GetStream() string {
+	if m != nil {
+		return m.Stream
+	}
+	return ""
+}
+
+func (m *JobLogChunk) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *JobLogChunk) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+type AppendJobLogsResponse struct {
+	Chunks               uint64   `protobuf:"varint,1,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	Bytes                uint64   `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppendJobLogsResponse) Reset()         { *m = AppendJobLogsResponse{} }
+func (m *AppendJobLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*AppendJobLogsResponse) ProtoMessage()    {}
+func (*AppendJobLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{8}
+}
+
+func (m *AppendJobLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppendJobLogsResponse.Unmarshal(m, b)
+}
+func (m *AppendJobLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppendJobLogsResponse.Marshal(b, m, deterministic)
+}
+func (m *AppendJobLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppendJobLogsResponse.Merge(m, src)
+}
+func (m *AppendJobLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_AppendJobLogsResponse.Size(m)
+}
+func (m *AppendJobLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppendJobLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppendJobLogsResponse proto.InternalMessageInfo
+
+func (m *AppendJobLogsResponse) GetChunks() uint64 {
+	if m != nil {
+		return m.Chunks
+	}
+	return 0
+}
+
+func (m *AppendJobLogsResponse) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+type TailJobLogsRequest struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// only chunks after this seq
+	AfterSeq uint64 `protobuf:"varint,2,opt,name=after_seq,json=afterSeq,proto3" json:"after_seq,omitempty"`
+	// start with the last chunks, all of them if 0
+	Last uint32 `protobuf:"varint,3,opt,name=last,proto3" json:"last,omitempty"`
+	// keep sending new chunks until the job is finished
+	Follow               bool     `protobuf:"varint,4,opt,name=follow,proto3" json:"follow,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TailJobLogsRequest) Reset()         { *m = TailJobLogsRequest{} }
+func (m *TailJobLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*TailJobLogsRequest) ProtoMessage()    {}
+func (*TailJobLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{9}
+}
+
+func (m *TailJobLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TailJobLogsRequest.Unmarshal(m, b)
+}
+func (m *TailJobLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TailJobLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *TailJobLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TailJobLogsRequest.Merge(m, src)
+}
+func (m *TailJobLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_TailJobLogsRequest.Size(m)
+}
+func (m *TailJobLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TailJobLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TailJobLogsRequest proto.InternalMessageInfo
+
+func (m *TailJobLogsRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *TailJobLogsRequest) GetAfterSeq() uint64 {
+	if m != nil {
+		return m.AfterSeq
+	}
+	return 0
+}
+
+func (m *TailJobLogsRequest) GetLast() uint32 {
+	if m != nil {
+		return m.Last
+	}
+	return 0
+}
+
+func (m *TailJobLogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+type ListOfJobs struct {
+	Jobs                 []*Job   `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOfJobs) Reset()         { *m = ListOfJobs{} }
+func (m *ListOfJobs) String() string { return proto.CompactTextString(m) }
+func (*ListOfJobs) ProtoMessage()    {}
+func (*ListOfJobs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{10}
+}
+
+func (m *ListOfJobs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOfJobs.Unmarshal(m, b)
+}
+func (m *ListOfJobs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOfJobs.Marshal(b, m, deterministic)
+}
+func (m *ListOfJobs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOfJobs.Merge(m, src)
+}
+func (m *ListOfJobs) XXX_Size() int {
+	return xxx_messageInfo_ListOfJobs.Size(m)
+}
+func (m *ListOfJobs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOfJobs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOfJobs proto.InternalMessageInfo
+
+func (m *ListOfJobs) GetJobs() []*Job {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+type RequestWithId struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestWithId) Reset()         { *m = RequestWithId{} }
+func (m *RequestWithId) String() string { return proto.CompactTextString(m) }
+func (*RequestWithId) ProtoMessage()    {}
+func (*RequestWithId) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{11}
+}
+
+func (m *RequestWithId) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestWithId.Unmarshal(m, b)
+}
+func (m *RequestWithId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestWithId.Marshal(b, m, deterministic)
+}
+func (m *RequestWithId) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestWithId.Merge(m, src)
+}
+func (m *RequestWithId) XXX_Size() int {
+	return xxx_messageInfo_RequestWithId.Size(m)
+}
+func (m *RequestWithId) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestWithId.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestWithId proto.InternalMessageInfo
+
+func (m *RequestWithId) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type ListJobsRequest struct {
+	HowMany uint32 `protobuf:"varint,1,opt,name=how_many,json=howMany,proto3" json:"how_many,omitempty"`
+	Project string `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	Kind    string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	// list deleted jobs too, for admins only
+	IncludeDeleted bool `protobuf:"varint,4,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// only list pending jobs whose run_after is still in the future
+	Scheduled bool `protobuf:"varint,5,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	// only list the jobs of this array job
+	ArrayId              uint64   `protobuf:"varint,6,opt,name=array_id,json=arrayId,proto3" json:"array_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobsRequest) Reset()         { *m = ListJobsRequest{} }
+func (m *ListJobsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListJobsRequest) ProtoMessage()    {}
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{12}
+}
+
+func (m *ListJobsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobsRequest.Unmarshal(m, b)
+}
+func (m *ListJobsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListJobsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsRequest.Merge(m, src)
+}
+func (m *ListJobsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListJobsRequest.Size(m)
+}
+func (m *ListJobsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobsRequest proto.InternalMessageInfo
+
+func (m *ListJobsRequest) GetHowMany() uint32 {
+	if m != nil {
+		return m.HowMany
+	}
+	return 0
+}
+
+func (m *ListJobsRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *ListJobsRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ListJobsRequest) GetIncludeDeleted() bool {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return false
+}
+
+func (m *ListJobsRequest) GetScheduled() bool {
+	if m != nil {
+		return m.Scheduled
+	}
+	return false
+}
+
+func (m *ListJobsRequest) GetArrayId() uint64 {
+	if m != nil {
+		return m.ArrayId
+	}
+	return 0
+}
+
+type RescheduleJobRequest struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// new run_after of the job, 0 to run it right away
+	RunAfter             int64    `protobuf:"varint,2,opt,name=run_after,json=runAfter,proto3" json:"run_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RescheduleJobRequest) Reset()         { *m = RescheduleJobRequest{} }
+func (m *RescheduleJobRequest) String() string { return proto.CompactTextString(m) }
+func (*RescheduleJobRequest) ProtoMessage()    {}
+func (*RescheduleJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{13}
+}
+
+func (m *RescheduleJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RescheduleJobRequest.Unmarshal(m, b)
+}
+func (m *RescheduleJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RescheduleJobRequest.Marshal(b, m, deterministic)
+}
+func (m *RescheduleJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RescheduleJobRequest.Merge(m, src)
+}
+func (m *RescheduleJobRequest) XXX_Size() int {
+	return xxx_messageInfo_RescheduleJobRequest.Size(m)
+}
+func (m *RescheduleJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RescheduleJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RescheduleJobRequest proto.InternalMessageInfo
+
+func (m *RescheduleJobRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *RescheduleJobRequest) GetRunAfter() int64 {
+	if m != nil {
+		return m.RunAfter
+	}
+	return 0
+}
+
+// RoleBinding grants role to principal (a certificate common name) for jobs
+// of the given project and kind. "ANY" matches every project or kind.
+type RoleBinding struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Principal            string   `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`
+	Role                 string   `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	Project              string   `protobuf:"bytes,4,opt,name=project,proto3" json:"project,omitempty"`
+	Kind                 string   `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RoleBinding) Reset()         { *m = RoleBinding{} }
+func (m *RoleBinding) String() string { return proto.CompactTextString(m) }
+func (*RoleBinding) ProtoMessage()    {}
+func (*RoleBinding) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{14}
+}
+
+func (m *RoleBinding) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoleBinding.Unmarshal(m, b)
+}
+func (m *RoleBinding) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoleBinding.Marshal(b, m, deterministic)
+}
+func (m *RoleBinding) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoleBinding.Merge(m, src)
+}
+func (m *RoleBinding) XXX_Size() int {
+	return xxx_messageInfo_RoleBinding.Size(m)
+}
+func (m *RoleBinding) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoleBinding.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoleBinding proto.InternalMessageInfo
+
+func (m *RoleBinding) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *RoleBinding) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
+}
+
+func (m *RoleBinding) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *RoleBinding) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *RoleBinding) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+type ListOfRoleBindings struct {
+	Bindings             []*RoleBinding `protobuf:"bytes,1,rep,name=bindings,proto3" json:"bindings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListOfRoleBindings) Reset()         { *m = ListOfRoleBindings{} }
+func (m *ListOfRoleBindings) String() string { return proto.CompactTextString(m) }
+func (*ListOfRoleBindings) ProtoMessage()    {}
+func (*ListOfRoleBindings) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{15}
+}
+
+func (m *ListOfRoleBindings) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOfRoleBindings.Unmarshal(m, b)
+}
+func (m *ListOfRoleBindings) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOfRoleBindings.Marshal(b, m, deterministic)
+}
+func (m *ListOfRoleBindings) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOfRoleBindings.Merge(m, src)
+}
+func (m *ListOfRoleBindings) XXX_Size() int {
+	return xxx_messageInfo_ListOfRoleBindings.Size(m)
+}
+func (m *ListOfRoleBindings) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOfRoleBindings.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOfRoleBindings proto.InternalMessageInfo
+
+func (m *ListOfRoleBindings) GetBindings() []*RoleBinding {
+	if m != nil {
+		return m.Bindings
+	}
+	return nil
+}
+
+type ListRoleBindingsRequest struct {
+	Principal            string   `protobuf:"bytes,1,opt,name=principal,proto3" json:"principal,omitempty"`
+	Project              string   `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRoleBindingsRequest) Reset()         { *m = ListRoleBindingsRequest{} }
+func (m *ListRoleBindingsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRoleBindingsRequest) ProtoMessage()    {}
+func (*ListRoleBindingsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{16}
+}
+
+func (m *ListRoleBindingsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRoleBindingsRequest.Unmarshal(m, b)
+}
+func (m *ListRoleBindingsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRoleBindingsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRoleBindingsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRoleBindingsRequest.Merge(m, src)
+}
+func (m *ListRoleBindingsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRoleBindingsRequest.Size(m)
+}
+func (m *ListRoleBindingsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRoleBindingsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRoleBindingsRequest proto.InternalMessageInfo
+
+func (m *ListRoleBindingsRequest) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
+}
+
+func (m *ListRoleBindingsRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+type IssueTokenRequest struct {
+	// subject becomes the username of requests made with the token
+	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// grants in the "project.kind" format of client certificates
+	Grants []string `protobuf:"bytes,2,rep,name=grants,proto3" json:"grants,omitempty"`
+	// lifetime of the token, one hour if not set
+	TtlSeconds           int64    `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssueTokenRequest) Reset()         { *m = IssueTokenRequest{} }
+func (m *IssueTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*IssueTokenRequest) ProtoMessage()    {}
+func (*IssueTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{17}
+}
+
+func (m *IssueTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssueTokenRequest.Unmarshal(m, b)
+}
+func (m *IssueTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssueTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *IssueTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssueTokenRequest.Merge(m, src)
+}
+func (m *IssueTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_IssueTokenRequest.Size(m)
+}
+func (m *IssueTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssueTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssueTokenRequest proto.InternalMessageInfo
+
+func (m *IssueTokenRequest) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+func (m *IssueTokenRequest) GetGrants() []string {
+	if m != nil {
+		return m.Grants
+	}
+	return nil
+}
+
+func (m *IssueTokenRequest) GetTtlSeconds() int64 {
+	if m != nil {
+		return m.TtlSeconds
+	}
+	return 0
+}
+
+type Token struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// expiry time, in seconds since the epoch
+	ExpiresAt            int64    `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Token) Reset()         { *m = Token{} }
+func (m *Token) String() string { return proto.CompactTextString(m) }
+func (*Token) ProtoMessage()    {}
+func (*Token) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{18}
+}
+
+func (m *Token) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Token.Unmarshal(m, b)
+}
+func (m *Token) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Token.Marshal(b, m, deterministic)
+}
+func (m *Token) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Token.Merge(m, src)
+}
+func (m *Token) XXX_Size() int {
+	return xxx_messageInfo_Token.Size(m)
+}
+func (m *Token) XXX_DiscardUnknown() {
+	xxx_messageInfo_Token.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Token proto.InternalMessageInfo
+
+func (m *Token) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *Token) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type RevokedCertificate struct {
+	// serial number in hexadecimal
+	Serial               string   `protobuf:"bytes,1,opt,name=serial,proto3" json:"serial,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	RevokedBy            string   `protobuf:"bytes,3,opt,name=revoked_by,json=revokedBy,proto3" json:"revoked_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokedCertificate) Reset()         { *m = RevokedCertificate{} }
+func (m *RevokedCertificate) String() string { return proto.CompactTextString(m) }
+func (*RevokedCertificate) ProtoMessage()    {}
+func (*RevokedCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{19}
+}
+
+func (m *RevokedCertificate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokedCertificate.Unmarshal(m, b)
+}
+func (m *RevokedCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokedCertificate.Marshal(b, m, deterministic)
+}
+func (m *RevokedCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokedCertificate.Merge(m, src)
+}
+func (m *RevokedCertificate) XXX_Size() int {
+	return xxx_messageInfo_RevokedCertificate.Size(m)
+}
+func (m *RevokedCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokedCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokedCertificate proto.InternalMessageInfo
+
+func (m *RevokedCertificate) GetSerial() string {
+	if m != nil {
+		return m.Serial
+	}
+	return ""
+}
+
+func (m *RevokedCertificate) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *RevokedCertificate) GetRevokedBy() string {
+	if m != nil {
+		return m.RevokedBy
+	}
+	return ""
+}
+
+type ListOfRevokedCertificates struct {
+	Certificates         []*RevokedCertificate `protobuf:"bytes,1,rep,name=certificates,proto3" json:"certificates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ListOfRevokedCertificates) Reset()         { *m = ListOfRevokedCertificates{} }
+func (m *ListOfRevokedCertificates) String() string { return proto.CompactTextString(m) }
+func (*ListOfRevokedCertificates) ProtoMessage()    {}
+func (*ListOfRevokedCertificates) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{20}
+}
+
+func (m *ListOfRevokedCertificates) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOfRevokedCertificates.Unmarshal(m, b)
+}
+func (m *ListOfRevokedCertificates) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOfRevokedCertificates.Marshal(b, m, deterministic)
+}
+func (m *ListOfRevokedCertificates) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOfRevokedCertificates.Merge(m, src)
+}
+func (m *ListOfRevokedCertificates) XXX_Size() int {
+	return xxx_messageInfo_ListOfRevokedCertificates.Size(m)
+}
+func (m *ListOfRevokedCertificates) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOfRevokedCertificates.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOfRevokedCertificates proto.InternalMessageInfo
+
+func (m *ListOfRevokedCertificates) GetCertificates() []*RevokedCertificate {
+	if m != nil {
+		return m.Certificates
+	}
+	return nil
+}
+
+type ListRevokedCertificatesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRevokedCertificatesRequest) Reset()         { *m = ListRevokedCertificatesRequest{} }
+func (m *ListRevokedCertificatesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRevokedCertificatesRequest) ProtoMessage()    {}
+func (*ListRevokedCertificatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{21}
+}
+
+func (m *ListRevokedCertificatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRevokedCertificatesRequest.Unmarshal(m, b)
+}
+func (m *ListRevokedCertificatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRevokedCertificatesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRevokedCertificatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRevokedCertificatesRequest.Merge(m, src)
+}
+func (m *ListRevokedCertificatesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRevokedCertificatesRequest.Size(m)
+}
+func (m *ListRevokedCertificatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRevokedCertificatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRevokedCertificatesRequest proto.InternalMessageInfo
+
+type RequestWithSerial struct {
+	Serial               string   `protobuf:"bytes,1,opt,name=serial,proto3" json:"serial,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestWithSerial) Reset()         { *m = RequestWithSerial{} }
+func (m *RequestWithSerial) String() string { return proto.CompactTextString(m) }
+func (*RequestWithSerial) ProtoMessage()    {}
+func (*RequestWithSerial) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{22}
+}
+
+func (m *RequestWithSerial) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestWithSerial.Unmarshal(m, b)
+}
+func (m *RequestWithSerial) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestWithSerial.Marshal(b, m, deterministic)
+}
+func (m *RequestWithSerial) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestWithSerial.Merge(m, src)
+}
+func (m *RequestWithSerial) XXX_Size() int {
+	return xxx_messageInfo_RequestWithSerial.Size(m)
+}
+func (m *RequestWithSerial) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestWithSerial.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestWithSerial proto.InternalMessageInfo
+
+func (m *RequestWithSerial) GetSerial() string {
+	if m != nil {
+		return m.Serial
+	}
+	return ""
+}
+
+type IssueCertificateRequest struct {
+	// PEM encoded certificate signing request, its common name becomes the
+	// user name
+	Csr string `protobuf:"bytes,1,opt,name=csr,proto3" json:"csr,omitempty"`
+	// grants in the "project.kind" format
+	Grants []string `protobuf:"bytes,2,rep,name=grants,proto3" json:"grants,omitempty"`
+	// validity of the certificate, the server default if not set
+	LifetimeSeconds      int64    `protobuf:"varint,3,opt,name=lifetime_seconds,json=lifetimeSeconds,proto3" json:"lifetime_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssueCertificateRequest) Reset()         { *m = IssueCertificateRequest{} }
+func (m *IssueCertificateRequest) String() string { return proto.CompactTextString(m) }
+func (*IssueCertificateRequest) ProtoMessage()    {}
+func (*IssueCertificateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{23}
+}
+
+func (m *IssueCertificateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssueCertificateRequest.Unmarshal(m, b)
+}
+func (m *IssueCertificateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssueCertificateRequest.Marshal(b, m, deterministic)
+}
+func (m *IssueCertificateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssueCertificateRequest.Merge(m, src)
+}
+func (m *IssueCertificateRequest) XXX_Size() int {
+	return xxx_messageInfo_IssueCertificateRequest.Size(m)
+}
+func (m *IssueCertificateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssueCertificateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssueCertificateRequest proto.InternalMessageInfo
+
+func (m *IssueCertificateRequest) GetCsr() string {
+	if m != nil {
+		return m.Csr
+	}
+	return ""
+}
+
+func (m *IssueCertificateRequest) GetGrants() []string {
+	if m != nil {
+		return m.Grants
+	}
+	return nil
+}
+
+func (m *IssueCertificateRequest) GetLifetimeSeconds() int64 {
+	if m != nil {
+		return m.LifetimeSeconds
+	}
+	return 0
+}
+
+type RenewCertificateRequest struct {
+	// PEM encoded certificate signing request for the new key
+	Csr                  string   `protobuf:"bytes,1,opt,name=csr,proto3" json:"csr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RenewCertificateRequest) Reset()         { *m = RenewCertificateRequest{} }
+func (m *RenewCertificateRequest) String() string { return proto.CompactTextString(m) }
+func (*RenewCertificateRequest) ProtoMessage()    {}
+func (*RenewCertificateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{24}
+}
+
+func (m *RenewCertificateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenewCertificateRequest.Unmarshal(m, b)
+}
+func (m *RenewCertificateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenewCertificateRequest.Marshal(b, m, deterministic)
+}
+func (m *RenewCertificateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenewCertificateRequest.Merge(m, src)
+}
+func (m *RenewCertificateRequest) XXX_Size() int {
+	return xxx_messageInfo_RenewCertificateRequest.Size(m)
+}
+func (m *RenewCertificateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenewCertificateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenewCertificateRequest proto.InternalMessageInfo
+
+func (m *RenewCertificateRequest) GetCsr() string {
+	if m != nil {
+		return m.Csr
+	}
+	return ""
+}
+
+type IssuedCertificate struct {
+	Serial     string   `protobuf:"bytes,1,opt,name=serial,proto3" json:"serial,omitempty"`
+	CommonName string   `protobuf:"bytes,2,opt,name=common_name,json=commonName,proto3" json:"common_name,omitempty"`
+	Grants     []string `protobuf:"bytes,3,rep,name=grants,proto3" json:"grants,omitempty"`
+	// PEM encoded certificate
+	Certificate string `protobuf:"bytes,4,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// expiry time, in seconds since the epoch
+	NotAfter             int64    `protobuf:"varint,5,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	IssuedBy             string   `protobuf:"bytes,6,opt,name=issued_by,json=issuedBy,proto3" json:"issued_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssuedCertificate) Reset()         { *m = IssuedCertificate{} }
+func (m *IssuedCertificate) String() string { return proto.CompactTextString(m) }
+func (*IssuedCertificate) ProtoMessage()    {}
+func (*IssuedCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{25}
+}
+
+func (m *IssuedCertificate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssuedCertificate.Unmarshal(m, b)
+}
+func (m *IssuedCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssuedCertificate.Marshal(b, m, deterministic)
+}
+func (m *IssuedCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssuedCertificate.Merge(m, src)
+}
+func (m *IssuedCertificate) XXX_Size() int {
+	return xxx_messageInfo_IssuedCertificate.Size(m)
+}
+func (m *IssuedCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssuedCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssuedCertificate proto.InternalMessageInfo
+
+func (m *IssuedCertificate) GetSerial() string {
+	if m != nil {
+		return m.Serial
+	}
+	return ""
+}
+
+func (m *IssuedCertificate) GetCommonName() string {
+	if m != nil {
+		return m.CommonName
+	}
+	return ""
+}
+
+func (m *IssuedCertificate) GetGrants() []string {
+	if m != nil {
+		return m.Grants
+	}
+	return nil
+}
+
+func (m *IssuedCertificate) GetCertificate() string {
+	if m != nil {
+		return m.Certificate
+	}
+	return ""
+}
+
+func (m *IssuedCertificate) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
+	}
+	return 0
+}
+
+func (m *IssuedCertificate) GetIssuedBy() string {
+	if m != nil {
+		return m.IssuedBy
+	}
+	return ""
+}
+
+// Schedule creates a job from template at the times given by a cron
+// expression.
+type Schedule struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// project, kind, input, metadata and max_runtime of the created jobs
+	Template *Job `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
+	// standard five field expression, or a descriptor such as @daily
+	Cron string `protobuf:"bytes,3,opt,name=cron,proto3" json:"cron,omitempty"`
+	// IANA time zone the expression is evaluated in, UTC if not set
+	Timezone string           `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Overlap  Schedule_Overlap `protobuf:"varint,5,opt,name=overlap,proto3,enum=Schedule_Overlap" json:"overlap,omitempty"`
+	Paused   bool             `protobuf:"varint,6,opt,name=paused,proto3" json:"paused,omitempty"`
+	// set by the server, in seconds since the epoch
+	NextRun              int64    `protobuf:"varint,7,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
+	LastRun              int64    `protobuf:"varint,8,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`
+	LastJobId            uint64   `protobuf:"varint,9,opt,name=last_job_id,json=lastJobId,proto3" json:"last_job_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Schedule) Reset()         { *m = Schedule{} }
+func (m *Schedule) String() string { return proto.CompactTextString(m) }
+func (*Schedule) ProtoMessage()    {}
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{26}
+}
+
+func (m *Schedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Schedule.Unmarshal(m, b)
+}
+func (m *Schedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Schedule.Marshal(b, m, deterministic)
+}
+func (m *Schedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schedule.Merge(m, src)
+}
+func (m *Schedule) XXX_Size() int {
+	return xxx_messageInfo_Schedule.Size(m)
+}
+func (m *Schedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_Schedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Schedule proto.InternalMessageInfo
+
+func (m *Schedule) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Schedule) GetTemplate() *Job {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+func (m *Schedule) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *Schedule) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *Schedule) GetOverlap() Schedule_Overlap {
+	if m != nil {
+		return m.Overlap
+	}
+	return Schedule_SKIP
+}
+
+func (m *Schedule) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+func (m *Schedule) GetNextRun() int64 {
+	if m != nil {
+		return m.NextRun
+	}
+	return 0
+}
+
+func (m *Schedule) GetLastRun() int64 {
+	if m != nil {
+		return m.LastRun
+	}
+	return 0
+}
+
+func (m *Schedule) GetLastJobId() uint64 {
+	if m != nil {
+		return m.LastJobId
+	}
+	return 0
+}
+
+type ListOfSchedules struct {
+	Schedules            []*Schedule `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListOfSchedules) Reset()         { *m = ListOfSchedules{} }
+func (m *ListOfSchedules) String() string { return proto.CompactTextString(m) }
+func (*ListOfSchedules) ProtoMessage()    {}
+func (*ListOfSchedules) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{27}
+}
+
+func (m *ListOfSchedules) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOfSchedules.Unmarshal(m, b)
+}
+func (m *ListOfSchedules) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOfSchedules.Marshal(b, m, deterministic)
+}
+func (m *ListOfSchedules) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOfSchedules.Merge(m, src)
+}
+func (m *ListOfSchedules) XXX_Size() int {
+	return xxx_messageInfo_ListOfSchedules.Size(m)
+}
+func (m *ListOfSchedules) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOfSchedules.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOfSchedules proto.InternalMessageInfo
+
+func (m *ListOfSchedules) GetSchedules() []*Schedule {
+	if m != nil {
+		return m.Schedules
+	}
+	return nil
+}
+
+type ListSchedulesRequest struct {
+	Project              string   `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSchedulesRequest) Reset()         { *m = ListSchedulesRequest{} }
+func (m *ListSchedulesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSchedulesRequest) ProtoMessage()    {}
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{28}
+}
+
+func (m *ListSchedulesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSchedulesRequest.Unmarshal(m, b)
+}
+func (m *ListSchedulesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSchedulesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListSchedulesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSchedulesRequest.Merge(m, src)
+}
+func (m *ListSchedulesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListSchedulesRequest.Size(m)
+}
+func (m *ListSchedulesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSchedulesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSchedulesRequest proto.InternalMessageInfo
+
+func (m *ListSchedulesRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+type PauseScheduleRequest struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// false resumes the schedule
+	Paused               bool     `protobuf:"varint,2,opt,name=paused,proto3" json:"paused,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PauseScheduleRequest) Reset()         { *m = PauseScheduleRequest{} }
+func (m *PauseScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*PauseScheduleRequest) ProtoMessage()    {}
+func (*PauseScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{29}
+}
+
+func (m *PauseScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseScheduleRequest.Unmarshal(m, b)
+}
+func (m *PauseScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseScheduleRequest.Marshal(b, m, deterministic)
+}
+func (m *PauseScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseScheduleRequest.Merge(m, src)
+}
+func (m *PauseScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_PauseScheduleRequest.Size(m)
+}
+func (m *PauseScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseScheduleRequest proto.InternalMessageInfo
+
+func (m *PauseScheduleRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *PauseScheduleRequest) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+// Webhook subscribes a URL to the status changes of the jobs of a project.
+// Empty filters match everything.
+type Webhook struct {
+	Id       uint64       `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Project  string       `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	Url      string       `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Statuses []Job_Status `protobuf:"varint,4,rep,packed,name=statuses,proto3,enum=Job_Status" json:"statuses,omitempty"`
+	Kinds    []string     `protobuf:"bytes,5,rep,name=kinds,proto3" json:"kinds,omitempty"`
+	// top-level fields the job metadata must have with these string values
+	Labels map[string]string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// key of the HMAC-SHA256 signature of deliveries, generated if left out;
+	// only returned by CreateWebhook
+	Secret string `protobuf:"bytes,7,opt,name=secret,proto3" json:"secret,omitempty"`
+	// set by the server, in seconds since the epoch
+	Created              int64    `protobuf:"varint,8,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Webhook) Reset()         { *m = Webhook{} }
+func (m *Webhook) String() string { return proto.CompactTextString(m) }
+func (*Webhook) ProtoMessage()    {}
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{30}
+}
+
+func (m *Webhook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Webhook.Unmarshal(m, b)
+}
+func (m *Webhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Webhook.Marshal(b, m, deterministic)
+}
+func (m *Webhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Webhook.Merge(m, src)
+}
+func (m *Webhook) XXX_Size() int {
+	return xxx_messageInfo_Webhook.Size(m)
+}
+func (m *Webhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_Webhook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Webhook proto.InternalMessageInfo
+
+func (m *Webhook) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Webhook) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *Webhook) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Webhook) GetStatuses() []Job_Status {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+func (m *Webhook) GetKinds() []string {
+	if m != nil {
+		return m.Kinds
+	}
+	return nil
+}
+
+func (m *Webhook) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Webhook) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *Webhook) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+type ListOfWebhooks struct {
+	Webhooks             []*Webhook `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListOfWebhooks) Reset()         { *m = ListOfWebhooks{} }
+func (m *ListOfWebhooks) String() string { return proto.CompactTextString(m) }
+func (*ListOfWebhooks) ProtoMessage()    {}
+func (*ListOfWebhooks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{31}
+}
+
+func (m *ListOfWebhooks) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOfWebhooks.Unmarshal(m, b)
+}
+func (m *ListOfWebhooks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOfWebhooks.Marshal(b, m, deterministic)
+}
+func (m *ListOfWebhooks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOfWebhooks.Merge(m, src)
+}
+func (m *ListOfWebhooks) XXX_Size() int {
+	return xxx_messageInfo_ListOfWebhooks.Size(m)
+}
+func (m *ListOfWebhooks) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOfWebhooks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOfWebhooks proto.InternalMessageInfo
+
+func (m *ListOfWebhooks) GetWebhooks() []*Webhook {
+	if m != nil {
+		return m.Webhooks
+	}
+	return nil
+}
+
+type ListWebhooksRequest struct {
+	Project              string   `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhooksRequest) Reset()         { *m = ListWebhooksRequest{} }
+func (m *ListWebhooksRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksRequest) ProtoMessage()    {}
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{32}
+}
+
+func (m *ListWebhooksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksRequest.Unmarshal(m, b)
+}
+func (m *ListWebhooksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksRequest.Merge(m, src)
+}
+func (m *ListWebhooksRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksRequest.Size(m)
+}
+func (m *ListWebhooksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksRequest proto.InternalMessageInfo
+
+func (m *ListWebhooksRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+// WebhookDelivery is the notification of a job status change to a webhook,
+// kept as a delivery log.
+type WebhookDelivery struct {
+	Id        uint64                `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId uint64                `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	JobId     uint64                `protobuf:"varint,3,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status    Job_Status            `protobuf:"varint,4,opt,name=status,proto3,enum=Job_Status" json:"status,omitempty"`
+	State     WebhookDelivery_State `protobuf:"varint,5,opt,name=state,proto3,enum=WebhookDelivery_State" json:"state,omitempty"`
+	Attempts  uint32                `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// times in seconds since the epoch
+	Created              int64    `protobuf:"varint,7,opt,name=created,proto3" json:"created,omitempty"`
+	NextAttempt          int64    `protobuf:"varint,8,opt,name=next_attempt,json=nextAttempt,proto3" json:"next_attempt,omitempty"`
+	DeliveredAt          int64    `protobuf:"varint,9,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	LastError            string   `protobuf:"bytes,10,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastResponseCode     int32    `protobuf:"varint,11,opt,name=last_response_code,json=lastResponseCode,proto3" json:"last_response_code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WebhookDelivery) Reset()         { *m = WebhookDelivery{} }
+func (m *WebhookDelivery) String() string { return proto.CompactTextString(m) }
+func (*WebhookDelivery) ProtoMessage()    {}
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{33}
+}
+
+func (m *WebhookDelivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDelivery.Unmarshal(m, b)
+}
+func (m *WebhookDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDelivery.Marshal(b, m, deterministic)
+}
+func (m *WebhookDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDelivery.Merge(m, src)
+}
+func (m *WebhookDelivery) XXX_Size() int {
+	return xxx_messageInfo_WebhookDelivery.Size(m)
+}
+func (m *WebhookDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDelivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDelivery proto.InternalMessageInfo
+
+func (m *WebhookDelivery) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetWebhookId() uint64 {
+	if m != nil {
+		return m.WebhookId
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetJobId() uint64 {
+	if m != nil {
+		return m.JobId
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetStatus() Job_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Job_PENDING
+}
+
+func (m *WebhookDelivery) GetState() WebhookDelivery_State {
+	if m != nil {
+		return m.State
+	}
+	return WebhookDelivery_PENDING
+}
+
+func (m *WebhookDelivery) GetAttempts() uint32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetNextAttempt() int64 {
+	if m != nil {
+		return m.NextAttempt
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetDeliveredAt() int64 {
+	if m != nil {
+		return m.DeliveredAt
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetLastResponseCode() int32 {
+	if m != nil {
+		return m.LastResponseCode
+	}
+	return 0
+}
+
+type ListOfWebhookDeliveries struct {
+	Deliveries           []*WebhookDelivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListOfWebhookDeliveries) Reset()         { *m = ListOfWebhookDeliveries{} }
+func (m *ListOfWebhookDeliveries) String() string { return proto.CompactTextString(m) }
+func (*ListOfWebhookDeliveries) ProtoMessage()    {}
+func (*ListOfWebhookDeliveries) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{34}
+}
+
+func (m *ListOfWebhookDeliveries) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOfWebhookDeliveries.Unmarshal(m, b)
+}
+func (m *ListOfWebhookDeliveries) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOfWebhookDeliveries.Marshal(b, m, deterministic)
+}
+func (m *ListOfWebhookDeliveries) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOfWebhookDeliveries.Merge(m, src)
+}
+func (m *ListOfWebhookDeliveries) XXX_Size() int {
+	return xxx_messageInfo_ListOfWebhookDeliveries.Size(m)
+}
+func (m *ListOfWebhookDeliveries) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOfWebhookDeliveries.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOfWebhookDeliveries proto.InternalMessageInfo
+
+func (m *ListOfWebhookDeliveries) GetDeliveries() []*WebhookDelivery {
+	if m != nil {
+		return m.Deliveries
+	}
+	return nil
+}
+
+type ListWebhookDeliveriesRequest struct {
+	WebhookId uint64 `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	HowMany   uint32 `protobuf:"varint,2,opt,name=how_many,json=howMany,proto3" json:"how_many,omitempty"`
+	// only the deliveries given up on
+	OnlyDead             bool     `protobuf:"varint,3,opt,name=only_dead,json=onlyDead,proto3" json:"only_dead,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhookDeliveriesRequest) Reset()         { *m = ListWebhookDeliveriesRequest{} }
+func (m *ListWebhookDeliveriesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWebhookDeliveriesRequest) ProtoMessage()    {}
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{35}
+}
+
+func (m *ListWebhookDeliveriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhookDeliveriesRequest.Unmarshal(m, b)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhookDeliveriesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhookDeliveriesRequest.Merge(m, src)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWebhookDeliveriesRequest.Size(m)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhookDeliveriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhookDeliveriesRequest proto.InternalMessageInfo
+
+func (m *ListWebhookDeliveriesRequest) GetWebhookId() uint64 {
+	if m != nil {
+		return m.WebhookId
+	}
+	return 0
+}
+
+func (m *ListWebhookDeliveriesRequest) GetHowMany() uint32 {
+	if m != nil {
+		return m.HowMany
+	}
+	return 0
+}
+
+func (m *ListWebhookDeliveriesRequest) GetOnlyDead() bool {
+	if m != nil {
+		return m.OnlyDead
+	}
+	return false
+}
+
+// Kind registers JSON Schemas for the input and output of the jobs of a kind
+// in a project. Registering a kind again adds a version: new jobs are
+// validated against the latest one, and jobs keep the version they were
+// created with.
+type Kind struct {
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// set by the server, counting from 1
+	Version uint32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// JSON Schemas, empty accepts anything; $ref may only point into the
+	// schema itself
+	InputSchema  string `protobuf:"bytes,4,opt,name=input_schema,json=inputSchema,proto3" json:"input_schema,omitempty"`
+	OutputSchema string `protobuf:"bytes,5,opt,name=output_schema,json=outputSchema,proto3" json:"output_schema,omitempty"`
+	Description  string `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	// set by the server, in seconds since the epoch
+	Created              int64    `protobuf:"varint,7,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Kind) Reset()         { *m = Kind{} }
+func (m *Kind) String() string { return proto.CompactTextString(m) }
+func (*Kind) ProtoMessage()    {}
+func (*Kind) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{36}
+}
+
+func (m *Kind) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Kind.Unmarshal(m, b)
+}
+func (m *Kind) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Kind.Marshal(b, m, deterministic)
+}
+func (m *Kind) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Kind.Merge(m, src)
+}
+func (m *Kind) XXX_Size() int {
+	return xxx_messageInfo_Kind.Size(m)
+}
+func (m *Kind) XXX_DiscardUnknown() {
+	xxx_messageInfo_Kind.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Kind proto.InternalMessageInfo
+
+func (m *Kind) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *Kind) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Kind) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Kind) GetInputSchema() string {
+	if m != nil {
+		return m.InputSchema
+	}
+	return ""
+}
+
+func (m *Kind) GetOutputSchema() string {
+	if m != nil {
+		return m.OutputSchema
+	}
+	return ""
+}
+
+func (m *Kind) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Kind) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+type GetKindRequest struct {
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// the latest version if 0
+	Version              uint32   `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetKindRequest) Reset()         { *m = GetKindRequest{} }
+func (m *GetKindRequest) String() string { return proto.CompactTextString(m) }
+func (*GetKindRequest) ProtoMessage()    {}
+func (*GetKindRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{37}
+}
+
+func (m *GetKindRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetKindRequest.Unmarshal(m, b)
+}
+func (m *GetKindRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetKindRequest.Marshal(b, m, deterministic)
+}
+func (m *GetKindRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetKindRequest.Merge(m, src)
+}
+func (m *GetKindRequest) XXX_Size() int {
+	return xxx_messageInfo_GetKindRequest.Size(m)
+}
+func (m *GetKindRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetKindRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetKindRequest proto.InternalMessageInfo
+
+func (m *GetKindRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *GetKindRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetKindRequest) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type ListKindsRequest struct {
+	Project              string   `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListKindsRequest) Reset()         { *m = ListKindsRequest{} }
+func (m *ListKindsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListKindsRequest) ProtoMessage()    {}
+func (*ListKindsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{38}
+}
+
+func (m *ListKindsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListKindsRequest.Unmarshal(m, b)
+}
+func (m *ListKindsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListKindsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListKindsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListKindsRequest.Merge(m, src)
+}
+func (m *ListKindsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListKindsRequest.Size(m)
+}
+func (m *ListKindsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListKindsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListKindsRequest proto.InternalMessageInfo
+
+func (m *ListKindsRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+// ListOfKinds holds the latest version of each kind.
+type ListOfKinds struct {
+	Kinds                []*Kind  `protobuf:"bytes,1,rep,name=kinds,proto3" json:"kinds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOfKinds) Reset()         { *m = ListOfKinds{} }
+func (m *ListOfKinds) String() string { return proto.CompactTextString(m) }
+func (*ListOfKinds) ProtoMessage()    {}
+func (*ListOfKinds) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{39}
+}
+
+func (m *ListOfKinds) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOfKinds.Unmarshal(m, b)
+}
+func (m *ListOfKinds) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOfKinds.Marshal(b, m, deterministic)
+}
+func (m *ListOfKinds) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOfKinds.Merge(m, src)
+}
+func (m *ListOfKinds) XXX_Size() int {
+	return xxx_messageInfo_ListOfKinds.Size(m)
+}
+func (m *ListOfKinds) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOfKinds.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOfKinds proto.InternalMessageInfo
+
+func (m *ListOfKinds) GetKinds() []*Kind {
+	if m != nil {
+		return m.Kinds
+	}
+	return nil
+}
+
+// TemplateParameter declares a parameter of a job template, used as
+// {{name}} in its input and metadata.
+type TemplateParameter struct {
+	Name string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type TemplateParameter_Type `protobuf:"varint,2,opt,name=type,proto3,enum=TemplateParameter_Type" json:"type,omitempty"`
+	// value used when the parameter is left out, in the form values are
+	// given to CreateJobFromTemplate; parameters without one are required
+	DefaultValue         string   `protobuf:"bytes,3,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	HasDefault           bool     `protobuf:"varint,4,opt,name=has_default,json=hasDefault,proto3" json:"has_default,omitempty"`
+	Description          string   `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TemplateParameter) Reset()         { *m = TemplateParameter{} }
+func (m *TemplateParameter) String() string { return proto.CompactTextString(m) }
+func (*TemplateParameter) ProtoMessage()    {}
+func (*TemplateParameter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{40}
+}
+
+func (m *TemplateParameter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TemplateParameter.Unmarshal(m, b)
+}
+func (m *TemplateParameter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TemplateParameter.Marshal(b, m, deterministic)
+}
+func (m *TemplateParameter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TemplateParameter.Merge(m, src)
+}
+func (m *TemplateParameter) XXX_Size() int {
+	return xxx_messageInfo_TemplateParameter.Size(m)
+}
+func (m *TemplateParameter) XXX_DiscardUnknown() {
+	xxx_messageInfo_TemplateParameter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TemplateParameter proto.InternalMessageInfo
+
+func (m *TemplateParameter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TemplateParameter) GetType() TemplateParameter_Type {
+	if m != nil {
+		return m.Type
+	}
+	return TemplateParameter_STRING
+}
+
+func (m *TemplateParameter) GetDefaultValue() string {
+	if m != nil {
+		return m.DefaultValue
+	}
+	return ""
+}
+
+func (m *TemplateParameter) GetHasDefault() bool {
+	if m != nil {
+		return m.HasDefault
+	}
+	return false
+}
+
+func (m *TemplateParameter) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+// JobTemplate is a job with parameters. Creating a template with the name of
+// an existing one in the project adds a version with the same id.
+type JobTemplate struct {
+	// set by the server
+	Id      uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Project string `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	Name    string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// set by the server, counting from 1
+	Version uint32 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Kind    string `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+	// in JSON input and metadata, strings which are just a placeholder are
+	// replaced by the typed value, and placeholders within strings by its
+	// text; other input and metadata are rendered as text
+	Input       string               `protobuf:"bytes,6,opt,name=input,proto3" json:"input,omitempty"`
+	Metadata    string               `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	MaxRuntime  int64                `protobuf:"varint,8,opt,name=max_runtime,json=maxRuntime,proto3" json:"max_runtime,omitempty"`
+	Parameters  []*TemplateParameter `protobuf:"bytes,9,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	Description string               `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	// set by the server, in seconds since the epoch
+	Created              int64    `protobuf:"varint,11,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobTemplate) Reset()         { *m = JobTemplate{} }
+func (m *JobTemplate) String() string { return proto.CompactTextString(m) }
+func (*JobTemplate) ProtoMessage()    {}
+func (*JobTemplate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{41}
+}
+
+func (m *JobTemplate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobTemplate.Unmarshal(m, b)
+}
+func (m *JobTemplate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobTemplate.Marshal(b, m, deterministic)
+}
+func (m *JobTemplate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobTemplate.Merge(m, src)
+}
+func (m *JobTemplate) XXX_Size() int {
+	return xxx_messageInfo_JobTemplate.Size(m)
+}
+func (m *JobTemplate) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobTemplate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobTemplate proto.InternalMessageInfo
+
+func (m *JobTemplate) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *JobTemplate) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *JobTemplate) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *JobTemplate) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *JobTemplate) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *JobTemplate) GetInput() string {
+	if m != nil {
+		return m.Input
+	}
+	return ""
+}
+
+func (m *JobTemplate) GetMetadata() string {
+	if m != nil {
+		return m.Metadata
+	}
+	return ""
+}
+
+func (m *JobTemplate) GetMaxRuntime() int64 {
+	if m != nil {
+		return m.MaxRuntime
+	}
+	return 0
+}
+
+func (m *JobTemplate) GetParameters() []*TemplateParameter {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+func (m *JobTemplate) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *JobTemplate) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+type ListJobTemplatesRequest struct {
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	// every version of the template with this name instead of the latest
+	// version of each template
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobTemplatesRequest) Reset()         { *m = ListJobTemplatesRequest{} }
+func (m *ListJobTemplatesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListJobTemplatesRequest) ProtoMessage()    {}
+func (*ListJobTemplatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{42}
+}
+
+func (m *ListJobTemplatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobTemplatesRequest.Unmarshal(m, b)
+}
+func (m *ListJobTemplatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobTemplatesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListJobTemplatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobTemplatesRequest.Merge(m, src)
+}
+func (m *ListJobTemplatesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListJobTemplatesRequest.Size(m)
+}
+func (m *ListJobTemplatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobTemplatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobTemplatesRequest proto.InternalMessageInfo
+
+func (m *ListJobTemplatesRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *ListJobTemplatesRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListOfJobTemplates struct {
+	Templates            []*JobTemplate `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListOfJobTemplates) Reset()         { *m = ListOfJobTemplates{} }
+func (m *ListOfJobTemplates) String() string { return proto.CompactTextString(m) }
+func (*ListOfJobTemplates) ProtoMessage()    {}
+func (*ListOfJobTemplates) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{43}
+}
+
+func (m *ListOfJobTemplates) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOfJobTemplates.Unmarshal(m, b)
+}
+func (m *ListOfJobTemplates) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOfJobTemplates.Marshal(b, m, deterministic)
+}
+func (m *ListOfJobTemplates) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOfJobTemplates.Merge(m, src)
+}
+func (m *ListOfJobTemplates) XXX_Size() int {
+	return xxx_messageInfo_ListOfJobTemplates.Size(m)
+}
+func (m *ListOfJobTemplates) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOfJobTemplates.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOfJobTemplates proto.InternalMessageInfo
+
+func (m *ListOfJobTemplates) GetTemplates() []*JobTemplate {
+	if m != nil {
+		return m.Templates
+	}
+	return nil
+}
+
+type CreateJobFromTemplateRequest struct {
+	Project string `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// the latest version if 0
+	Version uint32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// values as text, such as "42" or "true", parsed according to the
+	// types of the parameters
+	Parameters map[string]string `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// as in Job
+	IdempotencyKey       string   `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	RunAfter             int64    `protobuf:"varint,6,opt,name=run_after,json=runAfter,proto3" json:"run_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateJobFromTemplateRequest) Reset()         { *m = CreateJobFromTemplateRequest{} }
+func (m *CreateJobFromTemplateRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateJobFromTemplateRequest) ProtoMessage()    {}
+func (*CreateJobFromTemplateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{44}
+}
+
+func (m *CreateJobFromTemplateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateJobFromTemplateRequest.Unmarshal(m, b)
+}
+func (m *CreateJobFromTemplateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateJobFromTemplateRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateJobFromTemplateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateJobFromTemplateRequest.Merge(m, src)
+}
+func (m *CreateJobFromTemplateRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateJobFromTemplateRequest.Size(m)
+}
+func (m *CreateJobFromTemplateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateJobFromTemplateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateJobFromTemplateRequest proto.InternalMessageInfo
+
+func (m *CreateJobFromTemplateRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *CreateJobFromTemplateRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateJobFromTemplateRequest) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *CreateJobFromTemplateRequest) GetParameters() map[string]string {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+func (m *CreateJobFromTemplateRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+func (m *CreateJobFromTemplateRequest) GetRunAfter() int64 {
+	if m != nil {
+		return m.RunAfter
+	}
+	return 0
+}
+
+// ArrayJob creates many jobs differing in a few parameters at once, such as
+// the jobs of a parameter sweep.
+type ArrayJob struct {
+	// set by the server
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// project, kind, input, metadata, run_after, max_runtime and deadline
+	// of the jobs; {{name}} placeholders in input and metadata are rendered
+	// with the parameters of each job as in job templates
+	Base       *Job                 `protobuf:"bytes,2,opt,name=base,proto3" json:"base,omitempty"`
+	Parameters []*TemplateParameter `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// one job for every combination of these values, the last parameter
+	// varying fastest; values are given as text as in CreateJobFromTemplate
+	Grid []*ArrayAxis `protobuf:"bytes,4,rep,name=grid,proto3" json:"grid,omitempty"`
+	// or one job for each of these parameter sets
+	ParameterSets []*ParameterSet `protobuf:"bytes,5,rep,name=parameter_sets,json=parameterSets,proto3" json:"parameter_sets,omitempty"`
+	// number of jobs, set by the server
+	Size_ uint32 `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	// set by the server, in seconds since the epoch
+	Created int64 `protobuf:"varint,7,opt,name=created,proto3" json:"created,omitempty"`
+	// set by the server when the array job is read
+	Status               *ArrayStatus `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ArrayJob) Reset()         { *m = ArrayJob{} }
+func (m *ArrayJob) String() string { return proto.CompactTextString(m) }
+func (*ArrayJob) ProtoMessage()    {}
+func (*ArrayJob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{45}
+}
+
+func (m *ArrayJob) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArrayJob.Unmarshal(m, b)
+}
+func (m *ArrayJob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArrayJob.Marshal(b, m, deterministic)
+}
+func (m *ArrayJob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArrayJob.Merge(m, src)
+}
+func (m *ArrayJob) XXX_Size() int {
+	return xxx_messageInfo_ArrayJob.Size(m)
+}
+func (m *ArrayJob) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArrayJob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArrayJob proto.InternalMessageInfo
+
+func (m *ArrayJob) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ArrayJob) GetBase() *Job {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *ArrayJob) GetParameters() []*TemplateParameter {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+func (m *ArrayJob) GetGrid() []*ArrayAxis {
+	if m != nil {
+		return m.Grid
+	}
+	return nil
+}
+
+func (m *ArrayJob) GetParameterSets() []*ParameterSet {
+	if m != nil {
+		return m.ParameterSets
+	}
+	return nil
+}
+
+func (m *ArrayJob) GetSize_() uint32 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+func (m *ArrayJob) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *ArrayJob) GetStatus() *ArrayStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type ArrayAxis struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values               []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArrayAxis) Reset()         { *m = ArrayAxis{} }
+func (m *ArrayAxis) String() string { return proto.CompactTextString(m) }
+func (*ArrayAxis) ProtoMessage()    {}
+func (*ArrayAxis) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{46}
+}
+
+func (m *ArrayAxis) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArrayAxis.Unmarshal(m, b)
+}
+func (m *ArrayAxis) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArrayAxis.Marshal(b, m, deterministic)
+}
+func (m *ArrayAxis) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArrayAxis.Merge(m, src)
+}
+func (m *ArrayAxis) XXX_Size() int {
+	return xxx_messageInfo_ArrayAxis.Size(m)
+}
+func (m *ArrayAxis) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArrayAxis.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArrayAxis proto.InternalMessageInfo
+
+func (m *ArrayAxis) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ArrayAxis) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type ParameterSet struct {
+	Parameters           map[string]string `protobuf:"bytes,1,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ParameterSet) Reset()         { *m = ParameterSet{} }
+func (m *ParameterSet) String() string { return proto.CompactTextString(m) }
+func (*ParameterSet) ProtoMessage()    {}
+func (*ParameterSet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{47}
+}
+
+func (m *ParameterSet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParameterSet.Unmarshal(m, b)
+}
+func (m *ParameterSet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParameterSet.Marshal(b, m, deterministic)
+}
+func (m *ParameterSet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParameterSet.Merge(m, src)
+}
+func (m *ParameterSet) XXX_Size() int {
+	return xxx_messageInfo_ParameterSet.Size(m)
+}
+func (m *ParameterSet) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParameterSet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParameterSet proto.InternalMessageInfo
+
+func (m *ParameterSet) GetParameters() map[string]string {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+// ArrayStatus counts the jobs of an array job by status. Deleted jobs are
+// not counted.
+type ArrayStatus struct {
+	Pending   uint32 `protobuf:"varint,1,opt,name=pending,proto3" json:"pending,omitempty"`
+	Pulled    uint32 `protobuf:"varint,2,opt,name=pulled,proto3" json:"pulled,omitempty"`
+	Running   uint32 `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
+	Completed uint32 `protobuf:"varint,4,opt,name=completed,proto3" json:"completed,omitempty"`
+	Failed    uint32 `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"`
+	Killed    uint32 `protobuf:"varint,6,opt,name=killed,proto3" json:"killed,omitempty"`
+	Expired   uint32 `protobuf:"varint,7,opt,name=expired,proto3" json:"expired,omitempty"`
+	// completed, failed, killed and expired jobs
+	Done                 uint32   `protobuf:"varint,8,opt,name=done,proto3" json:"done,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArrayStatus) Reset()         { *m = ArrayStatus{} }
+func (m *ArrayStatus) String() string { return proto.CompactTextString(m) }
+func (*ArrayStatus) ProtoMessage()    {}
+func (*ArrayStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{48}
+}
+
+func (m *ArrayStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArrayStatus.Unmarshal(m, b)
+}
+func (m *ArrayStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArrayStatus.Marshal(b, m, deterministic)
+}
+func (m *ArrayStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArrayStatus.Merge(m, src)
+}
+func (m *ArrayStatus) XXX_Size() int {
+	return xxx_messageInfo_ArrayStatus.Size(m)
+}
+func (m *ArrayStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArrayStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArrayStatus proto.InternalMessageInfo
+
+func (m *ArrayStatus) GetPending() uint32 {
+	if m != nil {
+		return m.Pending
+	}
+	return 0
+}
+
+func (m *ArrayStatus) GetPulled() uint32 {
+	if m != nil {
+		return m.Pulled
+	}
+	return 0
+}
+
+func (m *ArrayStatus) GetRunning() uint32 {
+	if m != nil {
+		return m.Running
+	}
+	return 0
+}
+
+func (m *ArrayStatus) GetCompleted() uint32 {
+	if m != nil {
+		return m.Completed
+	}
+	return 0
+}
+
+func (m *ArrayStatus) GetFailed() uint32 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+func (m *ArrayStatus) GetKilled() uint32 {
+	if m != nil {
+		return m.Killed
+	}
+	return 0
+}
+
+func (m *ArrayStatus) GetExpired() uint32 {
+	if m != nil {
+		return m.Expired
+	}
+	return 0
+}
+
+func (m *ArrayStatus) GetDone() uint32 {
+	if m != nil {
+		return m.Done
+	}
+	return 0
+}
+
+// JobEvent is an entry of the change feed of jobs, one for every change of a
+// job stored by the server except progress reports. Sequences increase in the
+// order the changes were committed, with gaps, so consumers resume after the
+// last sequence they processed. Events are kept for event_retention.
+type JobEvent struct {
+	Sequence       uint64        `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Type           JobEvent_Type `protobuf:"varint,2,opt,name=type,proto3,enum=JobEvent_Type" json:"type,omitempty"`
+	JobId          uint64        `protobuf:"varint,3,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Project        string        `protobuf:"bytes,4,opt,name=project,proto3" json:"project,omitempty"`
+	Kind           string        `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+	Status         Job_Status    `protobuf:"varint,6,opt,name=status,proto3,enum=Job_Status" json:"status,omitempty"`
+	PreviousStatus Job_Status    `protobuf:"varint,7,opt,name=previous_status,json=previousStatus,proto3,enum=Job_Status" json:"previous_status,omitempty"`
+	// names of the fields of the job that changed
+	Changed []string `protobuf:"bytes,8,rep,name=changed,proto3" json:"changed,omitempty"`
+	// the job after the change, before it for PURGED
+	Job *Job `protobuf:"bytes,9,opt,name=job,proto3" json:"job,omitempty"`
+	// who made the change, empty for the server itself
+	Principal string `protobuf:"bytes,10,opt,name=principal,proto3" json:"principal,omitempty"`
+	// time of the change, in seconds since the epoch
+	Created              int64    `protobuf:"varint,11,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobEvent) Reset()         { *m = JobEvent{} }
+func (m *JobEvent) String() string { return proto.CompactTextString(m) }
+func (*JobEvent) ProtoMessage()    {}
+func (*JobEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{49}
+}
+
+func (m *JobEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobEvent.Unmarshal(m, b)
+}
+func (m *JobEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobEvent.Marshal(b, m, deterministic)
+}
+func (m *JobEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobEvent.Merge(m, src)
+}
+func (m *JobEvent) XXX_Size() int {
+	return xxx_messageInfo_JobEvent.Size(m)
+}
+func (m *JobEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobEvent proto.InternalMessageInfo
+
+func (m *JobEvent) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *JobEvent) GetType() JobEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return JobEvent_CREATED
+}
+
+func (m *JobEvent) GetJobId() uint64 {
+	if m != nil {
+		return m.JobId
+	}
+	return 0
+}
+
+func (m *JobEvent) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *JobEvent) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *JobEvent) GetStatus() Job_Status {
+	if m != nil {
+		return m.Status
+	}
+	return Job_PENDING
+}
+
+func (m *JobEvent) GetPreviousStatus() Job_Status {
+	if m != nil {
+		return m.PreviousStatus
+	}
+	return Job_PENDING
+}
+
+func (m *JobEvent) GetChanged() []string {
+	if m != nil {
+		return m.Changed
+	}
+	return nil
+}
+
+func (m *JobEvent) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *JobEvent) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
+}
+
+func (m *JobEvent) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+type StreamEventsRequest struct {
+	// only events with at least this sequence, all retained events if 0
+	FromSequence uint64 `protobuf:"varint,1,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"`
+	Project      string `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	Kind         string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	// keep sending new events until the client goes away
+	Follow               bool     `protobuf:"varint,4,opt,name=follow,proto3" json:"follow,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamEventsRequest) Reset()         { *m = StreamEventsRequest{} }
+func (m *StreamEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamEventsRequest) ProtoMessage()    {}
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{50}
+}
+
+func (m *StreamEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamEventsRequest.Unmarshal(m, b)
+}
+func (m *StreamEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamEventsRequest.Merge(m, src)
+}
+func (m *StreamEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamEventsRequest.Size(m)
+}
+func (m *StreamEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamEventsRequest proto.InternalMessageInfo
+
+func (m *StreamEventsRequest) GetFromSequence() uint64 {
+	if m != nil {
+		return m.FromSequence
+	}
+	return 0
+}
+
+func (m *StreamEventsRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *StreamEventsRequest) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *StreamEventsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+// AuditEvent records a change made through the API. Events are written in
+// the same transaction as the change and never modified.
+type AuditEvent struct {
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// time of the change, in seconds since the epoch
+	Created   int64  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Principal string `protobuf:"bytes,3,opt,name=principal,proto3" json:"principal,omitempty"`
+	// full gRPC method name, or HTTP method and path for REST requests
+	Rpc string `protobuf:"bytes,4,opt,name=rpc,proto3" json:"rpc,omitempty"`
+	// changed job, 0 for other objects
+	JobId   uint64 `protobuf:"varint,5,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Project string `protobuf:"bytes,6,opt,name=project,proto3" json:"project,omitempty"`
+	// JSON of the changed fields of the object, or of the whole object if it
+	// did not exist before or after, in which case the other is empty
+	Before string `protobuf:"bytes,7,opt,name=before,proto3" json:"before,omitempty"`
+	After  string `protobuf:"bytes,8,opt,name=after,proto3" json:"after,omitempty"`
+	// names of the fields that differ between before and after
+	Changed     []string `protobuf:"bytes,9,rep,name=changed,proto3" json:"changed,omitempty"`
+	PeerAddress string   `protobuf:"bytes,10,opt,name=peer_address,json=peerAddress,proto3" json:"peer_address,omitempty"`
+	// serial of the client certificate, empty for token authentication
+	CertSerial           string   `protobuf:"bytes,11,opt,name=cert_serial,json=certSerial,proto3" json:"cert_serial,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditEvent) Reset()         { *m = AuditEvent{} }
+func (m *AuditEvent) String() string { return proto.CompactTextString(m) }
+func (*AuditEvent) ProtoMessage()    {}
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{51}
+}
+
+func (m *AuditEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEvent.Unmarshal(m, b)
+}
+func (m *AuditEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEvent.Marshal(b, m, deterministic)
+}
+func (m *AuditEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEvent.Merge(m, src)
+}
+func (m *AuditEvent) XXX_Size() int {
+	return xxx_messageInfo_AuditEvent.Size(m)
+}
+func (m *AuditEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEvent proto.InternalMessageInfo
+
+func (m *AuditEvent) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *AuditEvent) GetCreated() int64 {
+	if m != nil {
+		return m.Created
+	}
+	return 0
+}
+
+func (m *AuditEvent) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetRpc() string {
+	if m != nil {
+		return m.Rpc
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetJobId() uint64 {
+	if m != nil {
+		return m.JobId
+	}
+	return 0
+}
+
+func (m *AuditEvent) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetBefore() string {
+	if m != nil {
+		return m.Before
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetAfter() string {
+	if m != nil {
+		return m.After
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetChanged() []string {
+	if m != nil {
+		return m.Changed
+	}
+	return nil
+}
+
+func (m *AuditEvent) GetPeerAddress() string {
+	if m != nil {
+		return m.PeerAddress
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetCertSerial() string {
+	if m != nil {
+		return m.CertSerial
+	}
+	return ""
+}
+
+type ListOfAuditEvents struct {
+	Events               []*AuditEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListOfAuditEvents) Reset()         { *m = ListOfAuditEvents{} }
+func (m *ListOfAuditEvents) String() string { return proto.CompactTextString(m) }
+func (*ListOfAuditEvents) ProtoMessage()    {}
+func (*ListOfAuditEvents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{52}
+}
+
+func (m *ListOfAuditEvents) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOfAuditEvents.Unmarshal(m, b)
+}
+func (m *ListOfAuditEvents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOfAuditEvents.Marshal(b, m, deterministic)
+}
+func (m *ListOfAuditEvents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOfAuditEvents.Merge(m, src)
+}
+func (m *ListOfAuditEvents) XXX_Size() int {
+	return xxx_messageInfo_ListOfAuditEvents.Size(m)
+}
+func (m *ListOfAuditEvents) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOfAuditEvents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOfAuditEvents proto.InternalMessageInfo
+
+func (m *ListOfAuditEvents) GetEvents() []*AuditEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type ListAuditEventsRequest struct {
+	HowMany   uint32 `protobuf:"varint,1,opt,name=how_many,json=howMany,proto3" json:"how_many,omitempty"`
+	Project   string `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	Principal string `protobuf:"bytes,3,opt,name=principal,proto3" json:"principal,omitempty"`
+	JobId     uint64 `protobuf:"varint,4,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Rpc       string `protobuf:"bytes,5,opt,name=rpc,proto3" json:"rpc,omitempty"`
+	// only events at or after this time, in seconds since the epoch
+	Since                int64    `protobuf:"varint,6,opt,name=since,proto3" json:"since,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEventsRequest) Reset()         { *m = ListAuditEventsRequest{} }
+func (m *ListAuditEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsRequest) ProtoMessage()    {}
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5ffb90dacc1dd129, []int{53}
+}
+
+func (m *ListAuditEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsRequest.Unmarshal(m, b)
+}
+func (m *ListAuditEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsRequest.Merge(m, src)
+}
+func (m *ListAuditEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsRequest.Size(m)
+}
+func (m *ListAuditEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsRequest proto.InternalMessageInfo
+
+func (m *ListAuditEventsRequest) GetHowMany() uint32 {
+	if m != nil {
+		return m.HowMany
+	}
+	return 0
+}
+
+func (m *ListAuditEventsRequest) GetProject() string {
+	if m != nil {
+		return m.Project
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetPrincipal() string {
+	if m != nil {
+		return m.Principal
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetJobId() uint64 {
+	if m != nil {
+		return m.JobId
+	}
+	return 0
+}
+
+func (m *ListAuditEventsRequest) GetRpc() string {
+	if m != nil {
+		return m.Rpc
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Job)(nil), "Job")
+	proto.RegisterType((*Progress)(nil), "Progress")
+	proto.RegisterType((*ReportProgressRequest)(nil), "ReportProgressRequest")
+	proto.RegisterType((*JobError)(nil), "JobError")
+	proto.RegisterType((*SummarizeFailuresRequest)(nil), "SummarizeFailuresRequest")
+	proto.RegisterType((*FailureGroup)(nil), "FailureGroup")
+	proto.RegisterType((*FailureSummary)(nil), "FailureSummary")
+	proto.RegisterType((*JobLogChunk)(nil), "JobLogChunk")
+	proto.RegisterType((*AppendJobLogsResponse)(nil), "AppendJobLogsResponse")
+	proto.RegisterType((*TailJobLogsRequest)(nil), "TailJobLogsRequest")
+	proto.RegisterType((*ListOfJobs)(nil), "ListOfJobs")
+	proto.RegisterType((*RequestWithId)(nil), "RequestWithId")
+	proto.RegisterType((*ListJobsRequest)(nil), "ListJobsRequest")
+	proto.RegisterType((*RescheduleJobRequest)(nil), "RescheduleJobRequest")
+	proto.RegisterType((*RoleBinding)(nil), "RoleBinding")
+	proto.RegisterType((*ListOfRoleBindings)(nil), "ListOfRoleBindings")
+	proto.RegisterType((*ListRoleBindingsRequest)(nil), "ListRoleBindingsRequest")
+	proto.RegisterType((*IssueTokenRequest)(nil), "IssueTokenRequest")
+	proto.RegisterType((*Token)(nil), "Token")
+	proto.RegisterType((*RevokedCertificate)(nil), "RevokedCertificate")
+	proto.RegisterType((*ListOfRevokedCertificates)(nil), "ListOfRevokedCertificates")
+	proto.RegisterType((*ListRevokedCertificatesRequest)(nil), "ListRevokedCertificatesRequest")
+	proto.RegisterType((*RequestWithSerial)(nil), "RequestWithSerial")
+	proto.RegisterType((*IssueCertificateRequest)(nil), "IssueCertificateRequest")
+	proto.RegisterType((*RenewCertificateRequest)(nil), "RenewCertificateRequest")
+	proto.RegisterType((*IssuedCertificate)(nil), "IssuedCertificate")
+	proto.RegisterType((*Schedule)(nil), "Schedule")
+	proto.RegisterType((*ListOfSchedules)(nil), "ListOfSchedules")
+	proto.RegisterType((*ListSchedulesRequest)(nil), "ListSchedulesRequest")
+	proto.RegisterType((*PauseScheduleRequest)(nil), "PauseScheduleRequest")
+	proto.RegisterType((*Webhook)(nil), "Webhook")
+	proto.RegisterMapType((map[string]string)(nil), "Webhook.LabelsEntry")
+	proto.RegisterType((*ListOfWebhooks)(nil), "ListOfWebhooks")
+	proto.RegisterType((*ListWebhooksRequest)(nil), "ListWebhooksRequest")
+	proto.RegisterType((*WebhookDelivery)(nil), "WebhookDelivery")
+	proto.RegisterType((*ListOfWebhookDeliveries)(nil), "ListOfWebhookDeliveries")
+	proto.RegisterType((*ListWebhookDeliveriesRequest)(nil), "ListWebhookDeliveriesRequest")
+	proto.RegisterType((*Kind)(nil), "Kind")
+	proto.RegisterType((*GetKindRequest)(nil), "GetKindRequest")
+	proto.RegisterType((*ListKindsRequest)(nil), "ListKindsRequest")
+	proto.RegisterType((*ListOfKinds)(nil), "ListOfKinds")
+	proto.RegisterType((*TemplateParameter)(nil), "TemplateParameter")
+	proto.RegisterType((*JobTemplate)(nil), "JobTemplate")
+	proto.RegisterType((*ListJobTemplatesRequest)(nil), "ListJobTemplatesRequest")
+	proto.RegisterType((*ListOfJobTemplates)(nil), "ListOfJobTemplates")
+	proto.RegisterType((*CreateJobFromTemplateRequest)(nil), "CreateJobFromTemplateRequest")
+	proto.RegisterMapType((map[string]string)(nil), "CreateJobFromTemplateRequest.ParametersEntry")
+	proto.RegisterType((*ArrayJob)(nil), "ArrayJob")
+	proto.RegisterType((*ArrayAxis)(nil), "ArrayAxis")
+	proto.RegisterType((*ParameterSet)(nil), "ParameterSet")
+	proto.RegisterMapType((map[string]string)(nil), "ParameterSet.ParametersEntry")
+	proto.RegisterType((*ArrayStatus)(nil), "ArrayStatus")
+	proto.RegisterType((*JobEvent)(nil), "JobEvent")
+	proto.RegisterType((*StreamEventsRequest)(nil), "StreamEventsRequest")
+	proto.RegisterType((*AuditEvent)(nil), "AuditEvent")
+	proto.RegisterType((*ListOfAuditEvents)(nil), "ListOfAuditEvents")
+	proto.RegisterType((*ListAuditEventsRequest)(nil), "ListAuditEventsRequest")
+	proto.RegisterEnum("Job_Status", Job_Status_name, Job_Status_value)
+	proto.RegisterEnum("Schedule_Overlap", Schedule_Overlap_name, Schedule_Overlap_value)
+	proto.RegisterEnum("WebhookDelivery_State", WebhookDelivery_State_name, WebhookDelivery_State_value)
+	proto.RegisterEnum("TemplateParameter_Type", TemplateParameter_Type_name, TemplateParameter_Type_value)
+	proto.RegisterEnum("JobEvent_Type", JobEvent_Type_name, JobEvent_Type_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// WonderlandClient is the client API for Wonderland service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WonderlandClient interface {
+	CreateJob(ctx context.Context, in *Job, opts ...grpc.CallOption) (*Job, error)
+	GetJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Job, error)
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListOfJobs, error)
+	ModifyJob(ctx context.Context, in *Job, opts ...grpc.CallOption) (*Job, error)
+	PullPendingJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListOfJobs, error)
 	DeleteJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Job, error)
 	KillJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Job, error)
+	RescheduleJob(ctx context.Context, in *RescheduleJobRequest, opts ...grpc.CallOption) (*Job, error)
+	UndeleteJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Job, error)
+	RestoreArchivedJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Job, error)
+	SummarizeFailures(ctx context.Context, in *SummarizeFailuresRequest, opts ...grpc.CallOption) (*FailureSummary, error)
+	// AppendJobLogs stores the chunks sent by a worker, which may belong
+	// to different jobs.
+	AppendJobLogs(ctx context.Context, opts ...grpc.CallOption) (Wonderland_AppendJobLogsClient, error)
+	TailJobLogs(ctx context.Context, in *TailJobLogsRequest, opts ...grpc.CallOption) (Wonderland_TailJobLogsClient, error)
+	ReportProgress(ctx context.Context, in *ReportProgressRequest, opts ...grpc.CallOption) (*Progress, error)
+	// WatchJob sends the job, then again whenever it changes, until it is
+	// finished.
+	WatchJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (Wonderland_WatchJobClient, error)
+	CreateSchedule(ctx context.Context, in *Schedule, opts ...grpc.CallOption) (*Schedule, error)
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListOfSchedules, error)
+	PauseSchedule(ctx context.Context, in *PauseScheduleRequest, opts ...grpc.CallOption) (*Schedule, error)
+	DeleteSchedule(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Schedule, error)
+	CreateWebhook(ctx context.Context, in *Webhook, opts ...grpc.CallOption) (*Webhook, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListOfWebhooks, error)
+	DeleteWebhook(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Webhook, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListOfWebhookDeliveries, error)
+	// RetryWebhookDelivery sends a dead delivery again.
+	RetryWebhookDelivery(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*WebhookDelivery, error)
+	RegisterKind(ctx context.Context, in *Kind, opts ...grpc.CallOption) (*Kind, error)
+	GetKind(ctx context.Context, in *GetKindRequest, opts ...grpc.CallOption) (*Kind, error)
+	ListKinds(ctx context.Context, in *ListKindsRequest, opts ...grpc.CallOption) (*ListOfKinds, error)
+	CreateJobTemplate(ctx context.Context, in *JobTemplate, opts ...grpc.CallOption) (*JobTemplate, error)
+	ListJobTemplates(ctx context.Context, in *ListJobTemplatesRequest, opts ...grpc.CallOption) (*ListOfJobTemplates, error)
+	CreateJobFromTemplate(ctx context.Context, in *CreateJobFromTemplateRequest, opts ...grpc.CallOption) (*Job, error)
+	CreateArrayJob(ctx context.Context, in *ArrayJob, opts ...grpc.CallOption) (*ArrayJob, error)
+	GetArrayJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*ArrayJob, error)
+	// KillArrayJob kills the jobs of an array job which are not finished.
+	KillArrayJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*ArrayJob, error)
+	// StreamEvents sends the change feed of jobs in sequence order. Starting
+	// before the oldest retained event fails with OUT_OF_RANGE.
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (Wonderland_StreamEventsClient, error)
+	CreateRoleBinding(ctx context.Context, in *RoleBinding, opts ...grpc.CallOption) (*RoleBinding, error)
+	ListRoleBindings(ctx context.Context, in *ListRoleBindingsRequest, opts ...grpc.CallOption) (*ListOfRoleBindings, error)
+	DeleteRoleBinding(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*RoleBinding, error)
+	IssueToken(ctx context.Context, in *IssueTokenRequest, opts ...grpc.CallOption) (*Token, error)
+	RevokeCertificate(ctx context.Context, in *RevokedCertificate, opts ...grpc.CallOption) (*RevokedCertificate, error)
+	ListRevokedCertificates(ctx context.Context, in *ListRevokedCertificatesRequest, opts ...grpc.CallOption) (*ListOfRevokedCertificates, error)
+	UnrevokeCertificate(ctx context.Context, in *RequestWithSerial, opts ...grpc.CallOption) (*RevokedCertificate, error)
+	IssueCertificate(ctx context.Context, in *IssueCertificateRequest, opts ...grpc.CallOption) (*IssuedCertificate, error)
+	RenewCertificate(ctx context.Context, in *RenewCertificateRequest, opts ...grpc.CallOption) (*IssuedCertificate, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListOfAuditEvents, error)
+}
+
+type wonderlandClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWonderlandClient(cc *grpc.ClientConn) WonderlandClient {
+	return &wonderlandClient{cc}
+}
+
+func (c *wonderlandClient) CreateJob(ctx context.Context, in *Job, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/Wonderland/CreateJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) GetJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/Wonderland/GetJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListOfJobs, error) {
+	out := new(ListOfJobs)
+	err := c.cc.Invoke(ctx, "/Wonderland/ListJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) ModifyJob(ctx context.Context, in *Job, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/Wonderland/ModifyJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) PullPendingJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListOfJobs, error) {
+	out := new(ListOfJobs)
+	err := c.cc.Invoke(ctx, "/Wonderland/PullPendingJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) DeleteJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/Wonderland/DeleteJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) KillJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/Wonderland/KillJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) RescheduleJob(ctx context.Context, in *RescheduleJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/Wonderland/RescheduleJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) UndeleteJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/Wonderland/UndeleteJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) RestoreArchivedJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/Wonderland/RestoreArchivedJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) SummarizeFailures(ctx context.Context, in *SummarizeFailuresRequest, opts ...grpc.CallOption) (*FailureSummary, error) {
+	out := new(FailureSummary)
+	err := c.cc.Invoke(ctx, "/Wonderland/SummarizeFailures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) AppendJobLogs(ctx context.Context, opts ...grpc.CallOption) (Wonderland_AppendJobLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Wonderland_serviceDesc.Streams[0], "/Wonderland/AppendJobLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &wonderlandAppendJobLogsClient{stream}
+	return x, nil
+}
+
+type Wonderland_AppendJobLogsClient interface {
+	Send(*JobLogChunk) error
+	CloseAndRecv() (*AppendJobLogsResponse, error)
+	grpc.ClientStream
+}
+
+type wonderlandAppendJobLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *wonderlandAppendJobLogsClient) Send(m *JobLogChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *wonderlandAppendJobLogsClient) CloseAndRecv() (*AppendJobLogsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(AppendJobLogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *wonderlandClient) TailJobLogs(ctx context.Context, in *TailJobLogsRequest, opts ...grpc.CallOption) (Wonderland_TailJobLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Wonderland_serviceDesc.Streams[1], "/Wonderland/TailJobLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &wonderlandTailJobLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Wonderland_TailJobLogsClient interface {
+	Recv() (*JobLogChunk, error)
+	grpc.ClientStream
+}
+
+type wonderlandTailJobLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *wonderlandTailJobLogsClient) Recv() (*JobLogChunk, error) {
+	m := new(JobLogChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *wonderlandClient) ReportProgress(ctx context.Context, in *ReportProgressRequest, opts ...grpc.CallOption) (*Progress, error) {
+	out := new(Progress)
+	err := c.cc.Invoke(ctx, "/Wonderland/ReportProgress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) WatchJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (Wonderland_WatchJobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Wonderland_serviceDesc.Streams[2], "/Wonderland/WatchJob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &wonderlandWatchJobClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Wonderland_WatchJobClient interface {
+	Recv() (*Job, error)
+	grpc.ClientStream
+}
+
+type wonderlandWatchJobClient struct {
+	grpc.ClientStream
+}
+
+func (x *wonderlandWatchJobClient) Recv() (*Job, error) {
+	m := new(Job)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *wonderlandClient) CreateSchedule(ctx context.Context, in *Schedule, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, "/Wonderland/CreateSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListOfSchedules, error) {
+	out := new(ListOfSchedules)
+	err := c.cc.Invoke(ctx, "/Wonderland/ListSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) PauseSchedule(ctx context.Context, in *PauseScheduleRequest, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, "/Wonderland/PauseSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) DeleteSchedule(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, "/Wonderland/DeleteSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) CreateWebhook(ctx context.Context, in *Webhook, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, "/Wonderland/CreateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListOfWebhooks, error) {
+	out := new(ListOfWebhooks)
+	err := c.cc.Invoke(ctx, "/Wonderland/ListWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) DeleteWebhook(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, "/Wonderland/DeleteWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListOfWebhookDeliveries, error) {
+	out := new(ListOfWebhookDeliveries)
+	err := c.cc.Invoke(ctx, "/Wonderland/ListWebhookDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) RetryWebhookDelivery(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, "/Wonderland/RetryWebhookDelivery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) RegisterKind(ctx context.Context, in *Kind, opts ...grpc.CallOption) (*Kind, error) {
+	out := new(Kind)
+	err := c.cc.Invoke(ctx, "/Wonderland/RegisterKind", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) GetKind(ctx context.Context, in *GetKindRequest, opts ...grpc.CallOption) (*Kind, error) {
+	out := new(Kind)
+	err := c.cc.Invoke(ctx, "/Wonderland/GetKind", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) ListKinds(ctx context.Context, in *ListKindsRequest, opts ...grpc.CallOption) (*ListOfKinds, error) {
+	out := new(ListOfKinds)
+	err := c.cc.Invoke(ctx, "/Wonderland/ListKinds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) CreateJobTemplate(ctx context.Context, in *JobTemplate, opts ...grpc.CallOption) (*JobTemplate, error) {
+	out := new(JobTemplate)
+	err := c.cc.Invoke(ctx, "/Wonderland/CreateJobTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) ListJobTemplates(ctx context.Context, in *ListJobTemplatesRequest, opts ...grpc.CallOption) (*ListOfJobTemplates, error) {
+	out := new(ListOfJobTemplates)
+	err := c.cc.Invoke(ctx, "/Wonderland/ListJobTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) CreateJobFromTemplate(ctx context.Context, in *CreateJobFromTemplateRequest, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, "/Wonderland/CreateJobFromTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) CreateArrayJob(ctx context.Context, in *ArrayJob, opts ...grpc.CallOption) (*ArrayJob, error) {
+	out := new(ArrayJob)
+	err := c.cc.Invoke(ctx, "/Wonderland/CreateArrayJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) GetArrayJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*ArrayJob, error) {
+	out := new(ArrayJob)
+	err := c.cc.Invoke(ctx, "/Wonderland/GetArrayJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) KillArrayJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*ArrayJob, error) {
+	out := new(ArrayJob)
+	err := c.cc.Invoke(ctx, "/Wonderland/KillArrayJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (Wonderland_StreamEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Wonderland_serviceDesc.Streams[3], "/Wonderland/StreamEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &wonderlandStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Wonderland_StreamEventsClient interface {
+	Recv() (*JobEvent, error)
+	grpc.ClientStream
+}
+
+type wonderlandStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *wonderlandStreamEventsClient) Recv() (*JobEvent, error) {
+	m := new(JobEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *wonderlandClient) CreateRoleBinding(ctx context.Context, in *RoleBinding, opts ...grpc.CallOption) (*RoleBinding, error) {
+	out := new(RoleBinding)
+	err := c.cc.Invoke(ctx, "/Wonderland/CreateRoleBinding", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) ListRoleBindings(ctx context.Context, in *ListRoleBindingsRequest, opts ...grpc.CallOption) (*ListOfRoleBindings, error) {
+	out := new(ListOfRoleBindings)
+	err := c.cc.Invoke(ctx, "/Wonderland/ListRoleBindings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) DeleteRoleBinding(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*RoleBinding, error) {
+	out := new(RoleBinding)
+	err := c.cc.Invoke(ctx, "/Wonderland/DeleteRoleBinding", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) IssueToken(ctx context.Context, in *IssueTokenRequest, opts ...grpc.CallOption) (*Token, error) {
+	out := new(Token)
+	err := c.cc.Invoke(ctx, "/Wonderland/IssueToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) RevokeCertificate(ctx context.Context, in *RevokedCertificate, opts ...grpc.CallOption) (*RevokedCertificate, error) {
+	out := new(RevokedCertificate)
+	err := c.cc.Invoke(ctx, "/Wonderland/RevokeCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) ListRevokedCertificates(ctx context.Context, in *ListRevokedCertificatesRequest, opts ...grpc.CallOption) (*ListOfRevokedCertificates, error) {
+	out := new(ListOfRevokedCertificates)
+	err := c.cc.Invoke(ctx, "/Wonderland/ListRevokedCertificates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) UnrevokeCertificate(ctx context.Context, in *RequestWithSerial, opts ...grpc.CallOption) (*RevokedCertificate, error) {
+	out := new(RevokedCertificate)
+	err := c.cc.Invoke(ctx, "/Wonderland/UnrevokeCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) IssueCertificate(ctx context.Context, in *IssueCertificateRequest, opts ...grpc.CallOption) (*IssuedCertificate, error) {
+	out := new(IssuedCertificate)
+	err := c.cc.Invoke(ctx, "/Wonderland/IssueCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) RenewCertificate(ctx context.Context, in *RenewCertificateRequest, opts ...grpc.CallOption) (*IssuedCertificate, error) {
+	out := new(IssuedCertificate)
+	err := c.cc.Invoke(ctx, "/Wonderland/RenewCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wonderlandClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListOfAuditEvents, error) {
+	out := new(ListOfAuditEvents)
+	err := c.cc.Invoke(ctx, "/Wonderland/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WonderlandServer is the server API for Wonderland service.
+type WonderlandServer interface {
+	CreateJob(context.Context, *Job) (*Job, error)
+	GetJob(context.Context, *RequestWithId) (*Job, error)
+	ListJobs(context.Context, *ListJobsRequest) (*ListOfJobs, error)
+	ModifyJob(context.Context, *Job) (*Job, error)
+	PullPendingJobs(context.Context, *ListJobsRequest) (*ListOfJobs, error)
+	DeleteJob(context.Context, *RequestWithId) (*Job, error)
+	KillJob(context.Context, *RequestWithId) (*Job, error)
+	RescheduleJob(context.Context, *RescheduleJobRequest) (*Job, error)
+	UndeleteJob(context.Context, *RequestWithId) (*Job, error)
+	RestoreArchivedJob(context.Context, *RequestWithId) (*Job, error)
+	SummarizeFailures(context.Context, *SummarizeFailuresRequest) (*FailureSummary, error)
+	// AppendJobLogs stores the chunks sent by a worker, which may belong
+	// to different jobs.
+	AppendJobLogs(Wonderland_AppendJobLogsServer) error
+	TailJobLogs(*TailJobLogsRequest, Wonderland_TailJobLogsServer) error
+	ReportProgress(context.Context, *ReportProgressRequest) (*Progress, error)
+	// WatchJob sends the job, then again whenever it changes, until it is
+	// finished.
+	WatchJob(*RequestWithId, Wonderland_WatchJobServer) error
+	CreateSchedule(context.Context, *Schedule) (*Schedule, error)
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListOfSchedules, error)
+	PauseSchedule(context.Context, *PauseScheduleRequest) (*Schedule, error)
+	DeleteSchedule(context.Context, *RequestWithId) (*Schedule, error)
+	CreateWebhook(context.Context, *Webhook) (*Webhook, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListOfWebhooks, error)
+	DeleteWebhook(context.Context, *RequestWithId) (*Webhook, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListOfWebhookDeliveries, error)
+	// RetryWebhookDelivery sends a dead delivery again.
+	RetryWebhookDelivery(context.Context, *RequestWithId) (*WebhookDelivery, error)
+	RegisterKind(context.Context, *Kind) (*Kind, error)
+	GetKind(context.Context, *GetKindRequest) (*Kind, error)
+	ListKinds(context.Context, *ListKindsRequest) (*ListOfKinds, error)
+	CreateJobTemplate(context.Context, *JobTemplate) (*JobTemplate, error)
+	ListJobTemplates(context.Context, *ListJobTemplatesRequest) (*ListOfJobTemplates, error)
+	CreateJobFromTemplate(context.Context, *CreateJobFromTemplateRequest) (*Job, error)
+	CreateArrayJob(context.Context, *ArrayJob) (*ArrayJob, error)
+	GetArrayJob(context.Context, *RequestWithId) (*ArrayJob, error)
+	// KillArrayJob kills the jobs of an array job which are not finished.
+	KillArrayJob(context.Context, *RequestWithId) (*ArrayJob, error)
+	// StreamEvents sends the change feed of jobs in sequence order. Starting
+	// before the oldest retained event fails with OUT_OF_RANGE.
+	StreamEvents(*StreamEventsRequest, Wonderland_StreamEventsServer) error
+	CreateRoleBinding(context.Context, *RoleBinding) (*RoleBinding, error)
+	ListRoleBindings(context.Context, *ListRoleBindingsRequest) (*ListOfRoleBindings, error)
+	DeleteRoleBinding(context.Context, *RequestWithId) (*RoleBinding, error)
+	IssueToken(context.Context, *IssueTokenRequest) (*Token, error)
+	RevokeCertificate(context.Context, *RevokedCertificate) (*RevokedCertificate, error)
+	ListRevokedCertificates(context.Context, *ListRevokedCertificatesRequest) (*ListOfRevokedCertificates, error)
+	UnrevokeCertificate(context.Context, *RequestWithSerial) (*RevokedCertificate, error)
+	IssueCertificate(context.Context, *IssueCertificateRequest) (*IssuedCertificate, error)
+	RenewCertificate(context.Context, *RenewCertificateRequest) (*IssuedCertificate, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListOfAuditEvents, error)
+}
+
+func RegisterWonderlandServer(s *grpc.Server, srv WonderlandServer) {
+	s.RegisterService(&_Wonderland_serviceDesc, srv)
+}
+
+func _Wonderland_CreateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Job)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).CreateJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/CreateJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).CreateJob(ctx, req.(*Job))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestWithId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/GetJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).GetJob(ctx, req.(*RequestWithId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/ListJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_ModifyJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Job)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).ModifyJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/ModifyJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).ModifyJob(ctx, req.(*Job))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_PullPendingJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).PullPendingJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/PullPendingJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).PullPendingJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_DeleteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestWithId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).DeleteJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/DeleteJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).DeleteJob(ctx, req.(*RequestWithId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_KillJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestWithId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).KillJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/KillJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).KillJob(ctx, req.(*RequestWithId))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type wonderlandClient struct {
-	cc *grpc.ClientConn
+func _Wonderland_RescheduleJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RescheduleJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).RescheduleJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/RescheduleJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).RescheduleJob(ctx, req.(*RescheduleJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_UndeleteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestWithId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).UndeleteJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/UndeleteJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).UndeleteJob(ctx, req.(*RequestWithId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_RestoreArchivedJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestWithId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).RestoreArchivedJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/RestoreArchivedJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).RestoreArchivedJob(ctx, req.(*RequestWithId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_SummarizeFailures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SummarizeFailuresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).SummarizeFailures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/SummarizeFailures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).SummarizeFailures(ctx, req.(*SummarizeFailuresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_AppendJobLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WonderlandServer).AppendJobLogs(&wonderlandAppendJobLogsServer{stream})
+}
+
+type Wonderland_AppendJobLogsServer interface {
+	SendAndClose(*AppendJobLogsResponse) error
+	Recv() (*JobLogChunk, error)
+	grpc.ServerStream
+}
+
+type wonderlandAppendJobLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *wonderlandAppendJobLogsServer) SendAndClose(m *AppendJobLogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *wonderlandAppendJobLogsServer) Recv() (*JobLogChunk, error) {
+	m := new(JobLogChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Wonderland_TailJobLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailJobLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WonderlandServer).TailJobLogs(m, &wonderlandTailJobLogsServer{stream})
+}
+
+type Wonderland_TailJobLogsServer interface {
+	Send(*JobLogChunk) error
+	grpc.ServerStream
+}
+
+type wonderlandTailJobLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *wonderlandTailJobLogsServer) Send(m *JobLogChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Wonderland_ReportProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).ReportProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/ReportProgress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).ReportProgress(ctx, req.(*ReportProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_WatchJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RequestWithId)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WonderlandServer).WatchJob(m, &wonderlandWatchJobServer{stream})
+}
+
+type Wonderland_WatchJobServer interface {
+	Send(*Job) error
+	grpc.ServerStream
+}
+
+type wonderlandWatchJobServer struct {
+	grpc.ServerStream
+}
+
+func (x *wonderlandWatchJobServer) Send(m *Job) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Wonderland_CreateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Schedule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).CreateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/CreateSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).CreateSchedule(ctx, req.(*Schedule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/ListSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_PauseSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).PauseSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/PauseSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).PauseSchedule(ctx, req.(*PauseScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_DeleteSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestWithId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).DeleteSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/DeleteSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).DeleteSchedule(ctx, req.(*RequestWithId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Webhook)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/CreateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).CreateWebhook(ctx, req.(*Webhook))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/ListWebhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestWithId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/DeleteWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).DeleteWebhook(ctx, req.(*RequestWithId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/ListWebhookDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_RetryWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestWithId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).RetryWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/RetryWebhookDelivery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).RetryWebhookDelivery(ctx, req.(*RequestWithId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_RegisterKind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Kind)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).RegisterKind(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/RegisterKind",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).RegisterKind(ctx, req.(*Kind))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_GetKind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).GetKind(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/GetKind",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).GetKind(ctx, req.(*GetKindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Wonderland_ListKinds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListKindsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).ListKinds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/ListKinds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).ListKinds(ctx, req.(*ListKindsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewWonderlandClient(cc *grpc.ClientConn) WonderlandClient {
-	return &wonderlandClient{cc}
+func _Wonderland_CreateJobTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobTemplate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).CreateJobTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/CreateJobTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).CreateJobTemplate(ctx, req.(*JobTemplate))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *wonderlandClient) CreateJob(ctx context.Context, in *Job, opts ...grpc.CallOption) (*Job, error) {
-	out := new(Job)
-	err := c.cc.Invoke(ctx, "/Wonderland/CreateJob", in, out, opts...)
-	if err != nil {
+func _Wonderland_ListJobTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobTemplatesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(WonderlandServer).ListJobTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/ListJobTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).ListJobTemplates(ctx, req.(*ListJobTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *wonderlandClient) GetJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Job, error) {
-	out := new(Job)
-	err := c.cc.Invoke(ctx, "/Wonderland/GetJob", in, out, opts...)
-	if err != nil {
+func _Wonderland_CreateJobFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateJobFromTemplateRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(WonderlandServer).CreateJobFromTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/CreateJobFromTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).CreateJobFromTemplate(ctx, req.(*CreateJobFromTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *wonderlandClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListOfJobs, error) {
-	out := new(ListOfJobs)
-	err := c.cc.Invoke(ctx, "/Wonderland/ListJobs", in, out, opts...)
-	if err != nil {
+func _Wonderland_CreateArrayJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArrayJob)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(WonderlandServer).CreateArrayJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/CreateArrayJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).CreateArrayJob(ctx, req.(*ArrayJob))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *wonderlandClient) ModifyJob(ctx context.Context, in *Job, opts ...grpc.CallOption) (*Job, error) {
-	out := new(Job)
-	err := c.cc.Invoke(ctx, "/Wonderland/ModifyJob", in, out, opts...)
-	if err != nil {
+func _Wonderland_GetArrayJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestWithId)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(WonderlandServer).GetArrayJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/GetArrayJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).GetArrayJob(ctx, req.(*RequestWithId))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *wonderlandClient) PullPendingJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListOfJobs, error) {
-	out := new(ListOfJobs)
-	err := c.cc.Invoke(ctx, "/Wonderland/PullPendingJobs", in, out, opts...)
-	if err != nil {
+func _Wonderland_KillArrayJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestWithId)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(WonderlandServer).KillArrayJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/KillArrayJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).KillArrayJob(ctx, req.(*RequestWithId))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *wonderlandClient) DeleteJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Job, error) {
-	out := new(Job)
-	err := c.cc.Invoke(ctx, "/Wonderland/DeleteJob", in, out, opts...)
-	if err != nil {
-		return nil, err
+func _Wonderland_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return out, nil
+	return srv.(WonderlandServer).StreamEvents(m, &wonderlandStreamEventsServer{stream})
 }
 
-func (c *wonderlandClient) KillJob(ctx context.Context, in *RequestWithId, opts ...grpc.CallOption) (*Job, error) {
-	out := new(Job)
-	err := c.cc.Invoke(ctx, "/Wonderland/KillJob", in, out, opts...)
-	if err != nil {
+type Wonderland_StreamEventsServer interface {
+	Send(*JobEvent) error
+	grpc.ServerStream
+}
+
+type wonderlandStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *wonderlandStreamEventsServer) Send(m *JobEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Wonderland_CreateRoleBinding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleBinding)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(WonderlandServer).CreateRoleBinding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/CreateRoleBinding",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).CreateRoleBinding(ctx, req.(*RoleBinding))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// WonderlandServer is the server API for Wonderland service.
-type WonderlandServer interface {
-	CreateJob(context.Context, *Job) (*Job, error)
-	GetJob(context.Context, *RequestWithId) (*Job, error)
-	ListJobs(context.Context, *ListJobsRequest) (*ListOfJobs, error)
-	ModifyJob(context.Context, *Job) (*Job, error)
-	PullPendingJobs(context.Context, *ListJobsRequest) (*ListOfJobs, error)
-	DeleteJob(context.Context, *RequestWithId) (*Job, error)
-	KillJob(context.Context, *RequestWithId) (*Job, error)
+func _Wonderland_ListRoleBindings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleBindingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).ListRoleBindings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/ListRoleBindings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).ListRoleBindings(ctx, req.(*ListRoleBindingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterWonderlandServer(s *grpc.Server, srv WonderlandServer) {
-	s.RegisterService(&_Wonderland_serviceDesc, srv)
+func _Wonderland_DeleteRoleBinding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestWithId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WonderlandServer).DeleteRoleBinding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Wonderland/DeleteRoleBinding",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WonderlandServer).DeleteRoleBinding(ctx, req.(*RequestWithId))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Wonderland_CreateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Job)
+func _Wonderland_IssueToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WonderlandServer).CreateJob(ctx, in)
+		return srv.(WonderlandServer).IssueToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Wonderland/CreateJob",
+		FullMethod: "/Wonderland/IssueToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WonderlandServer).CreateJob(ctx, req.(*Job))
+		return srv.(WonderlandServer).IssueToken(ctx, req.(*IssueTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Wonderland_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestWithId)
+func _Wonderland_RevokeCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokedCertificate)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WonderlandServer).GetJob(ctx, in)
+		return srv.(WonderlandServer).RevokeCertificate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Wonderland/GetJob",
+		FullMethod: "/Wonderland/RevokeCertificate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WonderlandServer).GetJob(ctx, req.(*RequestWithId))
+		return srv.(WonderlandServer).RevokeCertificate(ctx, req.(*RevokedCertificate))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Wonderland_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListJobsRequest)
+func _Wonderland_ListRevokedCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRevokedCertificatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WonderlandServer).ListJobs(ctx, in)
+		return srv.(WonderlandServer).ListRevokedCertificates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Wonderland/ListJobs",
+		FullMethod: "/Wonderland/ListRevokedCertificates",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WonderlandServer).ListJobs(ctx, req.(*ListJobsRequest))
+		return srv.(WonderlandServer).ListRevokedCertificates(ctx, req.(*ListRevokedCertificatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Wonderland_ModifyJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Job)
+func _Wonderland_UnrevokeCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestWithSerial)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WonderlandServer).ModifyJob(ctx, in)
+		return srv.(WonderlandServer).UnrevokeCertificate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Wonderland/ModifyJob",
+		FullMethod: "/Wonderland/UnrevokeCertificate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WonderlandServer).ModifyJob(ctx, req.(*Job))
+		return srv.(WonderlandServer).UnrevokeCertificate(ctx, req.(*RequestWithSerial))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Wonderland_PullPendingJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListJobsRequest)
+func _Wonderland_IssueCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueCertificateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WonderlandServer).PullPendingJobs(ctx, in)
+		return srv.(WonderlandServer).IssueCertificate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Wonderland/PullPendingJobs",
+		FullMethod: "/Wonderland/IssueCertificate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WonderlandServer).PullPendingJobs(ctx, req.(*ListJobsRequest))
+		return srv.(WonderlandServer).IssueCertificate(ctx, req.(*IssueCertificateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Wonderland_DeleteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestWithId)
+func _Wonderland_RenewCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewCertificateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WonderlandServer).DeleteJob(ctx, in)
+		return srv.(WonderlandServer).RenewCertificate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Wonderland/DeleteJob",
+		FullMethod: "/Wonderland/RenewCertificate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WonderlandServer).DeleteJob(ctx, req.(*RequestWithId))
+		return srv.(WonderlandServer).RenewCertificate(ctx, req.(*RenewCertificateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Wonderland_KillJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestWithId)
+func _Wonderland_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(WonderlandServer).KillJob(ctx, in)
+		return srv.(WonderlandServer).ListAuditEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Wonderland/KillJob",
+		FullMethod: "/Wonderland/ListAuditEvents",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WonderlandServer).KillJob(ctx, req.(*RequestWithId))
+		return srv.(WonderlandServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -555,40 +5326,419 @@ var _Wonderland_serviceDesc = grpc.ServiceDesc{
 			MethodName: "KillJob",
 			Handler:    _Wonderland_KillJob_Handler,
 		},
+		{
+			MethodName: "RescheduleJob",
+			Handler:    _Wonderland_RescheduleJob_Handler,
+		},
+		{
+			MethodName: "UndeleteJob",
+			Handler:    _Wonderland_UndeleteJob_Handler,
+		},
+		{
+			MethodName: "RestoreArchivedJob",
+			Handler:    _Wonderland_RestoreArchivedJob_Handler,
+		},
+		{
+			MethodName: "SummarizeFailures",
+			Handler:    _Wonderland_SummarizeFailures_Handler,
+		},
+		{
+			MethodName: "ReportProgress",
+			Handler:    _Wonderland_ReportProgress_Handler,
+		},
+		{
+			MethodName: "CreateSchedule",
+			Handler:    _Wonderland_CreateSchedule_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _Wonderland_ListSchedules_Handler,
+		},
+		{
+			MethodName: "PauseSchedule",
+			Handler:    _Wonderland_PauseSchedule_Handler,
+		},
+		{
+			MethodName: "DeleteSchedule",
+			Handler:    _Wonderland_DeleteSchedule_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _Wonderland_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _Wonderland_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _Wonderland_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _Wonderland_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "RetryWebhookDelivery",
+			Handler:    _Wonderland_RetryWebhookDelivery_Handler,
+		},
+		{
+			MethodName: "RegisterKind",
+			Handler:    _Wonderland_RegisterKind_Handler,
+		},
+		{
+			MethodName: "GetKind",
+			Handler:    _Wonderland_GetKind_Handler,
+		},
+		{
+			MethodName: "ListKinds",
+			Handler:    _Wonderland_ListKinds_Handler,
+		},
+		{
+			MethodName: "CreateJobTemplate",
+			Handler:    _Wonderland_CreateJobTemplate_Handler,
+		},
+		{
+			MethodName: "ListJobTemplates",
+			Handler:    _Wonderland_ListJobTemplates_Handler,
+		},
+		{
+			MethodName: "CreateJobFromTemplate",
+			Handler:    _Wonderland_CreateJobFromTemplate_Handler,
+		},
+		{
+			MethodName: "CreateArrayJob",
+			Handler:    _Wonderland_CreateArrayJob_Handler,
+		},
+		{
+			MethodName: "GetArrayJob",
+			Handler:    _Wonderland_GetArrayJob_Handler,
+		},
+		{
+			MethodName: "KillArrayJob",
+			Handler:    _Wonderland_KillArrayJob_Handler,
+		},
+		{
+			MethodName: "CreateRoleBinding",
+			Handler:    _Wonderland_CreateRoleBinding_Handler,
+		},
+		{
+			MethodName: "ListRoleBindings",
+			Handler:    _Wonderland_ListRoleBindings_Handler,
+		},
+		{
+			MethodName: "DeleteRoleBinding",
+			Handler:    _Wonderland_DeleteRoleBinding_Handler,
+		},
+		{
+			MethodName: "IssueToken",
+			Handler:    _Wonderland_IssueToken_Handler,
+		},
+		{
+			MethodName: "RevokeCertificate",
+			Handler:    _Wonderland_RevokeCertificate_Handler,
+		},
+		{
+			MethodName: "ListRevokedCertificates",
+			Handler:    _Wonderland_ListRevokedCertificates_Handler,
+		},
+		{
+			MethodName: "UnrevokeCertificate",
+			Handler:    _Wonderland_UnrevokeCertificate_Handler,
+		},
+		{
+			MethodName: "IssueCertificate",
+			Handler:    _Wonderland_IssueCertificate_Handler,
+		},
+		{
+			MethodName: "RenewCertificate",
+			Handler:    _Wonderland_RenewCertificate_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _Wonderland_ListAuditEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AppendJobLogs",
+			Handler:       _Wonderland_AppendJobLogs_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "TailJobLogs",
+			Handler:       _Wonderland_TailJobLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchJob",
+			Handler:       _Wonderland_WatchJob_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _Wonderland_StreamEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "wonderland.proto",
 }
 
 func init() { proto.RegisterFile("wonderland.proto", fileDescriptor_5ffb90dacc1dd129) }
 
 var fileDescriptor_5ffb90dacc1dd129 = []byte{
-	// 430 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0x5d, 0x8f, 0xd2, 0x40,
-	0x14, 0xa5, 0xa5, 0xb4, 0x70, 0x09, 0x6c, 0x73, 0x63, 0xcc, 0x2c, 0x2f, 0xd6, 0x6e, 0x62, 0x48,
-	0x4c, 0xfa, 0x80, 0xbf, 0xc0, 0x2c, 0xb8, 0x81, 0x05, 0xb6, 0xa9, 0x92, 0x4d, 0x7c, 0x31, 0x53,
-	0x67, 0x56, 0x66, 0xed, 0xce, 0x20, 0x9d, 0x86, 0xf0, 0x13, 0x7c, 0xf0, 0x3f, 0x9b, 0x19, 0x0a,
-	0xba, 0x7e, 0xc5, 0x97, 0xa6, 0xf7, 0x9c, 0x33, 0x67, 0x4e, 0xcf, 0x2d, 0x84, 0x3b, 0x25, 0x19,
-	0xdf, 0x16, 0x54, 0xb2, 0x64, 0xb3, 0x55, 0x5a, 0xc5, 0x5f, 0x5d, 0x68, 0xce, 0x54, 0x8e, 0x04,
-	0x82, 0xcd, 0x56, 0xdd, 0xf3, 0x8f, 0x9a, 0x38, 0x91, 0x33, 0xec, 0x64, 0xc7, 0x11, 0xfb, 0xe0,
-	0x0a, 0x46, 0xdc, 0xc8, 0x19, 0x7a, 0x99, 0x2b, 0x18, 0x22, 0x78, 0x9f, 0x85, 0x64, 0xa4, 0x69,
-	0x65, 0xf6, 0x1d, 0x2f, 0xc0, 0x2f, 0x35, 0xd5, 0x55, 0x49, 0xbc, 0xc8, 0x19, 0xf6, 0x47, 0xdd,
-	0x64, 0xa6, 0xf2, 0xe4, 0xad, 0x85, 0xb2, 0x9a, 0xc2, 0x27, 0xd0, 0x12, 0x72, 0x53, 0x69, 0xd2,
-	0xb2, 0x27, 0x0f, 0x03, 0x3e, 0x05, 0x5f, 0x55, 0xda, 0xc0, 0xbe, 0x85, 0xeb, 0x09, 0x07, 0xd0,
-	0x7e, 0xe0, 0x9a, 0x32, 0xaa, 0x29, 0x09, 0x2c, 0x73, 0x9a, 0xe3, 0x15, 0xf8, 0x07, 0x6f, 0xec,
-	0x42, 0x90, 0x4e, 0x96, 0xe3, 0xe9, 0xf2, 0x2a, 0x6c, 0x20, 0x80, 0x9f, 0xae, 0xe6, 0xf3, 0xc9,
-	0x38, 0x74, 0x0c, 0x91, 0xad, 0x96, 0x4b, 0x43, 0xb8, 0x86, 0x78, 0xf3, 0x7a, 0x6a, 0x88, 0x26,
-	0xf6, 0xa0, 0x73, 0x79, 0xb3, 0x48, 0xe7, 0x93, 0x77, 0x93, 0x71, 0xe8, 0x19, 0xea, 0x7a, 0x6a,
-	0xcf, 0xb4, 0xe2, 0x17, 0x00, 0x73, 0x51, 0xea, 0x9b, 0xbb, 0x99, 0xca, 0x4b, 0x24, 0xe0, 0xdd,
-	0xab, 0xbc, 0x24, 0x4e, 0xd4, 0x1c, 0x76, 0x47, 0x9e, 0xf9, 0xa2, 0xcc, 0x22, 0xf1, 0x33, 0xe8,
-	0x65, 0xfc, 0x4b, 0xc5, 0x4b, 0x7d, 0x2b, 0xf4, 0x7a, 0xca, 0xea, 0x8a, 0x9c, 0x63, 0x45, 0xf1,
-	0x7b, 0x38, 0x33, 0x46, 0xc6, 0xa6, 0x16, 0xe2, 0x39, 0xb4, 0xd7, 0x6a, 0xf7, 0xe1, 0x81, 0xca,
-	0xbd, 0x15, 0xf6, 0xb2, 0x60, 0xad, 0x76, 0x0b, 0x2a, 0xf7, 0x3f, 0x57, 0xef, 0x3e, 0xae, 0xfe,
-	0x0f, 0x55, 0x8f, 0xbe, 0xb9, 0x00, 0xb7, 0xa7, 0x2d, 0xe2, 0x39, 0x74, 0x2e, 0xb7, 0x9c, 0x6a,
-	0x6e, 0x96, 0x68, 0x43, 0x0e, 0xec, 0x33, 0x6e, 0x60, 0x04, 0xfe, 0x15, 0x37, 0x21, 0xb0, 0x9f,
-	0x3c, 0xca, 0x7b, 0x52, 0xbc, 0x84, 0xf6, 0x31, 0x27, 0x86, 0xc9, 0x2f, 0x91, 0x07, 0xdd, 0xe4,
-	0x47, 0x1b, 0x71, 0xc3, 0xdc, 0xb4, 0x50, 0x4c, 0xdc, 0xed, 0x7f, 0xbf, 0x69, 0x04, 0x67, 0x69,
-	0x55, 0x14, 0x29, 0x97, 0x4c, 0xc8, 0x4f, 0xff, 0x67, 0x77, 0x01, 0x9d, 0x31, 0x2f, 0xf8, 0x21,
-	0xf8, 0xdf, 0x02, 0x3e, 0x87, 0xe0, 0x5a, 0x14, 0xc5, 0x3f, 0x24, 0xb9, 0x6f, 0xff, 0xe3, 0x57,
-	0xdf, 0x03, 0x00, 0x00, 0xff, 0xff, 0x59, 0x9c, 0xff, 0x70, 0xdb, 0x02, 0x00, 0x00,
+	// 4035 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x4b, 0x73, 0x1c, 0x59,
+	0x56, 0xee, 0x7a, 0xaa, 0xea, 0xd4, 0x43, 0xa5, 0xab, 0x87, 0xd3, 0xe5, 0x97, 0xfa, 0xba, 0x7b,
+	0xda, 0x2d, 0x7b, 0x52, 0x1e, 0x33, 0xd0, 0x3d, 0x66, 0x7a, 0x7a, 0xca, 0x52, 0xb5, 0x90, 0x2d,
+	0xcb, 0xea, 0x94, 0xe4, 0x6e, 0x98, 0x80, 0x22, 0xab, 0xf2, 0x4a, 0x4a, 0x2b, 0x2b, 0xb3, 0x3a,
+	0x33, 0x4b, 0x76, 0x75, 0x63, 0x82, 0x60, 0xc5, 0x82, 0x15, 0xfc, 0x00, 0x22, 0x20, 0x82, 0x05,
+	0x3b, 0x22, 0x58, 0x11, 0xb3, 0xe0, 0x0f, 0xb0, 0x81, 0xe0, 0x1f, 0xb0, 0xe0, 0x0f, 0xc0, 0x9a,
+	0x38, 0xf7, 0x91, 0xaf, 0xaa, 0x92, 0x65, 0x86, 0x8d, 0x94, 0xe7, 0xdc, 0x7b, 0xcf, 0x3d, 0xcf,
+	0xfb, 0xf8, 0x6e, 0x41, 0xeb, 0xb5, 0xe7, 0x5a, 0xcc, 0x77, 0x4c, 0xd7, 0xd2, 0x47, 0xbe, 0x17,
+	0x7a, 0xed, 0x9b, 0xa7, 0x9e, 0x77, 0xea, 0xb0, 0x4d, 0x73, 0x64, 0x6f, 0x9a, 0xae, 0xeb, 0x85,
+	0x66, 0x68, 0x7b, 0x6e, 0x20, 0x5a, 0xe9, 0x7f, 0x97, 0xa0, 0xf0, 0xd4, 0xeb, 0x13, 0x0d, 0x16,
+	0x46, 0xbe, 0xf7, 0x8a, 0x0d, 0x42, 0x2d, 0xb7, 0x9e, 0xbb, 0x57, 0x35, 0x14, 0x49, 0x9a, 0x90,
+	0xb7, 0x2d, 0x2d, 0xbf, 0x9e, 0xbb, 0x57, 0x34, 0xf2, 0xb6, 0x45, 0x08, 0x14, 0xcf, 0x6d, 0xd7,
+	0xd2, 0x0a, 0xbc, 0x1b, 0xff, 0x26, 0x77, 0xa1, 0x1c, 0x84, 0x66, 0x38, 0x0e, 0xb4, 0xe2, 0x7a,
+	0xee, 0x5e, 0xf3, 0x51, 0x4d, 0x7f, 0xea, 0xf5, 0xf5, 0x43, 0xce, 0x32, 0x64, 0x13, 0x59, 0x81,
+	0x92, 0xed, 0x8e, 0xc6, 0xa1, 0x56, 0xe2, 0x23, 0x05, 0x41, 0xd6, 0xa0, 0xec, 0x8d, 0x43, 0x64,
+	0x97, 0x39, 0x5b, 0x52, 0xa4, 0x0d, 0x95, 0x21, 0x0b, 0x4d, 0xcb, 0x0c, 0x4d, 0x6d, 0x81, 0xb7,
+	0x44, 0x34, 0xf9, 0x10, 0xea, 0xa1, 0x6f, 0x0e, 0x58, 0x6f, 0x64, 0xfa, 0xcc, 0x0d, 0xb5, 0x0a,
+	0x6f, 0xaf, 0x71, 0xde, 0x01, 0x67, 0x91, 0x5b, 0x00, 0x16, 0x73, 0x58, 0xc8, 0xac, 0x9e, 0x19,
+	0x6a, 0xd5, 0xf5, 0xdc, 0xbd, 0x82, 0x51, 0x95, 0x9c, 0x4e, 0x48, 0x3e, 0x81, 0x45, 0xdb, 0x62,
+	0xc3, 0x91, 0x17, 0x32, 0x77, 0x30, 0xe9, 0x9d, 0xb3, 0x89, 0x06, 0x5c, 0x48, 0x33, 0xc1, 0x7e,
+	0xc6, 0x26, 0xe4, 0x06, 0x54, 0xfd, 0xb1, 0xdb, 0x33, 0x4f, 0x42, 0xe6, 0x6b, 0x35, 0x2e, 0xa6,
+	0xe2, 0x8f, 0xdd, 0x0e, 0xd2, 0xe4, 0x0e, 0xd4, 0x86, 0xe6, 0x9b, 0x9e, 0x3f, 0x76, 0x43, 0x7b,
+	0xc8, 0xb4, 0x3a, 0x6f, 0x86, 0xa1, 0xf9, 0xc6, 0x10, 0x1c, 0x34, 0xc2, 0x62, 0xa6, 0xe5, 0xd8,
+	0x2e, 0xd3, 0x1a, 0x62, 0xb0, 0xa2, 0xc9, 0xc7, 0xd0, 0x3c, 0x31, 0x6d, 0x67, 0xec, 0xb3, 0x9e,
+	0xcf, 0xcc, 0xc0, 0x73, 0xb5, 0x26, 0xd7, 0xa0, 0x21, 0xb9, 0x06, 0x67, 0x92, 0x3b, 0x50, 0x62,
+	0xbe, 0xef, 0xf9, 0xda, 0xe2, 0x7a, 0xee, 0x5e, 0xed, 0x51, 0x15, 0x3d, 0xdb, 0x45, 0x86, 0x21,
+	0xf8, 0xe4, 0x63, 0xa8, 0x8c, 0x7c, 0xef, 0xd4, 0x67, 0x41, 0xa0, 0xb5, 0x64, 0x9f, 0x03, 0xc9,
+	0x30, 0xa2, 0x26, 0xf4, 0x19, 0x86, 0xaa, 0x77, 0xc1, 0xfc, 0xc0, 0xf6, 0x5c, 0x6d, 0x69, 0x3d,
+	0x77, 0xaf, 0x61, 0xd4, 0x90, 0xf7, 0x52, 0xb0, 0xd0, 0x9c, 0x90, 0x0d, 0x47, 0x8e, 0x19, 0xb2,
+	0x9e, 0x6d, 0x69, 0x84, 0x87, 0x1c, 0x14, 0x6b, 0xd7, 0x22, 0x9f, 0x42, 0x2b, 0xea, 0xa0, 0xe4,
+	0x2c, 0x73, 0x39, 0x8b, 0x8a, 0xaf, 0x64, 0x5d, 0x87, 0x8a, 0xe9, 0xfb, 0xe6, 0x04, 0x05, 0xad,
+	0x70, 0x41, 0x0b, 0x9c, 0xde, 0xb5, 0x70, 0x1a, 0xd9, 0xe4, 0x5a, 0xec, 0x8d, 0xb6, 0xca, 0x05,
+	0x80, 0x68, 0x45, 0x0e, 0xed, 0x43, 0x59, 0xa4, 0x0e, 0xa9, 0xc1, 0xc2, 0x41, 0x77, 0x7f, 0x7b,
+	0x77, 0x7f, 0xa7, 0xf5, 0x01, 0x01, 0x28, 0x1f, 0x1c, 0xef, 0xed, 0x75, 0xb7, 0x5b, 0x39, 0x6c,
+	0x30, 0x8e, 0xf7, 0xf7, 0xb1, 0x21, 0x8f, 0x0d, 0x5f, 0x75, 0x76, 0xb1, 0xa1, 0x40, 0x1a, 0x50,
+	0xdd, 0x7a, 0xf1, 0xfc, 0x60, 0xaf, 0x7b, 0xd4, 0xdd, 0x6e, 0x15, 0xb1, 0xe9, 0xd9, 0x2e, 0x1f,
+	0x53, 0xc2, 0x31, 0xdd, 0x6f, 0x0f, 0x76, 0x8d, 0xee, 0x76, 0xab, 0x4c, 0xff, 0x3e, 0x07, 0x15,
+	0xe5, 0x25, 0x0c, 0xd3, 0x89, 0x6f, 0x0e, 0xb0, 0x2e, 0x78, 0xf6, 0xe7, 0x8c, 0x88, 0xc6, 0x74,
+	0x0f, 0x42, 0x36, 0x92, 0x05, 0xc0, 0xbf, 0xb9, 0xa3, 0xbc, 0xd0, 0x74, 0x7a, 0x48, 0x05, 0x5a,
+	0x41, 0x3a, 0x0a, 0x59, 0x87, 0xc8, 0xc1, 0x6a, 0x1a, 0xb2, 0x20, 0x30, 0x4f, 0x19, 0x2f, 0x88,
+	0xaa, 0xa1, 0x48, 0xcc, 0xcb, 0xf1, 0xc8, 0x32, 0x65, 0x5e, 0x96, 0x44, 0x5e, 0x4a, 0x4e, 0x27,
+	0x24, 0x2d, 0x28, 0xb0, 0xd0, 0xe4, 0xa5, 0x50, 0x30, 0xf0, 0x93, 0xee, 0xc3, 0xaa, 0xc1, 0x46,
+	0x9e, 0x1f, 0x46, 0x31, 0x65, 0xdf, 0x8d, 0x59, 0xa0, 0xea, 0x32, 0x17, 0xd5, 0x65, 0x32, 0x0f,
+	0xf2, 0x73, 0xf3, 0x80, 0xfe, 0x45, 0x0e, 0x2a, 0x2a, 0x85, 0xd0, 0xb8, 0x81, 0x67, 0x31, 0x59,
+	0xf2, 0xfc, 0x3b, 0xa9, 0x7b, 0x3e, 0xad, 0xfb, 0x4d, 0xa8, 0xfa, 0x2c, 0xf4, 0x27, 0x66, 0xdf,
+	0x61, 0xdc, 0xe8, 0x8a, 0x11, 0x33, 0xb0, 0x90, 0x5f, 0x7b, 0xfe, 0x39, 0xf3, 0xa5, 0xc9, 0x92,
+	0x42, 0x79, 0x16, 0x0b, 0x4d, 0xdb, 0x09, 0x64, 0xe1, 0x2b, 0x92, 0xfe, 0x11, 0x68, 0x87, 0xe3,
+	0xe1, 0xd0, 0xf4, 0xed, 0xef, 0xd9, 0x57, 0x22, 0xe9, 0x23, 0xeb, 0xe6, 0xaf, 0x47, 0x6a, 0xfd,
+	0xc9, 0x27, 0xd6, 0x9f, 0x15, 0x28, 0x05, 0xb6, 0x3b, 0x10, 0x5a, 0x15, 0x0c, 0x41, 0xd0, 0xff,
+	0xca, 0x41, 0x5d, 0xca, 0xdd, 0xf1, 0xbd, 0xf1, 0xe8, 0x3d, 0x85, 0x2a, 0xe7, 0x14, 0x12, 0xce,
+	0x59, 0x81, 0xd2, 0xc0, 0x1b, 0xbb, 0x21, 0xb7, 0xb1, 0x68, 0x08, 0x22, 0xed, 0x98, 0x12, 0x6f,
+	0x89, 0x19, 0x98, 0x2d, 0x8e, 0x19, 0x84, 0x3d, 0xac, 0x6b, 0x66, 0xc9, 0xd8, 0x02, 0xb2, 0xbe,
+	0xe2, 0x1c, 0x72, 0x5b, 0x76, 0x78, 0xe5, 0xf5, 0xb1, 0x5c, 0x16, 0x84, 0x00, 0x64, 0x3d, 0xf5,
+	0xfa, 0xbb, 0x16, 0x96, 0x2e, 0x6f, 0x57, 0x61, 0x91, 0xcb, 0x1d, 0xf2, 0x9e, 0x0b, 0x16, 0xfd,
+	0x0c, 0x9a, 0xd2, 0x52, 0xe1, 0xd1, 0x09, 0xf9, 0x18, 0xca, 0xa7, 0x68, 0x74, 0xa0, 0xe5, 0xd6,
+	0x0b, 0xf7, 0x6a, 0x8f, 0x1a, 0x7a, 0xd2, 0x15, 0x86, 0x6c, 0xa4, 0x7f, 0x02, 0xb5, 0xa7, 0x5e,
+	0x7f, 0xcf, 0x3b, 0xdd, 0x3a, 0x1b, 0xbb, 0xe7, 0x64, 0x15, 0xca, 0x52, 0x0b, 0x91, 0x58, 0xa5,
+	0x57, 0x5c, 0x83, 0x16, 0x14, 0x02, 0xf6, 0x9d, 0xac, 0x01, 0xfc, 0xc4, 0x68, 0x07, 0xa1, 0xcf,
+	0xcc, 0xa1, 0x74, 0x8f, 0xa4, 0xd0, 0x69, 0x7c, 0xc9, 0x16, 0x39, 0xc0, 0xbf, 0xd1, 0xed, 0x03,
+	0x9f, 0x61, 0x86, 0xcb, 0x84, 0x57, 0x24, 0xed, 0xc2, 0x6a, 0x67, 0x34, 0x62, 0xae, 0x25, 0x74,
+	0x08, 0x0c, 0x16, 0x8c, 0x3c, 0x37, 0xe0, 0xc9, 0x34, 0x40, 0x85, 0x02, 0xa9, 0x87, 0xa4, 0xd0,
+	0xff, 0xfd, 0x49, 0xc8, 0x02, 0xa9, 0x8a, 0x20, 0xe8, 0x10, 0xc8, 0x91, 0x69, 0x3b, 0x91, 0x90,
+	0xd9, 0x05, 0x72, 0x03, 0xaa, 0x7c, 0x19, 0xef, 0xc5, 0xa6, 0x54, 0x38, 0xe3, 0x90, 0x7d, 0x87,
+	0x7a, 0xa3, 0x3f, 0xb9, 0x35, 0x0d, 0x83, 0x7f, 0xa3, 0x12, 0x27, 0x9e, 0xe3, 0x78, 0xaf, 0xb9,
+	0x35, 0x15, 0x43, 0x52, 0xf4, 0x47, 0x00, 0x7b, 0x76, 0x10, 0xbe, 0x38, 0x79, 0xea, 0xf5, 0xb1,
+	0xd6, 0x8b, 0xaf, 0xbc, 0xbe, 0x72, 0x73, 0x11, 0xd7, 0x67, 0x83, 0x73, 0xe8, 0x1d, 0x68, 0x48,
+	0x5d, 0xbe, 0xb1, 0xc3, 0xb3, 0x5d, 0x2b, 0xab, 0x11, 0xfd, 0x75, 0x0e, 0x16, 0x51, 0x12, 0xca,
+	0x51, 0x5a, 0x5f, 0x87, 0xca, 0x99, 0xf7, 0xba, 0x37, 0x34, 0xdd, 0x09, 0xef, 0xd9, 0x30, 0x16,
+	0xce, 0xbc, 0xd7, 0xcf, 0x4d, 0x77, 0x92, 0x4c, 0xdf, 0xfc, 0xec, 0xf4, 0x4d, 0xee, 0xc9, 0xb8,
+	0xc5, 0xb9, 0x03, 0x67, 0x6c, 0xb1, 0x9e, 0xdc, 0xf7, 0xa4, 0x19, 0x4d, 0xc9, 0xde, 0x16, 0x5c,
+	0xcc, 0xde, 0x60, 0x70, 0xc6, 0xac, 0xb1, 0x23, 0x03, 0x54, 0x31, 0x62, 0x46, 0x6a, 0x21, 0x2f,
+	0xa7, 0x16, 0x72, 0xba, 0x05, 0x2b, 0x06, 0x53, 0x3d, 0xd1, 0xec, 0xf9, 0x8e, 0x8f, 0xf7, 0xd0,
+	0x7c, 0x7a, 0x0f, 0xa5, 0x6f, 0xa1, 0x66, 0x78, 0x0e, 0x7b, 0x62, 0xbb, 0x96, 0xed, 0x9e, 0x4e,
+	0x8d, 0xbd, 0x09, 0xd5, 0x91, 0x6f, 0xbb, 0x03, 0x7b, 0x64, 0x3a, 0xd2, 0xea, 0x98, 0x81, 0x76,
+	0xfb, 0x9e, 0x13, 0x95, 0x28, 0x7e, 0x27, 0xbd, 0x54, 0x9c, 0xed, 0xa5, 0x52, 0xec, 0x25, 0xfa,
+	0x0b, 0x20, 0x22, 0x96, 0x09, 0x25, 0x02, 0x72, 0x0f, 0x2a, 0x7d, 0xf9, 0x2d, 0xe3, 0x5a, 0xd7,
+	0x13, 0x1d, 0x8c, 0xa8, 0x95, 0x7e, 0x0d, 0xd7, 0x70, 0x7c, 0x72, 0xb4, 0x72, 0x43, 0x4a, 0xf5,
+	0x5c, 0x56, 0xf5, 0xb9, 0xc1, 0xa4, 0x27, 0xb0, 0xb4, 0x1b, 0x04, 0x63, 0x76, 0xe4, 0x9d, 0x33,
+	0x37, 0xb1, 0x1e, 0x06, 0xe3, 0x7e, 0x72, 0xe9, 0x92, 0x24, 0x66, 0xe9, 0xa9, 0x6f, 0xba, 0x21,
+	0xd6, 0x44, 0x01, 0x2b, 0x51, 0x50, 0x7c, 0x93, 0x0a, 0x9d, 0x5e, 0xc0, 0x06, 0x9e, 0x6b, 0x05,
+	0x72, 0x65, 0x84, 0x30, 0x74, 0x0e, 0x05, 0x87, 0xfe, 0x1c, 0x4a, 0x7c, 0x0a, 0x2c, 0xaa, 0x10,
+	0x3f, 0xa4, 0x64, 0x41, 0xe0, 0x4e, 0xc5, 0xde, 0x8c, 0x6c, 0x9f, 0x05, 0x3d, 0x53, 0xe8, 0x58,
+	0x30, 0xaa, 0x92, 0xd3, 0x09, 0xe9, 0x00, 0x88, 0xc1, 0x2e, 0xbc, 0x73, 0x66, 0x6d, 0x31, 0x3f,
+	0xb4, 0x4f, 0xec, 0x81, 0x19, 0xf2, 0xba, 0x0d, 0x98, 0x6f, 0x47, 0x06, 0x4b, 0x0a, 0xf9, 0xf2,
+	0x90, 0x23, 0x8c, 0x95, 0x14, 0x4e, 0xe2, 0x0b, 0x29, 0xbd, 0xfe, 0x44, 0x86, 0xb1, 0x2a, 0x39,
+	0x4f, 0x26, 0xf4, 0x08, 0xae, 0xcb, 0xe8, 0x4c, 0x4d, 0x15, 0x90, 0xcf, 0xa0, 0x3e, 0x48, 0xd0,
+	0x32, 0x50, 0xcb, 0xfa, 0x74, 0x5f, 0x23, 0xd5, 0x91, 0xae, 0xc3, 0x6d, 0x1e, 0xb3, 0x69, 0x99,
+	0xd2, 0xdb, 0xf4, 0x3e, 0x2c, 0x25, 0x2a, 0xf7, 0x30, 0xb2, 0x61, 0x96, 0x6d, 0xd4, 0x85, 0x6b,
+	0x3c, 0x5e, 0xc9, 0x09, 0x65, 0xd4, 0x5a, 0x50, 0x18, 0x04, 0xbe, 0xec, 0x8f, 0x9f, 0x73, 0xa3,
+	0xf5, 0x29, 0xb4, 0x1c, 0xfb, 0x84, 0xe1, 0xa9, 0x31, 0x13, 0xb2, 0x45, 0xc5, 0x57, 0x71, 0xbb,
+	0x0f, 0xd7, 0x0c, 0xe6, 0xb2, 0xd7, 0x57, 0x99, 0x8f, 0xfe, 0x4b, 0x4e, 0x66, 0xd3, 0x95, 0xc2,
+	0x74, 0x07, 0x6a, 0x03, 0x6f, 0x38, 0xf4, 0xdc, 0x9e, 0x6b, 0x0e, 0xd5, 0xfe, 0x0f, 0x82, 0xb5,
+	0x6f, 0x0e, 0x59, 0x42, 0xfd, 0x42, 0x4a, 0xfd, 0x75, 0xa8, 0x25, 0x5c, 0x2c, 0x0b, 0x2f, 0xc9,
+	0xc2, 0x45, 0xc0, 0xf5, 0x42, 0xb9, 0x08, 0x88, 0x6d, 0xa0, 0xe2, 0x7a, 0xa1, 0x38, 0x48, 0xdf,
+	0x80, 0xaa, 0xcd, 0x95, 0xc4, 0x2c, 0x10, 0xf7, 0x80, 0x8a, 0x60, 0x3c, 0x99, 0xd0, 0x7f, 0xca,
+	0x43, 0xe5, 0x50, 0xae, 0x32, 0x53, 0xeb, 0xc3, 0x3a, 0x54, 0xd4, 0xd1, 0x53, 0x9e, 0x7a, 0xc4,
+	0x0a, 0x1c, 0x71, 0xf9, 0x36, 0xee, 0x7b, 0x6e, 0xb4, 0x8d, 0xfb, 0x9e, 0x8b, 0x07, 0x3e, 0xf4,
+	0xe8, 0xf7, 0x9e, 0xab, 0x74, 0x8d, 0x68, 0x72, 0x1f, 0x16, 0xbc, 0x0b, 0xbc, 0x41, 0x8d, 0xb8,
+	0x9a, 0xcd, 0x47, 0x4b, 0xba, 0x9a, 0x5d, 0x7f, 0x21, 0x1a, 0x0c, 0xd5, 0x03, 0xfd, 0x31, 0x32,
+	0xc7, 0x81, 0xdc, 0xd6, 0x2b, 0x86, 0xa4, 0x70, 0xd5, 0x74, 0xd9, 0x9b, 0x10, 0xaf, 0x06, 0x7c,
+	0x3f, 0x2f, 0x18, 0x0b, 0x48, 0x1b, 0x63, 0x7e, 0x32, 0xe6, 0xbb, 0x39, 0x36, 0x55, 0x44, 0x13,
+	0xd2, 0xd8, 0x94, 0x39, 0x08, 0x54, 0x33, 0x07, 0x01, 0x7a, 0x1f, 0x16, 0xa4, 0x06, 0xa4, 0x02,
+	0xc5, 0xc3, 0x67, 0xbb, 0x07, 0xad, 0x0f, 0x48, 0x15, 0x4a, 0x5f, 0x1f, 0x77, 0x8f, 0xbb, 0xf2,
+	0x54, 0xdc, 0x3d, 0xd8, 0xeb, 0x6c, 0x75, 0x5b, 0x79, 0xfa, 0x58, 0xec, 0x2d, 0x2f, 0x4e, 0x94,
+	0xf6, 0x01, 0xf9, 0x24, 0x5e, 0xe9, 0x55, 0xb9, 0x54, 0x23, 0xe3, 0xe2, 0x45, 0x3f, 0xa0, 0x0f,
+	0x61, 0x05, 0xc7, 0x46, 0x23, 0xdf, 0x79, 0x2a, 0xa3, 0xbf, 0x80, 0x95, 0x03, 0x34, 0x3d, 0x92,
+	0x36, 0x67, 0x2f, 0x88, 0x1d, 0x96, 0x4f, 0x3a, 0x8c, 0xfe, 0x4d, 0x1e, 0x16, 0xbe, 0x61, 0xfd,
+	0x33, 0xcf, 0x3b, 0x9f, 0x1a, 0x33, 0x7f, 0xdf, 0x6b, 0x41, 0x61, 0xec, 0x3b, 0x32, 0xb4, 0xf8,
+	0x49, 0x3e, 0x81, 0x8a, 0xb8, 0x6e, 0x32, 0xbc, 0x8b, 0x16, 0xb2, 0x77, 0xd1, 0xa8, 0x11, 0x17,
+	0x3d, 0xdc, 0x00, 0xf0, 0x50, 0x8a, 0x89, 0x2c, 0x08, 0xf2, 0x00, 0xca, 0x8e, 0xd9, 0x67, 0x4e,
+	0xa0, 0x95, 0xb9, 0x7b, 0x56, 0x74, 0xa9, 0x94, 0xbe, 0xc7, 0xd9, 0x5d, 0x37, 0xf4, 0x27, 0x86,
+	0xec, 0x23, 0xca, 0x68, 0xe0, 0xb3, 0x50, 0xde, 0x50, 0x25, 0x95, 0x3c, 0xf0, 0x54, 0x52, 0x07,
+	0x9e, 0xf6, 0xcf, 0xa0, 0x96, 0x10, 0x84, 0xfa, 0xe3, 0xd5, 0x53, 0xd6, 0xeb, 0x39, 0x9b, 0xa0,
+	0x5a, 0x17, 0xa6, 0x33, 0x56, 0xb5, 0x27, 0x88, 0xc7, 0xf9, 0xcf, 0x73, 0xf4, 0x77, 0xa0, 0x29,
+	0xe2, 0x29, 0x35, 0x0a, 0xc8, 0x47, 0x50, 0x79, 0x2d, 0xbf, 0x65, 0x34, 0x2b, 0x4a, 0x5d, 0x23,
+	0x6a, 0xa1, 0x9b, 0xb0, 0x8c, 0xe3, 0xd4, 0xa8, 0x77, 0x87, 0xf2, 0x6f, 0x0b, 0xb0, 0x28, 0x7b,
+	0x6f, 0x33, 0xc7, 0xbe, 0x60, 0xfe, 0x64, 0x2a, 0x24, 0xb7, 0x00, 0xe4, 0x04, 0xbd, 0x08, 0x1c,
+	0xa8, 0x4a, 0xce, 0xae, 0x95, 0x38, 0x46, 0x16, 0x92, 0xc7, 0xc8, 0x2b, 0xc1, 0x04, 0x0f, 0xa0,
+	0x84, 0x5f, 0x4c, 0x56, 0xdf, 0x9a, 0x9e, 0xd1, 0x85, 0xf7, 0x67, 0x86, 0xe8, 0x84, 0x95, 0x6c,
+	0x86, 0x58, 0xeb, 0x61, 0xc0, 0x4b, 0xb0, 0x61, 0x44, 0x74, 0x32, 0x0c, 0x0b, 0xa9, 0x30, 0xe0,
+	0x89, 0x9a, 0x97, 0xa7, 0xec, 0x2a, 0xa3, 0x54, 0x43, 0x5e, 0x47, 0xb0, 0xb0, 0x8b, 0x25, 0x66,
+	0x4c, 0x42, 0x08, 0xb5, 0x88, 0xd7, 0xe1, 0x18, 0x03, 0x2f, 0x57, 0x71, 0x3f, 0x17, 0xf8, 0x01,
+	0xaf, 0x56, 0x71, 0xb9, 0x7a, 0x00, 0x84, 0x37, 0xfb, 0xf2, 0x50, 0xdb, 0xe3, 0xb7, 0x09, 0xc4,
+	0x10, 0x4a, 0x46, 0x0b, 0x5b, 0xd4, 0x69, 0x77, 0xcb, 0xb3, 0x18, 0xfd, 0x31, 0x94, 0xb8, 0x61,
+	0xe9, 0x3b, 0x6f, 0x03, 0xaa, 0xdb, 0xdd, 0xbd, 0xdd, 0x97, 0x5d, 0x83, 0x5f, 0x7b, 0x2b, 0x50,
+	0xdc, 0xee, 0x76, 0xb6, 0x5b, 0x79, 0xfa, 0x0c, 0xae, 0xa5, 0xb2, 0x41, 0x7a, 0xc7, 0x66, 0x01,
+	0x79, 0xc8, 0xa1, 0x0f, 0x49, 0xc9, 0xc4, 0x68, 0x65, 0xbd, 0x68, 0x24, 0xfa, 0xd0, 0x31, 0xdc,
+	0x4c, 0xa4, 0x48, 0x2c, 0x4a, 0xe5, 0x4a, 0x3a, 0xda, 0xb9, 0x6c, 0xb4, 0x93, 0x47, 0xd6, 0x7c,
+	0xfa, 0xc8, 0x7a, 0x03, 0xaa, 0x9e, 0xeb, 0x4c, 0x7a, 0x88, 0x7a, 0xc8, 0x2b, 0x63, 0x05, 0x19,
+	0xdb, 0xcc, 0xb4, 0xe8, 0xbf, 0xe5, 0xa0, 0xf8, 0x0c, 0x8f, 0xaa, 0x97, 0xde, 0xcb, 0x12, 0x3b,
+	0x11, 0xff, 0xc6, 0xde, 0x0a, 0x7c, 0x10, 0xa7, 0x75, 0x45, 0x62, 0xcc, 0x38, 0xa8, 0xd4, 0xc3,
+	0x95, 0x6c, 0xa8, 0x2e, 0x21, 0x35, 0xce, 0x3b, 0xe4, 0x2c, 0x72, 0x17, 0x1a, 0x02, 0x60, 0x52,
+	0x7d, 0xc4, 0x61, 0xb0, 0x2e, 0x98, 0xb2, 0xd3, 0x3a, 0xd4, 0x2c, 0x16, 0x0c, 0x7c, 0x7b, 0xc4,
+	0x21, 0x01, 0xb1, 0x21, 0x25, 0x59, 0xf3, 0x53, 0x8b, 0x7e, 0x0b, 0xcd, 0x1d, 0x16, 0xa2, 0x59,
+	0x57, 0xba, 0xca, 0x5e, 0xdd, 0x3a, 0xfa, 0x00, 0x5a, 0x18, 0x25, 0x14, 0x7d, 0x85, 0x2a, 0xde,
+	0x80, 0x9a, 0x48, 0x10, 0xde, 0x9f, 0xdc, 0x50, 0xcb, 0x9d, 0xc8, 0x87, 0x92, 0xce, 0x35, 0x14,
+	0x3c, 0xfa, 0x3f, 0x39, 0x58, 0x3a, 0x92, 0xfb, 0xe5, 0x81, 0xe9, 0x9b, 0x43, 0x86, 0x9b, 0xb2,
+	0xd2, 0x2e, 0x97, 0xd0, 0xee, 0x3e, 0x14, 0xc3, 0xc9, 0x48, 0x68, 0xdc, 0x7c, 0x74, 0x4d, 0x9f,
+	0x1a, 0xa5, 0x1f, 0x4d, 0x46, 0xcc, 0xe0, 0x9d, 0xd0, 0xd7, 0x16, 0x3b, 0x31, 0xc7, 0x4e, 0xd8,
+	0x13, 0x6b, 0x9a, 0x58, 0xa7, 0xeb, 0x92, 0xf9, 0x12, 0x79, 0x78, 0xe4, 0x38, 0x33, 0x83, 0x9e,
+	0xe4, 0xc9, 0x2b, 0x0a, 0x9c, 0x99, 0xc1, 0xb6, 0xe0, 0x64, 0x83, 0x51, 0x9a, 0x0a, 0x06, 0xfd,
+	0x1c, 0x8a, 0x38, 0x2b, 0x82, 0x3d, 0x87, 0x47, 0x86, 0x28, 0x9c, 0x1a, 0x2c, 0xec, 0xee, 0x1f,
+	0x75, 0x77, 0xba, 0x46, 0x2b, 0x87, 0x0d, 0xfb, 0xc7, 0xcf, 0x9f, 0x74, 0x8d, 0x56, 0x1e, 0x1b,
+	0x9e, 0xbc, 0x78, 0xb1, 0xd7, 0xed, 0xec, 0xb7, 0x0a, 0xf4, 0xd7, 0x79, 0x7e, 0xfd, 0x55, 0x56,
+	0xbc, 0xc7, 0xce, 0xa3, 0x9c, 0x53, 0x98, 0x1d, 0xba, 0x62, 0x3a, 0x31, 0x67, 0xdc, 0x3c, 0x62,
+	0x38, 0xb4, 0x9c, 0x84, 0x43, 0x2f, 0x83, 0x3d, 0x33, 0x70, 0x63, 0x65, 0x0a, 0x6e, 0x7c, 0x04,
+	0x30, 0x52, 0x81, 0x08, 0xb4, 0x2a, 0x8f, 0x34, 0x99, 0x8e, 0x91, 0x91, 0xe8, 0x95, 0x75, 0x2f,
+	0x5c, 0x9a, 0xeb, 0xb5, 0x74, 0xae, 0xef, 0x88, 0x45, 0x28, 0xe1, 0xc1, 0xe0, 0xff, 0x94, 0xf4,
+	0xf4, 0x97, 0xea, 0x16, 0x96, 0x14, 0x45, 0x36, 0xa0, 0xaa, 0x4e, 0x71, 0xf1, 0x35, 0x2c, 0xd1,
+	0xc3, 0x88, 0x9b, 0xe9, 0x3f, 0xe7, 0xe1, 0xe6, 0x16, 0x57, 0xeb, 0xa9, 0xd7, 0xff, 0xca, 0xf7,
+	0x86, 0x51, 0xa7, 0xff, 0xdf, 0x2a, 0x24, 0xcf, 0x53, 0x3e, 0x2e, 0x72, 0xad, 0x7e, 0xac, 0x5f,
+	0x36, 0xb5, 0x1e, 0x39, 0x5e, 0x1e, 0x1f, 0x92, 0xee, 0x9f, 0x01, 0x44, 0x97, 0xde, 0x0d, 0x44,
+	0x97, 0xd3, 0x97, 0xe8, 0xf6, 0x17, 0xb0, 0x98, 0x99, 0xe4, 0xbd, 0x8e, 0x16, 0x7f, 0x95, 0x87,
+	0x4a, 0x07, 0x2f, 0xf5, 0xf8, 0x12, 0x30, 0x5d, 0x03, 0xc5, 0xbe, 0x19, 0xa4, 0x4f, 0xd7, 0x9c,
+	0x93, 0x49, 0xb7, 0xc2, 0x95, 0xd2, 0xed, 0x36, 0x14, 0x4f, 0x7d, 0xdb, 0x92, 0x8e, 0x03, 0x9d,
+	0x4f, 0xdb, 0x79, 0x63, 0x07, 0x06, 0xe7, 0x93, 0x9f, 0x42, 0x33, 0xea, 0xdd, 0x0b, 0x58, 0x28,
+	0xce, 0x67, 0x08, 0x5f, 0x45, 0xf2, 0x0e, 0x59, 0x68, 0x34, 0x46, 0x09, 0x2a, 0xc0, 0x10, 0x06,
+	0xf6, 0xf7, 0x4c, 0x9e, 0x00, 0xf8, 0xf7, 0x25, 0xbb, 0xff, 0x47, 0xd1, 0x31, 0xa4, 0xc2, 0x6d,
+	0xaa, 0x0b, 0x2d, 0xd2, 0xe7, 0x10, 0xfa, 0x19, 0x54, 0x23, 0xe5, 0x66, 0xae, 0x85, 0x6b, 0x50,
+	0xe6, 0x2e, 0x8c, 0xae, 0x72, 0x82, 0xa2, 0x7f, 0x99, 0x83, 0x7a, 0x52, 0x59, 0xf2, 0x45, 0xca,
+	0x4f, 0x22, 0x91, 0x6f, 0xa5, 0xec, 0xb9, 0x2c, 0x45, 0x7e, 0xd3, 0xe0, 0xfe, 0x47, 0x0e, 0x6a,
+	0x09, 0xfb, 0x78, 0x21, 0x30, 0x8e, 0x54, 0x28, 0x7c, 0x49, 0x92, 0xfc, 0x6c, 0x3e, 0x76, 0x1c,
+	0x79, 0x36, 0x6f, 0x18, 0x92, 0xc2, 0x11, 0xfe, 0xd8, 0x75, 0x71, 0x84, 0x2c, 0x06, 0x49, 0x22,
+	0xc4, 0x31, 0xf0, 0x86, 0xa3, 0x18, 0x5d, 0x6a, 0x18, 0x31, 0x83, 0xe3, 0x67, 0x02, 0xf3, 0x2c,
+	0x09, 0x79, 0x82, 0x42, 0xfe, 0xb9, 0xed, 0x28, 0x2c, 0xb4, 0x61, 0x48, 0x0a, 0xe7, 0x11, 0xf8,
+	0x82, 0x88, 0x58, 0xc3, 0x50, 0x24, 0x47, 0x15, 0xf1, 0xae, 0x56, 0x11, 0xf1, 0xc5, 0x6f, 0xfa,
+	0x8f, 0x05, 0x01, 0x64, 0x5f, 0x30, 0x97, 0x2f, 0x9b, 0x01, 0x56, 0x1b, 0x62, 0xc0, 0x22, 0x75,
+	0x23, 0x9a, 0xd0, 0xd4, 0x9e, 0xd5, 0xd4, 0xd5, 0xa0, 0xe4, 0x56, 0x35, 0xe7, 0xc0, 0xfa, 0x5e,
+	0x58, 0x52, 0xe2, 0x78, 0x5b, 0x9e, 0x7f, 0xbc, 0xfd, 0x29, 0x2c, 0x8e, 0x7c, 0x76, 0x61, 0x7b,
+	0xe3, 0xa0, 0x27, 0x7b, 0x2f, 0x4c, 0xf7, 0x6e, 0xaa, 0x3e, 0x71, 0xd0, 0x06, 0x67, 0xa6, 0x7b,
+	0xca, 0x6f, 0x14, 0x98, 0x6c, 0x8a, 0x24, 0x6b, 0x50, 0x78, 0xe5, 0xf5, 0xb5, 0x6a, 0xa2, 0x3a,
+	0x91, 0x91, 0x46, 0x9f, 0x60, 0x06, 0xfa, 0x34, 0x67, 0x4d, 0xff, 0x95, 0xdc, 0x4c, 0x6b, 0xb0,
+	0xb0, 0x65, 0x74, 0x3b, 0xf8, 0x8c, 0xf2, 0x01, 0x21, 0xd0, 0x3c, 0x3c, 0xea, 0x1c, 0x1d, 0x1f,
+	0xf6, 0xb6, 0x7e, 0xaf, 0xb3, 0xbf, 0xa3, 0x9e, 0x60, 0x8e, 0x0f, 0xb6, 0x79, 0x07, 0xbe, 0xab,
+	0x6e, 0x77, 0xc5, 0xa3, 0x4b, 0x81, 0xd4, 0xa1, 0x62, 0x74, 0x0f, 0x8f, 0x5e, 0x18, 0xea, 0x09,
+	0xe6, 0xe0, 0xd8, 0xc0, 0x31, 0x25, 0xfa, 0x67, 0x39, 0x58, 0x3e, 0xe4, 0x40, 0x31, 0x8f, 0x40,
+	0xb4, 0x5b, 0xdc, 0x85, 0xc6, 0x89, 0xef, 0x0d, 0x7b, 0x99, 0x18, 0xd6, 0x91, 0x79, 0xa8, 0xe2,
+	0xf8, 0x7e, 0xf0, 0xe7, 0x3c, 0xf0, 0xf6, 0xef, 0xf2, 0x00, 0x9d, 0xb1, 0x65, 0x87, 0x22, 0x71,
+	0x66, 0xec, 0xf8, 0xca, 0x31, 0xf9, 0xf4, 0xaa, 0x91, 0x72, 0x68, 0x21, 0xeb, 0xd0, 0x16, 0x14,
+	0xfc, 0xd1, 0x40, 0x66, 0x09, 0x7e, 0x26, 0x52, 0xaa, 0x34, 0x27, 0xa5, 0xca, 0x69, 0x2b, 0xd6,
+	0xa0, 0xdc, 0x67, 0x27, 0x9e, 0xcf, 0xd4, 0x6d, 0x52, 0x50, 0x58, 0xda, 0x62, 0xd5, 0x17, 0xb8,
+	0xbf, 0x20, 0x92, 0x19, 0x51, 0x4d, 0x67, 0xc4, 0x87, 0x50, 0x1f, 0x31, 0xe6, 0xf7, 0x4c, 0xcb,
+	0xe2, 0x8f, 0x41, 0x72, 0x4b, 0x47, 0x5e, 0x47, 0xb0, 0x38, 0xce, 0xc3, 0xfc, 0xb0, 0x27, 0x41,
+	0xa0, 0x9a, 0xc4, 0x79, 0x98, 0x1f, 0x0a, 0xac, 0x8b, 0x7e, 0x0e, 0x4b, 0x62, 0x43, 0x8e, 0x5d,
+	0x15, 0x60, 0x7e, 0x33, 0xfe, 0x25, 0xd7, 0xb0, 0x9a, 0x1e, 0xb7, 0x1a, 0xb2, 0x89, 0xfe, 0x43,
+	0x0e, 0xd6, 0x70, 0x68, 0x62, 0xe0, 0x6f, 0x04, 0x6d, 0x5f, 0xee, 0xf6, 0xd8, 0xc9, 0xc5, 0xcc,
+	0x7b, 0x05, 0x46, 0xa3, 0x14, 0x47, 0x23, 0x7a, 0x21, 0x2a, 0x27, 0x5e, 0x88, 0x1e, 0xfd, 0x6b,
+	0x1b, 0xe0, 0x9b, 0xe8, 0xc1, 0x9c, 0x3c, 0x80, 0x6a, 0xb4, 0x91, 0x13, 0x5e, 0x4b, 0x6d, 0xfe,
+	0x97, 0x2e, 0xff, 0xf9, 0xbf, 0xff, 0xe7, 0x5f, 0xe7, 0x1b, 0xb4, 0xb2, 0x79, 0xf1, 0x93, 0x4d,
+	0xc4, 0xf6, 0x1f, 0xe7, 0x36, 0xc8, 0x6f, 0x43, 0x79, 0x87, 0x85, 0x7c, 0xcb, 0xd4, 0x53, 0x38,
+	0xbf, 0x1c, 0xb4, 0xca, 0x07, 0x2d, 0x92, 0x86, 0x1a, 0xb4, 0xf9, 0x83, 0x6d, 0xbd, 0x25, 0xbf,
+	0x0b, 0x15, 0x85, 0xf9, 0x93, 0x96, 0x9e, 0x81, 0xff, 0xdb, 0x35, 0x3d, 0x7e, 0x5a, 0xa0, 0x2d,
+	0x2e, 0x01, 0x48, 0x34, 0x2d, 0xf9, 0x09, 0x54, 0x9f, 0x7b, 0x96, 0x7d, 0x32, 0xc9, 0x6a, 0xa8,
+	0xf1, 0xae, 0xa4, 0x9d, 0x9e, 0x0c, 0xd5, 0x7c, 0x0a, 0x8b, 0x07, 0x63, 0xc7, 0x39, 0x10, 0x8b,
+	0xfc, 0x55, 0xa6, 0x95, 0xb2, 0x68, 0x24, 0xeb, 0x31, 0x6e, 0x04, 0x28, 0xeb, 0x73, 0xa8, 0x8a,
+	0x57, 0x83, 0x77, 0x5a, 0xbd, 0x31, 0x65, 0xf5, 0xc2, 0x33, 0xdb, 0x71, 0xe6, 0x8f, 0x6b, 0xf3,
+	0x71, 0x2b, 0x94, 0xa4, 0x0d, 0xc0, 0xad, 0x81, 0x7c, 0x8d, 0x0f, 0x29, 0x89, 0x87, 0x06, 0xb2,
+	0xaa, 0xcf, 0x7a, 0x78, 0x90, 0x92, 0xee, 0x72, 0x49, 0xb7, 0xa8, 0x96, 0x96, 0xe4, 0x47, 0x23,
+	0xd0, 0x92, 0x2d, 0xa8, 0x1d, 0xbb, 0xd6, 0x3b, 0x6c, 0xb9, 0xcd, 0x25, 0x69, 0x74, 0x2d, 0x2d,
+	0x69, 0x2c, 0x07, 0x92, 0x5d, 0xc4, 0xc0, 0x83, 0xd0, 0xf3, 0x59, 0xc7, 0x1f, 0x9c, 0xd9, 0x17,
+	0xcc, 0x9a, 0x2f, 0xeb, 0x16, 0x97, 0x75, 0x8d, 0xae, 0x4e, 0x69, 0x85, 0xe3, 0xc9, 0x31, 0x2c,
+	0x4d, 0xbd, 0x85, 0x92, 0xeb, 0xfa, 0xbc, 0xf7, 0xd1, 0xf6, 0xa2, 0x9e, 0x7e, 0xef, 0xa3, 0x2b,
+	0x5c, 0x7e, 0x93, 0xd4, 0x51, 0xfe, 0x89, 0x92, 0xf0, 0x33, 0x68, 0xa4, 0x1e, 0xd8, 0x48, 0x5d,
+	0x4f, 0x3c, 0xf7, 0xb5, 0xd7, 0xf4, 0x99, 0xcf, 0x6f, 0xf4, 0x83, 0x7b, 0x39, 0xb2, 0x0f, 0xb5,
+	0xc4, 0xa3, 0x1a, 0x59, 0xd6, 0xa7, 0x9f, 0xd8, 0xda, 0x29, 0x69, 0x2a, 0x84, 0x24, 0x1d, 0xc2,
+	0x4d, 0xc7, 0x3b, 0x0d, 0x1e, 0xe6, 0xc8, 0x4b, 0x68, 0xa6, 0x1f, 0xb2, 0xc9, 0x9a, 0x3e, 0xf3,
+	0x65, 0xbb, 0x1d, 0xbf, 0x5b, 0xd3, 0x0f, 0xb9, 0xc8, 0x1b, 0x8f, 0x73, 0x1b, 0xd9, 0x20, 0x44,
+	0x3f, 0x6c, 0xf8, 0x12, 0x2a, 0xdf, 0x98, 0xe1, 0xe0, 0x6c, 0xbe, 0xeb, 0x6f, 0x70, 0x21, 0xab,
+	0x64, 0x39, 0x2d, 0xe1, 0x35, 0x8e, 0x7a, 0x98, 0x23, 0xbf, 0x84, 0xa6, 0xa8, 0xfa, 0x08, 0x64,
+	0x8e, 0x41, 0xd1, 0x76, 0xfc, 0x99, 0x2e, 0x8b, 0x08, 0x2b, 0xc5, 0x64, 0x7a, 0x01, 0x8d, 0x14,
+	0x5c, 0x4a, 0x56, 0xf5, 0x59, 0xf0, 0x69, 0xbb, 0xa5, 0x67, 0x10, 0xd9, 0xf4, 0x1a, 0x11, 0xc9,
+	0x24, 0x2f, 0xa1, 0x91, 0x42, 0x53, 0xc9, 0xaa, 0x3e, 0x0b, 0x5d, 0x4d, 0x6a, 0x97, 0xca, 0xfa,
+	0x48, 0x92, 0xf4, 0x15, 0x8e, 0x45, 0x45, 0x77, 0xa0, 0x29, 0xea, 0x37, 0xc6, 0xd3, 0x33, 0x1e,
+	0x4b, 0x48, 0x94, 0xe1, 0xdc, 0x20, 0xd3, 0x12, 0xc9, 0xcf, 0xa1, 0x21, 0x7c, 0xa6, 0x30, 0xdb,
+	0x08, 0x79, 0x6c, 0x47, 0x5f, 0xf4, 0x1a, 0x17, 0xb0, 0x84, 0xc1, 0xe3, 0x59, 0xa9, 0x20, 0x49,
+	0xf2, 0x0c, 0xea, 0x49, 0x48, 0x92, 0xac, 0xe8, 0x33, 0x10, 0xca, 0xf6, 0xa2, 0x9e, 0xc6, 0x3b,
+	0xd3, 0x29, 0x1e, 0x09, 0xdb, 0x86, 0x86, 0xb0, 0x29, 0x82, 0x8f, 0x33, 0x26, 0xc5, 0x0a, 0x5d,
+	0xe7, 0x02, 0x96, 0x37, 0x96, 0x92, 0x02, 0x84, 0x41, 0x7f, 0x0a, 0xab, 0x33, 0x21, 0x30, 0x72,
+	0x4b, 0xbf, 0x0c, 0x1a, 0x6b, 0x6b, 0xfa, 0x1c, 0x18, 0x8e, 0x3e, 0xe0, 0x93, 0xfd, 0x88, 0x7c,
+	0x94, 0x9e, 0x2c, 0x06, 0xd2, 0xde, 0x6e, 0xc6, 0x10, 0x1c, 0x19, 0xe0, 0x5b, 0x6a, 0xe8, 0x4f,
+	0xa6, 0x80, 0xd7, 0x8c, 0x31, 0x53, 0x40, 0x1e, 0xfd, 0x94, 0xcf, 0x73, 0x97, 0x7e, 0x98, 0x98,
+	0xa7, 0x17, 0x4b, 0x56, 0xcb, 0x0c, 0x5e, 0x1b, 0x1e, 0x41, 0xdd, 0x60, 0xa7, 0x76, 0x10, 0x32,
+	0x9f, 0xe3, 0x6e, 0x02, 0x05, 0x6a, 0x8b, 0x7f, 0xca, 0xbd, 0xb4, 0x8a, 0x82, 0x38, 0x30, 0x84,
+	0x29, 0xd3, 0x85, 0x05, 0x89, 0x67, 0x91, 0x45, 0x3d, 0x8d, 0x6c, 0xa9, 0x81, 0x94, 0x0f, 0xbc,
+	0x49, 0xda, 0xd1, 0xc0, 0xcd, 0x1f, 0xe4, 0xf6, 0xfd, 0x76, 0xf3, 0x07, 0xbc, 0x2b, 0xbd, 0x25,
+	0x5f, 0x42, 0x35, 0x02, 0xaf, 0xc8, 0x92, 0x9e, 0x05, 0xb2, 0xda, 0x75, 0x3d, 0x81, 0x56, 0xd1,
+	0x25, 0x2e, 0xb1, 0x46, 0x62, 0x55, 0xc8, 0x2e, 0x2c, 0x45, 0x7b, 0x73, 0x84, 0xd7, 0xa4, 0xe0,
+	0x80, 0x76, 0x8a, 0x4a, 0x97, 0x6b, 0x04, 0x14, 0xa0, 0x49, 0xdf, 0x0a, 0x20, 0x2d, 0x85, 0x35,
+	0x68, 0xfa, 0x1c, 0x24, 0xa3, 0xbd, 0xac, 0x4f, 0x43, 0x13, 0xe9, 0xba, 0x8d, 0x84, 0x93, 0x3e,
+	0xac, 0xce, 0x44, 0x02, 0xc8, 0xad, 0x4b, 0x11, 0x02, 0xb9, 0x4e, 0x7d, 0xc4, 0x85, 0xde, 0xc6,
+	0x7a, 0xb9, 0x9e, 0x92, 0x2b, 0x9d, 0x28, 0x8e, 0x00, 0x5f, 0xa8, 0xe5, 0x2a, 0xba, 0xb1, 0x57,
+	0x75, 0xf5, 0xd9, 0x8e, 0x3f, 0x95, 0x8a, 0x14, 0x50, 0x14, 0x7f, 0xb2, 0xe7, 0xc6, 0x77, 0xa0,
+	0xb6, 0xc3, 0xc2, 0xf8, 0xb6, 0x3f, 0x55, 0xff, 0x91, 0x00, 0x59, 0xbe, 0x64, 0x31, 0x16, 0x20,
+	0x6a, 0x65, 0x07, 0xea, 0xb8, 0x97, 0x5f, 0x45, 0xc6, 0x4d, 0x2e, 0x63, 0x8d, 0xae, 0x64, 0x64,
+	0x88, 0x7d, 0xbd, 0x0b, 0xf5, 0xe4, 0x6d, 0x80, 0xac, 0xe8, 0x33, 0x2e, 0x07, 0xed, 0x6a, 0x74,
+	0x63, 0xa3, 0x84, 0x8b, 0xab, 0x13, 0x6e, 0x93, 0x38, 0x70, 0x3e, 0xcc, 0x91, 0x3d, 0x95, 0x1a,
+	0xc9, 0x1f, 0x12, 0xa4, 0x1e, 0xec, 0xdb, 0x29, 0x4a, 0x6d, 0x08, 0xb4, 0x85, 0x62, 0xf0, 0x47,
+	0x03, 0xea, 0x39, 0x1f, 0x1d, 0xf4, 0x2b, 0x91, 0x1d, 0xa9, 0xdf, 0x03, 0x68, 0x7a, 0x96, 0x95,
+	0xcd, 0x8e, 0x64, 0x9b, 0x4a, 0x3d, 0x32, 0x25, 0x9f, 0x1c, 0xc0, 0x92, 0x58, 0xac, 0x52, 0xbf,
+	0x79, 0xc8, 0xf8, 0x2f, 0xad, 0xac, 0x3c, 0x38, 0x6c, 0xac, 0x66, 0x85, 0x89, 0x60, 0x7c, 0x09,
+	0x10, 0xff, 0x5a, 0x80, 0x10, 0x7d, 0xea, 0xa7, 0x03, 0xed, 0xb2, 0xce, 0xc9, 0x74, 0x42, 0xf0,
+	0x37, 0x7e, 0x6e, 0xef, 0xef, 0xc3, 0x92, 0x78, 0x09, 0x4f, 0x3e, 0x10, 0xcf, 0x7a, 0x45, 0x6f,
+	0xcf, 0x62, 0x46, 0xe7, 0x36, 0x9e, 0x25, 0xf8, 0x72, 0x3f, 0x10, 0xbf, 0x2f, 0x45, 0xd1, 0x9e,
+	0xfc, 0x71, 0xc4, 0x8c, 0xc7, 0xfb, 0x3b, 0xfa, 0xe5, 0x4f, 0xf0, 0xed, 0xb6, 0x3e, 0xf7, 0xe5,
+	0x3f, 0x9d, 0x99, 0x89, 0x39, 0xc9, 0x1f, 0xc3, 0xf2, 0xb1, 0xeb, 0x4f, 0x59, 0x43, 0xf4, 0xa9,
+	0xd7, 0xfc, 0xd9, 0xc6, 0xac, 0x73, 0xc1, 0xed, 0x0d, 0x2d, 0x23, 0x78, 0xf3, 0x07, 0x71, 0x53,
+	0x7a, 0x4b, 0xfe, 0x10, 0x5a, 0xd9, 0xc7, 0x7e, 0xa2, 0xe9, 0x73, 0xde, 0xff, 0xdb, 0x32, 0x1c,
+	0xa9, 0x39, 0x64, 0xf2, 0x61, 0x95, 0xf3, 0xfc, 0x48, 0xfe, 0x34, 0x81, 0x0c, 0xa0, 0x95, 0x7d,
+	0xdb, 0x27, 0x9a, 0x3e, 0xe7, 0xb9, 0x7f, 0xa6, 0x78, 0x79, 0x62, 0xa2, 0x6b, 0x59, 0xd9, 0x8f,
+	0x7d, 0x94, 0x22, 0xd6, 0xbf, 0xc5, 0xcc, 0x0d, 0x8d, 0x5c, 0xd3, 0x67, 0xdf, 0xd9, 0xda, 0x44,
+	0x9f, 0xba, 0x07, 0xa6, 0xd3, 0xdb, 0xc4, 0x86, 0x9e, 0xa8, 0xc5, 0x27, 0xf5, 0x3f, 0x80, 0xf8,
+	0xf7, 0xc7, 0xfd, 0x32, 0xff, 0x89, 0xf1, 0x6f, 0xfd, 0xef, 0x00, 0x7d, 0xbe, 0xb4, 0x19, 0x94,
+	0x2c, 0x00, 0x00,
 }
@@ -2,21 +2,28 @@ package main
 
 import (
 	"crypto/tls"
-	"crypto/x509"
 	"github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
 	"github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	"github.com/wonderlandcompute/server/wonderland"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"gopkg.in/yaml.v2"
 	"io/ioutil"
 	"log"
 	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
 type WonderlandServerConfig struct {
@@ -25,35 +32,213 @@ type WonderlandServerConfig struct {
 	CACert      string `yaml:"ca_cert"`
 	ListenOn    string `yaml:"listen_on"`
 	DatabaseURI string `yaml:"db_uri"`
+
+	// CRLFile optionally lists revoked client certificates
+	CRLFile string `yaml:"crl_file"`
+
+	// CAKey is the private key of CACert. When set, the server issues
+	// client certificates valid for at most CertMaxLifetime.
+	CAKey           string        `yaml:"ca_key"`
+	CertMaxLifetime time.Duration `yaml:"cert_max_lifetime"`
+
+	// HTTPListenOn enables the REST gateway when set
+	HTTPListenOn string `yaml:"http_listen_on"`
+
+	// MetricsListenOn enables the Prometheus /metrics endpoint when set
+	MetricsListenOn       string        `yaml:"metrics_listen_on"`
+	MetricsSampleInterval time.Duration `yaml:"metrics_sample_interval"`
+
+	Tracing wonderland.TracingConfig `yaml:"tracing"`
+
+	// SecretKey signs and checks HS256 bearer tokens. Token authentication
+	// is enabled when it or any of the Tokens keys is set.
+	SecretKey string                 `yaml:"secret_key"`
+	Tokens    wonderland.TokenConfig `yaml:"tokens"`
+
+	// HealthCheckInterval is how often the database is pinged for health checks
+	HealthCheckInterval time.Duration `yaml:"health_check_interval"`
+	// ShutdownTimeout is how long in-flight requests may run after SIGTERM
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+
+	// TLSReloadInterval is how often certificate, key, CA and CRL files are
+	// checked for changes
+	TLSReloadInterval time.Duration `yaml:"tls_reload_interval"`
+	// RevocationRefreshInterval is how often revoked serials are reloaded
+	// from the database
+	RevocationRefreshInterval time.Duration `yaml:"revocation_refresh_interval"`
+	// RoleBindingCacheTTL is how long the role bindings of a user are cached
+	RoleBindingCacheTTL time.Duration `yaml:"role_binding_cache_ttl"`
+
+	// AuditLogFile is where audit events are appended as JSON lines, in
+	// addition to the database
+	AuditLogFile string `yaml:"audit_log_file"`
+
+	// DeletedJobRetention is how long deleted jobs stay in the trash before
+	// they are purged, checked every TrashPurgeInterval
+	DeletedJobRetention time.Duration `yaml:"deleted_job_retention"`
+	TrashPurgeInterval  time.Duration `yaml:"trash_purge_interval"`
+
+	// Archive enables moving finished jobs to archive files when its
+	// directory is set
+	Archive wonderland.ArchiveConfig `yaml:"archive"`
+
+	// IdempotencyKeyTTL is how long CreateJob idempotency keys are remembered
+	IdempotencyKeyTTL time.Duration `yaml:"idempotency_key_ttl"`
+
+	// ScheduleInterval is how often due schedules are checked for jobs to
+	// create
+	ScheduleInterval time.Duration `yaml:"schedule_interval"`
+
+	// WatchdogInterval is how often jobs are checked for exceeding their
+	// max_runtime or deadline
+	WatchdogInterval time.Duration `yaml:"watchdog_interval"`
+
+	// MaxJobLogSize is how many bytes of the newest logs are kept per job
+	MaxJobLogSize int64 `yaml:"max_job_log_size"`
+
+	// WebhookDeliveryInterval is how often due webhook deliveries are sent
+	WebhookDeliveryInterval time.Duration `yaml:"webhook_delivery_interval"`
+	// WebhookMaxAttempts is how often a delivery is tried before it is
+	// dead-lettered
+	WebhookMaxAttempts uint32 `yaml:"webhook_max_attempts"`
+	// WebhookDeliveryRetention is how long delivered and dead deliveries
+	// are kept
+	WebhookDeliveryRetention time.Duration `yaml:"webhook_delivery_retention"`
+	// WebhookAllowPrivateAddresses lets webhooks reach loopback, private
+	// and link-local addresses
+	WebhookAllowPrivateAddresses bool `yaml:"webhook_allow_private_addresses"`
+
+	// EventRetention is how long events of the job change feed are kept,
+	// compacted every EventCompactionInterval
+	EventRetention          time.Duration `yaml:"event_retention"`
+	EventCompactionInterval time.Duration `yaml:"event_compaction_interval"`
 }
 
 const maxMessageSizeInBytes = 5 * 1024 * 1024 * 1024
+const defaultMetricsSampleInterval = 15 * time.Second
+const defaultHealthCheckInterval = 5 * time.Second
+const defaultShutdownTimeout = 30 * time.Second
+const defaultTLSReloadInterval = time.Minute
+const defaultRevocationRefreshInterval = 30 * time.Second
+const defaultRoleBindingCacheTTL = 30 * time.Second
+const defaultDeletedJobRetention = 7 * 24 * time.Hour
+const defaultTrashPurgeInterval = time.Hour
+const defaultScheduleInterval = 10 * time.Second
+const defaultWatchdogInterval = 30 * time.Second
+const defaultWebhookDeliveryInterval = 5 * time.Second
+const defaultEventRetention = 7 * 24 * time.Hour
+const defaultEventCompactionInterval = time.Hour
 
 var Config *WonderlandServerConfig
 
-func getTransportCredentials() (*credentials.TransportCredentials, error) {
-	peerCert, err := tls.LoadX509KeyPair(Config.ServerCert, Config.ServerKey)
+func getTLSReloader() (*wonderland.TLSReloader, error) {
+	// clients authenticating with a bearer token have no certificate
+	clientAuth := tls.RequireAndVerifyClientCert
+	if tokensEnabled() {
+		clientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return wonderland.NewTLSReloader(wonderland.TLSFiles{
+		ServerCert: Config.ServerCert,
+		ServerKey:  Config.ServerKey,
+		CACert:     Config.CACert,
+		CRLFile:    Config.CRLFile,
+	}, clientAuth)
+}
+
+func tokensEnabled() bool {
+	return Config.SecretKey != "" || len(Config.Tokens.PublicKeys) > 0 || Config.Tokens.JWKSFile != ""
+}
+
+func serveGateway(server *wonderland.Server, tlsConfig *tls.Config) *http.Server {
+	gateway, err := wonderland.NewGateway(context.Background(), server)
 	if err != nil {
-		return nil, err
+		log.Fatalf("failed to create REST gateway: %v", err)
 	}
+	httpServer := &http.Server{
+		Addr:      Config.HTTPListenOn,
+		Handler:   gateway,
+		TLSConfig: tlsConfig,
+	}
+
+	go func() {
+		log.Printf("REST gateway started on %s", Config.HTTPListenOn)
+		if err := httpServer.ListenAndServeTLS("", ""); err != http.ErrServerClosed {
+			log.Fatalf("failed to serve REST gateway: %v", err)
+		}
+	}()
+	return httpServer
+}
 
-	caCert, err := ioutil.ReadFile(Config.CACert)
+func serveMetrics(ctx context.Context, storage *wonderland.WonderlandStorage, healthChecker *wonderland.HealthChecker) *http.Server {
+	err := storage.RegisterMetrics()
 	if err != nil {
-		return nil, err
+		log.Fatalf("failed to register metrics: %v", err)
+	}
+
+	interval := Config.MetricsSampleInterval
+	if interval == 0 {
+		interval = defaultMetricsSampleInterval
 	}
+	go storage.SampleQueueMetrics(ctx, interval)
 
-	caCertPool := x509.NewCertPool()
-	caCertPool.AppendCertsFromPEM(caCert)
-	tc := credentials.NewTLS(&tls.Config{
-		Certificates: []tls.Certificate{peerCert},
-		ClientCAs:    caCertPool,
-		ClientAuth:   tls.RequireAndVerifyClientCert,
-	})
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/readyz", healthChecker)
+	httpServer := &http.Server{
+		Addr:    Config.MetricsListenOn,
+		Handler: mux,
+	}
 
-	return &tc, nil
+	go func() {
+		log.Printf("Metrics endpoint started on %s", Config.MetricsListenOn)
+		if err := httpServer.ListenAndServe(); err != http.ErrServerClosed {
+			log.Fatalf("failed to serve metrics: %v", err)
+		}
+	}()
+	return httpServer
 }
 
-func main() {
+// waitForShutdown blocks until SIGINT or SIGTERM, then stops accepting new
+// requests and gives in-flight ones ShutdownTimeout to finish.
+func waitForShutdown(s *grpc.Server, server *wonderland.Server, healthChecker *wonderland.HealthChecker, gateway *http.Server) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-signals
+	log.Printf("Received %v, shutting down", sig)
+
+	timeout := Config.ShutdownTimeout
+	if timeout == 0 {
+		timeout = defaultShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Report NOT_SERVING so that load balancers stop sending new requests,
+	// and let streaming RPCs return.
+	healthChecker.Shutdown()
+	server.Drain()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	if gateway != nil {
+		if err := gateway.Shutdown(ctx); err != nil {
+			log.Printf("failed to shut down REST gateway: %v", err)
+		}
+	}
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Print("Shutdown timeout reached, closing remaining connections")
+		s.Stop()
+	}
+}
+
+func loadConfig() {
 	Config = &WonderlandServerConfig{}
 	config_path := os.Getenv("WONDERLAND_CONFIG_1")
 	content, err := ioutil.ReadFile(config_path)
@@ -65,19 +250,122 @@ func main() {
 	if err != nil {
 		log.Fatalf("Error parsing config: %v", err)
 	}
+}
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "ca" {
+		os.Exit(caCommand(os.Args[2:]))
+	}
+	loadConfig()
+
+	shutdownTracing, err := wonderland.SetupTracing(context.Background(), Config.Tracing)
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
 
 	storage, err := wonderland.NewWonderlandStorage(Config.DatabaseURI)
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer storage.Close()
+	storage.IdempotencyKeyTTL = Config.IdempotencyKeyTTL
+	storage.MaxJobLogSize = Config.MaxJobLogSize
+	if Config.AuditLogFile != "" {
+		storage.AuditSink, err = wonderland.OpenAuditFileSink(Config.AuditLogFile)
+		if err != nil {
+			log.Fatalf("failed to open the audit log: %v", err)
+		}
+		defer storage.AuditSink.Close()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	lis, err := net.Listen("tcp", Config.ListenOn)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
+	roleBindingCacheTTL := Config.RoleBindingCacheTTL
+	if roleBindingCacheTTL == 0 {
+		roleBindingCacheTTL = defaultRoleBindingCacheTTL
+	}
 	server := &wonderland.Server{
-		Storage: storage,
+		Storage:      storage,
+		RoleBindings: wonderland.NewRoleBindingCache(storage, roleBindingCacheTTL),
+		Revocations:  wonderland.NewRevocationList(),
+	}
+	revocationInterval := Config.RevocationRefreshInterval
+	if revocationInterval == 0 {
+		revocationInterval = defaultRevocationRefreshInterval
+	}
+	// revoked certificates must not be accepted until the first refresh
+	err = server.Revocations.Refresh(ctx, storage)
+	if err != nil {
+		log.Fatalf("failed to load revoked certificates: %v", err)
+	}
+	go server.Revocations.Run(ctx, storage, revocationInterval)
+	deletedJobRetention := Config.DeletedJobRetention
+	if deletedJobRetention == 0 {
+		deletedJobRetention = defaultDeletedJobRetention
+	}
+	trashPurgeInterval := Config.TrashPurgeInterval
+	if trashPurgeInterval == 0 {
+		trashPurgeInterval = defaultTrashPurgeInterval
+	}
+	go storage.PurgeTrash(ctx, deletedJobRetention, trashPurgeInterval)
+	if Config.Archive.Dir != "" {
+		server.Archiver, err = wonderland.NewArchiver(storage, Config.Archive)
+		if err != nil {
+			log.Fatalf("failed to set up archiving: %v", err)
+		}
+		go server.Archiver.Run(ctx)
+	}
+	scheduleInterval := Config.ScheduleInterval
+	if scheduleInterval == 0 {
+		scheduleInterval = defaultScheduleInterval
+	}
+	go storage.RunScheduler(ctx, scheduleInterval)
+	watchdogInterval := Config.WatchdogInterval
+	if watchdogInterval == 0 {
+		watchdogInterval = defaultWatchdogInterval
+	}
+	go storage.RunWatchdog(ctx, watchdogInterval)
+	webhookDeliveryInterval := Config.WebhookDeliveryInterval
+	if webhookDeliveryInterval == 0 {
+		webhookDeliveryInterval = defaultWebhookDeliveryInterval
+	}
+	dispatcher := wonderland.NewWebhookDispatcher(storage)
+	if Config.WebhookMaxAttempts != 0 {
+		dispatcher.MaxAttempts = Config.WebhookMaxAttempts
+	}
+	if Config.WebhookDeliveryRetention != 0 {
+		dispatcher.Retention = Config.WebhookDeliveryRetention
+	}
+	dispatcher.AllowPrivateAddresses = Config.WebhookAllowPrivateAddresses
+	go dispatcher.Run(ctx, webhookDeliveryInterval)
+	eventRetention := Config.EventRetention
+	if eventRetention == 0 {
+		eventRetention = defaultEventRetention
+	}
+	eventCompactionInterval := Config.EventCompactionInterval
+	if eventCompactionInterval == 0 {
+		eventCompactionInterval = defaultEventCompactionInterval
+	}
+	go storage.CompactEvents(ctx, eventRetention, eventCompactionInterval)
+	if Config.CAKey != "" {
+		server.CA, err = loadCertificateAuthority()
+		if err != nil {
+			log.Fatalf("failed to load certificate authority: %v", err)
+		}
+	}
+	if tokensEnabled() {
+		server.SecretKey = []byte(Config.SecretKey)
+		server.Tokens, err = wonderland.NewTokenAuth(server.SecretKey, Config.Tokens)
+		if err != nil {
+			log.Fatalf("failed to set up token authentication: %v", err)
+		}
 	}
 
 	logger := &logrus.Logger{
@@ -88,32 +376,65 @@ func main() {
 	}
 
 	logrusEntry := logrus.NewEntry(logger)
-	transportCredentials, err := getTransportCredentials()
+	tlsReloader, err := getTLSReloader()
 	if err != nil {
 		log.Fatalf("failed to get credentials: %v", err)
 	}
+	tlsReloadInterval := Config.TLSReloadInterval
+	if tlsReloadInterval == 0 {
+		tlsReloadInterval = defaultTLSReloadInterval
+	}
+	go tlsReloader.Run(ctx, tlsReloadInterval)
+	tlsConfig := tlsReloader.TLSConfig()
 
 	s := grpc.NewServer(
 		grpc.MaxRecvMsgSize(maxMessageSizeInBytes),
 		grpc.MaxSendMsgSize(maxMessageSizeInBytes),
-		grpc.Creds(*transportCredentials),
+		grpc.Creds(credentials.NewTLS(tlsConfig)),
 		grpc_middleware.WithUnaryServerChain(
+			wonderland.UnaryServerMetricsInterceptor(),
+			otelgrpc.UnaryServerInterceptor(),
 			grpc_ctxtags.UnaryServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
 			grpc_logrus.UnaryServerInterceptor(logrusEntry),
-			grpc_auth.UnaryServerInterceptor(nil),
+			grpc_auth.UnaryServerInterceptor(server.AuthenticatePeer),
 		),
 		grpc_middleware.WithStreamServerChain(
+			wonderland.StreamServerMetricsInterceptor(),
+			otelgrpc.StreamServerInterceptor(),
 			grpc_ctxtags.StreamServerInterceptor(grpc_ctxtags.WithFieldExtractor(grpc_ctxtags.CodeGenRequestFieldExtractor)),
 			grpc_logrus.StreamServerInterceptor(logrusEntry),
-			grpc_auth.StreamServerInterceptor(nil),
+			grpc_auth.StreamServerInterceptor(server.AuthenticatePeer),
 		),
 	)
 	wonderland.RegisterWonderlandServer(s, server)
 
+	healthInterval := Config.HealthCheckInterval
+	if healthInterval == 0 {
+		healthInterval = defaultHealthCheckInterval
+	}
+	healthChecker := wonderland.NewHealthChecker(storage)
+	go healthChecker.Run(ctx, healthInterval)
+	healthpb.RegisterHealthServer(s, healthChecker)
+
 	// Register reflection service on gRPC server.
 	reflection.Register(s)
-	log.Print("Server started")
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+
+	var gateway *http.Server
+	if Config.HTTPListenOn != "" {
+		gateway = serveGateway(server, tlsConfig)
+	}
+	if Config.MetricsListenOn != "" {
+		metrics := serveMetrics(ctx, storage, healthChecker)
+		defer metrics.Close()
 	}
+
+	go func() {
+		log.Print("Server started")
+		if err := s.Serve(lis); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+	}()
+
+	waitForShutdown(s, server, healthChecker, gateway)
+	log.Print("Server stopped")
 }